@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Proofsuite/amp-matching-engine/utils"
 	"github.com/go-ozzo/ozzo-validation"
@@ -35,18 +36,57 @@ type appConfig struct {
 	MakeFee float64 `mapstructure:"make_fee"`
 	// the take fee is the percentage to charged from maker
 	TakeFee float64 `mapstructure:"take_fee"`
-	// the signing method for JWT. Defaults to "HS256"
-	JWTSigningMethod string `mapstructure:"jwt_signing_method"`
-	// JWT signing key. required.
-	JWTSigningKey string `mapstructure:"jwt_signing_key"`
-	// JWT verification key. required.
-	JWTVerificationKey string `mapstructure:"jwt_verification_key"`
+	// the maximum difference (in seconds) between the timestamp of an API key signed request and the server time. Defaults to 30
+	APIKeyTimestampWindow int `mapstructure:"api_key_timestamp_window"`
+	// the lifetime (in seconds) of the challenges signed to create API keys. Defaults to 300
+	APIKeyChallengeTTL int `mapstructure:"api_key_challenge_ttl"`
 	// TickDuration is user by tick streaming cron
 	TickDuration map[string][]int64 `mapstructure:"tick_duration"`
+	// the gas ceiling of a single settlement batch. Defaults to 4000000
+	MaxBatchGas uint64 `mapstructure:"max_batch_gas"`
+	// the number of settlement batches an operator queue holds before new matches are held back. Defaults to 10
+	MaxQueueLength int `mapstructure:"max_queue_length"`
+	// the number of matches the operator holds back before new matches are rejected. Defaults to 10000
+	MaxPendingMatches int `mapstructure:"max_pending_matches"`
+	// the ETH balance (in wei) under which an operator wallet is removed from rotation. Defaults to 0.05 ETH
+	MinOperatorBalance string `mapstructure:"min_operator_balance"`
+	// the ETH balance (in wei) under which a low balance alert is raised for an operator wallet. Defaults to 0.5 ETH
+	LowOperatorBalance string `mapstructure:"low_operator_balance"`
+
+	// the token bucket rate limits applied to each IP, API key and account address, for the order placements,
+	// the order cancels and the other (market data) requests. Defaults to 10, 20 and 20 requests per second
+	OrderRateLimit      RateLimit `mapstructure:"order_rate_limit"`
+	CancelRateLimit     RateLimit `mapstructure:"cancel_rate_limit"`
+	MarketDataRateLimit RateLimit `mapstructure:"market_data_rate_limit"`
+	// identify the clients by the X-Forwarded-For header instead of the connection address, when
+	// the server is behind a reverse proxy. Defaults to false
+	TrustProxyHeaders bool `mapstructure:"trust_proxy_headers"`
+
+	// file listing the addresses that are not allowed to trade, one address per line
+	DenyListFile string `mapstructure:"deny_list_file"`
+
+	// the directory of the encrypted keystore holding the admin and operator accounts. Defaults to "./keystore"
+	KeystoreDir string `mapstructure:"keystore_dir"`
+	// the passphrase unlocking the keystore accounts. It is only read from the environment
+	KeystorePassphrase string `mapstructure:"-"`
+	// the url of an external signing service (JSON-RPC eth_signTransaction). When set, operator
+	// transactions are signed by this service instead of the local keystore
+	RemoteSignerURL string `mapstructure:"remote_signer_url"`
 
 	Logs map[string]string `mapstructure:"logs"`
 
 	Ethereum map[string]string `mapstructure:"ethereum"`
+	// the addresses of the other exchange contract deployments still settling orders, for example the
+	// previous version of the contract during a migration. Pairs are bound to one of these contracts
+	// or to the default exchange contract (ethereum.exchange_address)
+	ExchangeAddresses []string `mapstructure:"exchange_addresses"`
+	// the addresses of the exchange contracts that verify EIP-712 typed data signatures. Orders
+	// signed as typed data are only accepted on the pairs bound to one of these contracts
+	EIP712ExchangeAddresses []string `mapstructure:"eip712_exchange_addresses"`
+	// the chain ID of the network the exchange contract is deployed on. It is part of the EIP-712
+	// domain of the orders and cancels signed as typed data. Legacy signed orders do not cover the
+	// chain ID and are rejected once it is set, EIP-712 signed orders are rejected while it is not.
+	ChainID int64 `mapstructure:"chain_id"`
 
 	EnableTLS    bool   `mapstructure:"enable_tls"`
 	ServerCACert string `mapstructure:"server_ca_cert"`
@@ -58,12 +98,32 @@ type appConfig struct {
 	RabbitMQCert string `mapstructure:"rabbitmq_key"`
 }
 
+// RateLimit is a token bucket rate limit: Rate requests per second with bursts of up to Burst
+// requests. A negative rate disables the limit.
+type RateLimit struct {
+	Rate  float64 `mapstructure:"rate"`
+	Burst int     `mapstructure:"burst"`
+}
+
 func (config appConfig) Validate() error {
 	return validation.ValidateStruct(&config,
 		validation.Field(&config.MongoURL, validation.Required),
 	)
 }
 
+// Exchanges returns the addresses of all the exchange contracts, starting with the default exchange contract
+func (config appConfig) Exchanges() []string {
+	exchanges := []string{config.Ethereum["exchange_address"]}
+	for _, a := range config.ExchangeAddresses {
+		a = strings.TrimSpace(a)
+		if a != "" && !strings.EqualFold(a, config.Ethereum["exchange_address"]) {
+			exchanges = append(exchanges, a)
+		}
+	}
+
+	return exchanges
+}
+
 // LoadConfig loads configuration from the given list of paths and populates it into the Config variable.
 // The configuration file(s) should be named as app.yaml.
 // Environment variables with the prefix "RESTFUL_" in their names are also read automatically.
@@ -94,6 +154,64 @@ func LoadConfig(configPath string, env string) error {
 	Config.ServerPort = 8081
 	Config.ErrorFile = "config/errors.yaml"
 
+	//Operator Configuration
+	if Config.MaxBatchGas == 0 {
+		Config.MaxBatchGas = 4000000
+	}
+
+	if Config.MaxQueueLength == 0 {
+		Config.MaxQueueLength = 10
+	}
+
+	if Config.MaxPendingMatches == 0 {
+		Config.MaxPendingMatches = 10000
+	}
+
+	if Config.MinOperatorBalance == "" {
+		Config.MinOperatorBalance = "50000000000000000"
+	}
+
+	if Config.LowOperatorBalance == "" {
+		Config.LowOperatorBalance = "500000000000000000"
+	}
+
+	//API Key Configuration
+	if Config.APIKeyTimestampWindow == 0 {
+		Config.APIKeyTimestampWindow = 30
+	}
+
+	if Config.APIKeyChallengeTTL == 0 {
+		Config.APIKeyChallengeTTL = 300
+	}
+
+	//Rate Limit Configuration
+	if Config.OrderRateLimit.Rate == 0 {
+		Config.OrderRateLimit = RateLimit{Rate: 10, Burst: 20}
+	}
+
+	if Config.CancelRateLimit.Rate == 0 {
+		Config.CancelRateLimit = RateLimit{Rate: 20, Burst: 40}
+	}
+
+	if Config.MarketDataRateLimit.Rate == 0 {
+		Config.MarketDataRateLimit = RateLimit{Rate: 20, Burst: 50}
+	}
+
+	//Keystore Configuration
+	if dir := v.GetString("KEYSTORE_DIR"); dir != "" {
+		Config.KeystoreDir = dir
+	}
+
+	if Config.KeystoreDir == "" {
+		Config.KeystoreDir = "./keystore"
+	}
+
+	Config.KeystorePassphrase = v.GetString("KEYSTORE_PASSPHRASE")
+
+	if url := v.GetString("REMOTE_SIGNER_URL"); url != "" {
+		Config.RemoteSignerURL = url
+	}
+
 	//RabbitMQ Configuration
 	Config.RabbitMQURL = v.Get("RABBITMQ_URL").(string)
 
@@ -140,10 +258,27 @@ func LoadConfig(configPath string, env string) error {
 	Config.Ethereum["exchange_address"] = v.Get("EXCHANGE_CONTRACT_ADDRESS").(string)
 	Config.Ethereum["fee_account"] = v.Get("FEE_ACCOUNT_ADDRESS").(string)
 
+	if addresses := v.GetString("EXCHANGE_CONTRACT_ADDRESSES"); addresses != "" {
+		Config.ExchangeAddresses = strings.Split(addresses, ",")
+	}
+
+	if addresses := v.GetString("EIP712_EXCHANGE_CONTRACT_ADDRESSES"); addresses != "" {
+		Config.EIP712ExchangeAddresses = strings.Split(addresses, ",")
+	}
+
+	if chainID := v.GetInt64("CHAIN_ID"); chainID != 0 {
+		Config.ChainID = chainID
+	}
+
 	logger.Infof("Server port: %v", Config.ServerPort)
 	logger.Infof("Ethereum node HTTP url: %v", Config.Ethereum["http_url"])
 	logger.Infof("Ethereum node WS url: %v", Config.Ethereum["ws_url"])
 	logger.Infof("Exchange contract address: %v", Config.Ethereum["exchange_address"])
+	logger.Infof("Exchange contract addresses: %v", Config.Exchanges())
+	logger.Infof("EIP-712 exchange contract addresses: %v", Config.EIP712ExchangeAddresses)
+	logger.Infof("Chain ID: %v", Config.ChainID)
+	logger.Infof("Keystore directory: %v", Config.KeystoreDir)
+	logger.Infof("Remote signer url: %v", Config.RemoteSignerURL)
 	logger.Infof("MongoDB url: %v", Config.MongoURL)
 	logger.Infof("MongoUserName: %v", Config.MongoDBUsername)
 	logger.Infof("MongoShardURL2: %v", Config.MongoDBShardURL1)
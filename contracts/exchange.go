@@ -10,6 +10,7 @@ import (
 
 	"github.com/Proofsuite/amp-matching-engine/contracts/contractsinterfaces"
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/signer"
 	"github.com/Proofsuite/amp-matching-engine/types"
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -78,8 +79,13 @@ func (e *Exchange) DefaultTxOptions() (*bind.TransactOpts, error) {
 		return nil, err
 	}
 
-	opts := bind.NewKeyedTransactor(wallet.PrivateKey)
-	return opts, nil
+	sig, err := signer.GetSigner(wallet)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return signer.NewTransactor(sig), nil
 }
 
 func (e *Exchange) GetTxCallOptions() *bind.CallOpts {
@@ -135,27 +141,17 @@ func (e *Exchange) Operator(a common.Address) (bool, error) {
 	return isOperator, nil
 }
 
+// ExecuteBatchTrades settles the matches of a single taker order in one executeBatchTrades transaction
 func (e *Exchange) ExecuteBatchTrades(matches *types.Matches, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
-	orderValues := [][10]*big.Int{}
-	orderAddresses := [][4]common.Address{}
-	vValues := [][2]uint8{}
-	rsValues := [][4][32]byte{}
-	amounts := []*big.Int{}
-
-	makerOrders := matches.MakerOrders
-	trades := matches.Trades
-	takerOrder := matches.TakerOrder
-
-	for i, _ := range makerOrders {
-		mo := makerOrders[i]
-		to := takerOrder
-		t := trades[i]
+	return e.ExecuteTradeBatch(types.NewPendingTradeBatch(matches), txOpts)
+}
 
-		orderValues = append(orderValues, [10]*big.Int{mo.Amount, mo.PricePoint, mo.EncodedSide(), mo.Nonce, to.Amount, to.PricePoint, to.EncodedSide(), to.Nonce, mo.MakeFee, mo.TakeFee})
-		orderAddresses = append(orderAddresses, [4]common.Address{mo.UserAddress, to.UserAddress, mo.BaseToken, to.QuoteToken})
-		vValues = append(vValues, [2]uint8{mo.Signature.V, to.Signature.V})
-		rsValues = append(rsValues, [4][32]byte{mo.Signature.R, mo.Signature.S, to.Signature.R, to.Signature.S})
-		amounts = append(amounts, t.Amount)
+// ExecuteTradeBatch settles the matches of one or several taker orders in one executeBatchTrades transaction
+func (e *Exchange) ExecuteTradeBatch(batch *types.PendingTradeBatch, txOpts *bind.TransactOpts) (*eth.Transaction, error) {
+	orderValues, orderAddresses, amounts, vValues, rsValues, err := batchTradesArguments(batch)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
 	}
 
 	tx, err := e.Interface.ExecuteBatchTrades(txOpts, orderValues, orderAddresses, amounts, vValues, rsValues)
@@ -190,36 +186,18 @@ func (e *Exchange) Trade(match *types.Matches, txOpts *bind.TransactOpts) (*eth.
 	return tx, nil
 }
 
+// CallBatchTrades estimates the gas required to settle the matches of a single taker order
 func (e *Exchange) CallBatchTrades(matches *types.Matches, call *ethereum.CallMsg) (uint64, error) {
-	orderValues := [][10]*big.Int{}
-	orderAddresses := [][4]common.Address{}
-	amounts := []*big.Int{}
-	vValues := [][2]uint8{}
-	rsValues := [][4][32]byte{}
-
-	makerOrders := matches.MakerOrders
-	trades := matches.Trades
-	takerOrder := matches.TakerOrder
-
-	for i, _ := range makerOrders {
-		mo := makerOrders[i]
-		to := takerOrder
-		t := trades[i]
-
-		orderValues = append(orderValues, [10]*big.Int{mo.Amount, mo.PricePoint, mo.EncodedSide(), mo.Nonce, to.Amount, to.PricePoint, to.EncodedSide(), to.Nonce, mo.MakeFee, mo.TakeFee})
-		orderAddresses = append(orderAddresses, [4]common.Address{mo.UserAddress, to.UserAddress, mo.BaseToken, mo.QuoteToken})
-		amounts = append(amounts, t.Amount)
-
-		if mo.Signature == nil {
-			return 0, errors.New("Maker order is not signed")
-		}
-
-		if to.Signature == nil {
-			return 0, errors.New("Taker order is not signed")
-		}
+	return e.CallTradeBatch(types.NewPendingTradeBatch(matches), call)
+}
 
-		vValues = append(vValues, [2]uint8{mo.Signature.V, to.Signature.V})
-		rsValues = append(rsValues, [4][32]byte{mo.Signature.R, mo.Signature.S, to.Signature.R, to.Signature.S})
+// CallTradeBatch estimates the gas required to settle the matches of one or several taker orders
+// in one executeBatchTrades transaction
+func (e *Exchange) CallTradeBatch(batch *types.PendingTradeBatch, call *ethereum.CallMsg) (uint64, error) {
+	orderValues, orderAddresses, amounts, vValues, rsValues, err := batchTradesArguments(batch)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
 	}
 
 	exchangeABI, err := abi.JSON(strings.NewReader(contractsinterfaces.ExchangeABI))
@@ -232,13 +210,6 @@ func (e *Exchange) CallBatchTrades(matches *types.Matches, call *ethereum.CallMs
 		return 0, err
 	}
 
-	// call.Data = data
-	// b, err := e.Client.PendingCallContract(context.Background(), *call)
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return 0, err
-	// }
-
 	call.Data = data
 	gasLimit, err := e.Client.(bind.ContractBackend).EstimateGas(context.Background(), *call)
 	if err != nil {
@@ -249,6 +220,82 @@ func (e *Exchange) CallBatchTrades(matches *types.Matches, call *ethereum.CallMs
 	return gasLimit, nil
 }
 
+// SimulateTradeBatch calls executeBatchTrades against the latest state of the chain without sending
+// a transaction. It returns false if the contract rejected the batch, which happens when one of the
+// trades could not be settled.
+func (e *Exchange) SimulateTradeBatch(batch *types.PendingTradeBatch, call *ethereum.CallMsg) (bool, error) {
+	orderValues, orderAddresses, amounts, vValues, rsValues, err := batchTradesArguments(batch)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	exchangeABI, err := abi.JSON(strings.NewReader(contractsinterfaces.ExchangeABI))
+	if err != nil {
+		return false, err
+	}
+
+	data, err := exchangeABI.Pack("executeBatchTrades", orderValues, orderAddresses, amounts, vValues, rsValues)
+	if err != nil {
+		return false, err
+	}
+
+	call.Data = data
+	output, err := e.Client.(bind.ContractBackend).CallContract(context.Background(), *call, nil)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	// a reverted call does not return any output
+	if len(output) == 0 {
+		return false, nil
+	}
+
+	var success bool
+	err = exchangeABI.Unpack(&success, "executeBatchTrades", output)
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	return success, nil
+}
+
+// batchTradesArguments flattens the matches of a batch into the arguments of the executeBatchTrades
+// contract function. Each trade of the batch carries its own maker and taker order
+func batchTradesArguments(batch *types.PendingTradeBatch) ([][10]*big.Int, [][4]common.Address, []*big.Int, [][2]uint8, [][4][32]byte, error) {
+	orderValues := [][10]*big.Int{}
+	orderAddresses := [][4]common.Address{}
+	amounts := []*big.Int{}
+	vValues := [][2]uint8{}
+	rsValues := [][4][32]byte{}
+
+	for _, matches := range batch.Matches {
+		to := matches.TakerOrder
+
+		for i, mo := range matches.MakerOrders {
+			t := matches.Trades[i]
+
+			if mo.Signature == nil {
+				return nil, nil, nil, nil, nil, errors.New("Maker order is not signed")
+			}
+
+			if to.Signature == nil {
+				return nil, nil, nil, nil, nil, errors.New("Taker order is not signed")
+			}
+
+			orderValues = append(orderValues, [10]*big.Int{mo.Amount, mo.PricePoint, mo.EncodedSide(), mo.Nonce, to.Amount, to.PricePoint, to.EncodedSide(), to.Nonce, mo.MakeFee, mo.TakeFee})
+			orderAddresses = append(orderAddresses, [4]common.Address{mo.UserAddress, to.UserAddress, mo.BaseToken, mo.QuoteToken})
+			amounts = append(amounts, t.Amount)
+			vValues = append(vValues, [2]uint8{mo.Signature.V, to.Signature.V})
+			rsValues = append(rsValues, [4][32]byte{mo.Signature.R, mo.Signature.S, to.Signature.R, to.Signature.S})
+		}
+	}
+
+	return orderValues, orderAddresses, amounts, vValues, rsValues, nil
+}
+
 func (e *Exchange) CallTrade(match *types.Matches, call *ethereum.CallMsg) (uint64, error) {
 	mo := match.MakerOrders[0]
 	to := match.TakerOrder
@@ -332,6 +379,84 @@ func (e *Exchange) ListenToBatchTrades() (chan *contractsinterfaces.ExchangeLogB
 	return events, nil
 }
 
+// FilterTrades returns the trade logs emitted by the exchange smart contract between the start
+// and end blocks (included). A nil end block stands for the latest block
+func (e *Exchange) FilterTrades(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogTrade, error) {
+	opts := &bind.FilterOpts{Start: start, End: end}
+
+	it, err := e.Interface.FilterLogTrade(opts, nil, nil, nil)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer it.Close()
+
+	events := []*contractsinterfaces.ExchangeLogTrade{}
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+
+	if it.Error() != nil {
+		logger.Error(it.Error())
+		return nil, it.Error()
+	}
+
+	return events, nil
+}
+
+// FilterBatchTrades returns the batch trade logs emitted by the exchange smart contract between the start
+// and end blocks (included). A nil end block stands for the latest block
+func (e *Exchange) FilterBatchTrades(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogBatchTrades, error) {
+	opts := &bind.FilterOpts{Start: start, End: end}
+
+	it, err := e.Interface.FilterLogBatchTrades(opts, nil)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer it.Close()
+
+	events := []*contractsinterfaces.ExchangeLogBatchTrades{}
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+
+	if it.Error() != nil {
+		logger.Error(it.Error())
+		return nil, it.Error()
+	}
+
+	return events, nil
+}
+
+// FilterErrors returns the error logs emitted by the exchange smart contract between the start
+// and end blocks (included). A nil end block stands for the latest block
+func (e *Exchange) FilterErrors(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogError, error) {
+	opts := &bind.FilterOpts{Start: start, End: end}
+
+	it, err := e.Interface.FilterLogError(opts)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer it.Close()
+
+	events := []*contractsinterfaces.ExchangeLogError{}
+	for it.Next() {
+		events = append(events, it.Event)
+	}
+
+	if it.Error() != nil {
+		logger.Error(it.Error())
+		return nil, it.Error()
+	}
+
+	return events, nil
+}
+
 func (e *Exchange) GetErrorEvents(logs chan *contractsinterfaces.ExchangeLogError) error {
 	opts := &bind.WatchOpts{nil, nil}
 
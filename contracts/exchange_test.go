@@ -31,7 +31,7 @@ func SetupTest() (*testutils.Deployer, *types.Wallet, common.Address, common.Add
 	walletDao.On("GetDefaultAdminWallet").Return(wallet, nil)
 
 	walletService := services.NewWalletService(walletDao)
-	txService := services.NewTxService(walletDao, wallet)
+	txService := services.NewTxService(walletService, wallet)
 
 	client := ethereum.NewSimulatedClient([]common.Address{wallet.Address, maker.Address, taker.Address})
 	deployer := testutils.NewDeployer(walletService, txService, client)
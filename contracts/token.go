@@ -49,7 +49,7 @@ func (t *Token) GetTxSendOptions() (*bind.TransactOpts, error) {
 	return t.TxService.GetTxSendOptions()
 }
 
-func (t *Token) GetCustomTxSendOptions(w *types.Wallet) *bind.TransactOpts {
+func (t *Token) GetCustomTxSendOptions(w *types.Wallet) (*bind.TransactOpts, error) {
 	return t.TxService.GetCustomTxSendOptions(w)
 }
 
@@ -87,7 +87,10 @@ func (t *Token) Transfer(receiver common.Address, amount *big.Int) (*eth.Transac
 }
 
 func (t *Token) TransferFromCustomWallet(w *types.Wallet, receiver common.Address, amount *big.Int) (*eth.Transaction, error) {
-	opts := t.GetCustomTxSendOptions(w)
+	opts, err := t.GetCustomTxSendOptions(w)
+	if err != nil {
+		return nil, err
+	}
 
 	tx, err := t.Interface.Transfer(opts, receiver, amount)
 	if err != nil {
@@ -131,7 +134,10 @@ func (t *Token) Approve(spender common.Address, amount *big.Int) (*eth.Transacti
 }
 
 func (t *Token) ApproveFrom(w *types.Wallet, spender common.Address, amount *big.Int) (*eth.Transaction, error) {
-	opts := t.GetCustomTxSendOptions(w)
+	opts, err := t.GetCustomTxSendOptions(w)
+	if err != nil {
+		return nil, err
+	}
 
 	tx, err := t.Interface.Approve(opts, spender, amount)
 	if err != nil {
@@ -35,7 +35,7 @@ func SetupTokenTest() (*testutils.Deployer, *types.Wallet) {
 	walletDao.On("GetDefaultAdminWallet").Return(wallet, nil)
 
 	walletService := services.NewWalletService(walletDao)
-	txService := services.NewTxService(walletDao, wallet)
+	txService := services.NewTxService(walletService, wallet)
 
 	client := ethereum.NewSimulatedClient([]common.Address{wallet.Address})
 	deployer := testutils.NewDeployer(walletService, txService, client)
@@ -164,6 +164,10 @@ func (dao *AccountDao) GetTokenBalance(owner common.Address, token common.Addres
 	if err != nil {
 		return nil, err
 	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
 	//
 	//a := &types.Account{}
 	//bytes, _ := bson.Marshal(res[0])
@@ -215,6 +219,72 @@ func (dao *AccountDao) UpdateAllowance(owner common.Address, token common.Addres
 	return err
 }
 
+// GetBlockedAddresses returns the addresses of the blocked accounts
+func (dao *AccountDao) GetBlockedAddresses() ([]common.Address, error) {
+	res := []types.Account{}
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"isBlocked": true}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	addresses := []common.Address{}
+	for _, a := range res {
+		addresses = append(addresses, a.Address)
+	}
+
+	return addresses, nil
+}
+
+// UpdateIsBlocked blocks or unblocks an account
+func (dao *AccountDao) UpdateIsBlocked(owner common.Address, blocked bool) error {
+	q := bson.M{
+		"address": owner.Hex(),
+	}
+
+	updateQuery := bson.M{
+		"$set": bson.M{"isBlocked": blocked, "updatedAt": time.Now()},
+	}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
+	return err
+}
+
+// SyncTokenBalance sets the balance and the allowance read from the chain and marks the token balance as synced
+func (dao *AccountDao) SyncTokenBalance(owner, token common.Address, tokenBalance *types.TokenBalance) error {
+	q := bson.M{
+		"address": owner.Hex(),
+	}
+
+	updateQuery := bson.M{
+		"$set": bson.M{
+			"tokenBalances." + token.Hex() + ".address":   token.Hex(),
+			"tokenBalances." + token.Hex() + ".symbol":    tokenBalance.Symbol,
+			"tokenBalances." + token.Hex() + ".balance":   tokenBalance.Balance.String(),
+			"tokenBalances." + token.Hex() + ".allowance": tokenBalance.Allowance.String(),
+			"tokenBalances." + token.Hex() + ".synced":    true,
+		},
+	}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
+	return err
+}
+
+// UnsyncTokenBalances marks the balances of the given token as unsynced for every account holding
+// one, so that they are read from the chain again instead of being trusted
+func (dao *AccountDao) UnsyncTokenBalances(token common.Address) error {
+	q := bson.M{
+		"tokenBalances." + token.Hex(): bson.M{"$exists": true},
+	}
+
+	updateQuery := bson.M{
+		"$set": bson.M{"tokenBalances." + token.Hex() + ".synced": false},
+	}
+
+	err := db.UpdateAll(dao.dbName, dao.collectionName, q, updateQuery)
+	return err
+}
+
 // Drop drops all the order documents in the current database
 func (dao *AccountDao) Drop() {
 	db.DropCollection(dao.dbName, dao.collectionName)
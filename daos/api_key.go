@@ -0,0 +1,101 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	mgo "github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// APIKeyDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type APIKeyDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAPIKeyDao returns a new instance of APIKeyDao
+func NewAPIKeyDao() *APIKeyDao {
+	dbName := app.Config.DBName
+	collection := "api_keys"
+
+	i1 := mgo.Index{
+		Key:    []string{"key"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"userAddress"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &APIKeyDao{collection, dbName}
+}
+
+// Create inserts a new API key in the db
+func (dao *APIKeyDao) Create(k *types.APIKey) error {
+	k.ID = bson.NewObjectId()
+	k.CreatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, k)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByKey returns the API key with the given key, or nil if it does not exist
+func (dao *APIKeyDao) GetByKey(key string) (*types.APIKey, error) {
+	res := []*types.APIKey{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{"key": key}, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// GetByUserAddress returns the API keys of an account
+func (dao *APIKeyDao) GetByUserAddress(a common.Address) ([]*types.APIKey, error) {
+	res := []*types.APIKey{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, bson.M{"userAddress": a.Hex()}, []string{"createdAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete removes an API key of an account
+func (dao *APIKeyDao) Delete(a common.Address, key string) error {
+	err := db.RemoveAll(dao.dbName, dao.collectionName, bson.M{"userAddress": a.Hex(), "key": key})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
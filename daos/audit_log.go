@@ -0,0 +1,88 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	mgo "github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// AuditLogDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type AuditLogDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewAuditLogDao returns a new instance of AuditLogDao
+func NewAuditLogDao() *AuditLogDao {
+	dbName := app.Config.DBName
+	collection := "audit_logs"
+
+	i1 := mgo.Index{
+		Key: []string{"target", "-createdAt"},
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"-createdAt"},
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AuditLogDao{collection, dbName}
+}
+
+// Create inserts a new audit log in the db
+func (dao *AuditLogDao) Create(l *types.AuditLog) error {
+	l.ID = bson.NewObjectId()
+	l.CreatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, l)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetLatest returns the most recent audit logs, optionally restricted to a target
+func (dao *AuditLogDao) GetLatest(target string, limit int) ([]*types.AuditLog, error) {
+	res := []*types.AuditLog{}
+
+	q := bson.M{}
+	if target != "" {
+		q["target"] = target
+	}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, 0, limit, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete removes an audit log. It is used to withdraw the log of an admin action that failed
+// after being recorded.
+func (dao *AuditLogDao) Delete(id bson.ObjectId) error {
+	err := db.RemoveAll(dao.dbName, dao.collectionName, bson.M{"_id": id})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
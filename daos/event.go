@@ -0,0 +1,228 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	mgo "github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// EventDao contains:
+// collectionName: MongoDB collection name of the indexed contract events
+// checkpointCollectionName: MongoDB collection name of the last processed block
+// dbName: name of mongodb to interact with
+type EventDao struct {
+	collectionName           string
+	checkpointCollectionName string
+	dbName                   string
+}
+
+// NewEventDao returns a new instance of EventDao
+func NewEventDao() *EventDao {
+	dbName := app.Config.DBName
+	collection := "events"
+
+	i1 := mgo.Index{
+		Key:    []string{"txHash", "logIndex"},
+		Unique: true,
+	}
+
+	i2 := mgo.Index{
+		Key: []string{"type", "blockNumber"},
+	}
+
+	i3 := mgo.Index{
+		Key:    []string{"tradeHash"},
+		Sparse: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i2)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i3)
+	if err != nil {
+		panic(err)
+	}
+
+	return &EventDao{collection, "event_checkpoints", dbName}
+}
+
+// Upsert inserts the event or replaces the event with the same transaction hash and log index.
+// Indexing the same event twice is therefore harmless
+func (dao *EventDao) Upsert(e *types.ContractEvent) error {
+	if e.ID == "" {
+		e.ID = bson.NewObjectId()
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+
+	query := bson.M{"txHash": e.TxHash.Hex(), "logIndex": e.LogIndex}
+	record, err := e.GetBSON()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	set := record.(types.ContractEventRecord)
+	update := bson.M{
+		"$set": bson.M{
+			"type":             set.Type,
+			"contractAddress":  set.ContractAddress,
+			"blockNumber":      set.BlockNumber,
+			"blockHash":        set.BlockHash,
+			"removed":          set.Removed,
+			"maker":            set.Maker,
+			"taker":            set.Taker,
+			"tokenSell":        set.TokenSell,
+			"tokenBuy":         set.TokenBuy,
+			"filledAmountSell": set.FilledAmountSell,
+			"filledAmountBuy":  set.FilledAmountBuy,
+			"paidFeeMake":      set.PaidFeeMake,
+			"paidFeeTake":      set.PaidFeeTake,
+			"orderHash":        set.OrderHash,
+			"tradeHash":        set.TradeHash,
+			"tokenPairHash":    set.TokenPairHash,
+			"makerOrderHashes": set.MakerOrderHashes,
+			"takerOrderHashes": set.TakerOrderHashes,
+			"errorId":          set.ErrorID,
+			"makerOrderHash":   set.MakerOrderHash,
+			"takerOrderHash":   set.TakerOrderHash,
+		},
+		"$setOnInsert": bson.M{
+			"_id":       set.ID,
+			"createdAt": set.CreatedAt,
+		},
+	}
+
+	err = db.Upsert(dao.dbName, dao.collectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByTxHash returns the events emitted in the given transaction
+func (dao *EventDao) GetByTxHash(h common.Hash) ([]*types.ContractEvent, error) {
+	q := bson.M{"txHash": h.Hex()}
+	res := []*types.ContractEvent{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"logIndex"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByBlockRange returns the events of the given type emitted between the start and end blocks (included).
+// Events that were removed from the canonical chain are not returned
+func (dao *EventDao) GetByBlockRange(eventType string, start, end uint64) ([]*types.ContractEvent, error) {
+	q := bson.M{
+		"type":        eventType,
+		"removed":     false,
+		"blockNumber": bson.M{"$gte": int64(start), "$lte": int64(end)},
+	}
+
+	res := []*types.ContractEvent{}
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"blockNumber", "logIndex"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RemoveReorged removes the events of the contract emitted between the start and end blocks (included)
+// in blocks that are not part of the canonical chain anymore. The given block hashes are the hashes of
+// the canonical blocks in which the contract emitted events over that range.
+func (dao *EventDao) RemoveReorged(contract common.Address, start, end uint64, blockHashes []common.Hash) error {
+	hashes := []string{}
+	for _, h := range blockHashes {
+		hashes = append(hashes, h.Hex())
+	}
+
+	q := bson.M{
+		"contractAddress": contract.Hex(),
+		"blockNumber":     bson.M{"$gte": int64(start), "$lte": int64(end)},
+		"blockHash":       bson.M{"$nin": hashes},
+	}
+
+	err := db.RemoveAll(dao.dbName, dao.collectionName, q)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetLastProcessedBlock returns the highest block for which the events of the contract have been
+// indexed. It returns 0 if no events have been indexed yet
+func (dao *EventDao) GetLastProcessedBlock(contract common.Address) (uint64, error) {
+	res := []struct {
+		BlockNumber int64 `bson:"blockNumber"`
+	}{}
+
+	err := db.Get(dao.dbName, dao.checkpointCollectionName, bson.M{"contractAddress": contract.Hex()}, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if len(res) == 0 {
+		return 0, nil
+	}
+
+	return uint64(res[0].BlockNumber), nil
+}
+
+// SetLastProcessedBlock records the given block as processed for the contract. The last processed
+// block is only updated if the given block is higher than the current one
+func (dao *EventDao) SetLastProcessedBlock(contract common.Address, n uint64) error {
+	query := bson.M{"contractAddress": contract.Hex()}
+	update := bson.M{
+		"$max": bson.M{"blockNumber": int64(n)},
+		"$set": bson.M{"updatedAt": time.Now()},
+	}
+
+	err := db.Upsert(dao.dbName, dao.checkpointCollectionName, query, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Drop drops all the events and the last processed block
+func (dao *EventDao) Drop() error {
+	err := db.DropCollection(dao.dbName, dao.collectionName)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = db.DropCollection(dao.dbName, dao.checkpointCollectionName)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
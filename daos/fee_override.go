@@ -0,0 +1,94 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	mgo "github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// FeeOverrideDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type FeeOverrideDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewFeeOverrideDao returns a new instance of FeeOverrideDao
+func NewFeeOverrideDao() *FeeOverrideDao {
+	dbName := app.Config.DBName
+	collection := "fee_overrides"
+
+	i1 := mgo.Index{
+		Key:    []string{"userAddress", "quoteToken"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	return &FeeOverrideDao{collection, dbName}
+}
+
+// Upsert creates or replaces the fee override of an account on a quote token
+func (dao *FeeOverrideDao) Upsert(o *types.FeeOverride) error {
+	existing, err := dao.GetByUserAddress(o.UserAddress, o.QuoteToken)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	o.ID = bson.NewObjectId()
+	o.CreatedAt = time.Now()
+	if existing != nil {
+		o.ID = existing.ID
+		o.CreatedAt = existing.CreatedAt
+	}
+
+	o.UpdatedAt = time.Now()
+	q := bson.M{"userAddress": o.UserAddress.Hex(), "quoteToken": o.QuoteToken.Hex()}
+
+	err = db.Upsert(dao.dbName, dao.collectionName, q, o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetByUserAddress returns the fee override of an account on a quote token, or nil if the
+// account has no override
+func (dao *FeeOverrideDao) GetByUserAddress(a, qt common.Address) (*types.FeeOverride, error) {
+	res := []*types.FeeOverride{}
+	q := bson.M{"userAddress": a.Hex(), "quoteToken": qt.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return res[0], nil
+}
+
+// Delete removes the fee override of an account on a quote token
+func (dao *FeeOverrideDao) Delete(a, qt common.Address) error {
+	err := db.RemoveAll(dao.dbName, dao.collectionName, bson.M{"userAddress": a.Hex(), "quoteToken": qt.Hex()})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
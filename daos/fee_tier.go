@@ -0,0 +1,90 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	mgo "github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// FeeTierDao contains:
+// collectionName: MongoDB collection name
+// dbName: name of mongodb to interact with
+type FeeTierDao struct {
+	collectionName string
+	dbName         string
+}
+
+// NewFeeTierDao returns a new instance of FeeTierDao
+func NewFeeTierDao() *FeeTierDao {
+	dbName := app.Config.DBName
+	collection := "fee_tiers"
+
+	i1 := mgo.Index{
+		Key:    []string{"quoteToken", "name"},
+		Unique: true,
+	}
+
+	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
+	if err != nil {
+		panic(err)
+	}
+
+	return &FeeTierDao{collection, dbName}
+}
+
+// Create inserts a new fee tier in the db
+func (dao *FeeTierDao) Create(t *types.FeeTier) error {
+	t.ID = bson.NewObjectId()
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+
+	err := db.Create(dao.dbName, dao.collectionName, t)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAll returns all the fee tiers
+func (dao *FeeTierDao) GetAll() ([]*types.FeeTier, error) {
+	res := []*types.FeeTier{}
+
+	err := db.Get(dao.dbName, dao.collectionName, bson.M{}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetByQuoteToken returns the fee tiers of a quote token
+func (dao *FeeTierDao) GetByQuoteToken(qt common.Address) ([]*types.FeeTier, error) {
+	res := []*types.FeeTier{}
+	q := bson.M{"quoteToken": qt.Hex()}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteByName removes the fee tier of a quote token with the given name
+func (dao *FeeTierDao) DeleteByName(qt common.Address, name string) error {
+	err := db.RemoveAll(dao.dbName, dao.collectionName, bson.M{"quoteToken": qt.Hex(), "name": name})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
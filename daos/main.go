@@ -1 +1,53 @@
 package daos
+
+import (
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/globalsign/mgo/bson"
+)
+
+// historySort is the sort order of paginated queries. It has to match the cursor
+// condition built by historyFilter.
+var historySort = []string{"-createdAt", "-_id"}
+
+// historyFilter builds the mongo query corresponding to a paginated history request.
+// conds holds the collection specific conditions (eg. the user address) that are combined
+// with the query filters. The time range includes 'from' and excludes 'to'.
+func historyFilter(q *types.HistoryQuery, conds ...bson.M) bson.M {
+	if q.HasPair() {
+		conds = append(conds, bson.M{"baseToken": q.BaseToken.Hex(), "quoteToken": q.QuoteToken.Hex()})
+	}
+
+	if q.Side != "" {
+		conds = append(conds, bson.M{"side": q.Side})
+	}
+
+	if q.Status != "" {
+		conds = append(conds, bson.M{"status": q.Status})
+	}
+
+	createdAt := bson.M{}
+	if !q.From.IsZero() {
+		createdAt["$gte"] = q.From
+	}
+
+	if !q.To.IsZero() {
+		createdAt["$lt"] = q.To
+	}
+
+	if len(createdAt) > 0 {
+		conds = append(conds, bson.M{"createdAt": createdAt})
+	}
+
+	if q.Cursor != nil {
+		conds = append(conds, bson.M{"$or": []bson.M{
+			{"createdAt": bson.M{"$lt": q.Cursor.CreatedAt}},
+			{"createdAt": q.Cursor.CreatedAt, "_id": bson.M{"$lt": q.Cursor.ID}},
+		}})
+	}
+
+	if len(conds) == 0 {
+		return bson.M{}
+	}
+
+	return bson.M{"$and": conds}
+}
@@ -15,9 +15,25 @@ import (
 // OrderDao contains:
 // collectionName: MongoDB collection name
 // dbName: name of mongodb to interact with
+// clientOrderIDCollectionName: MongoDB collection holding the client order IDs reserved by the orders
+// nonceCollectionName: MongoDB collection holding the nonces reserved by the orders
 type OrderDao struct {
-	collectionName string
-	dbName         string
+	collectionName              string
+	dbName                      string
+	clientOrderIDCollectionName string
+	nonceCollectionName         string
+}
+
+// clientOrderID records the order holding a client order ID of a user
+type clientOrderID struct {
+	ID        string `bson:"_id"`
+	OrderHash string `bson:"orderHash"`
+}
+
+// orderNonce records the order holding a nonce of a user
+type orderNonce struct {
+	ID        string `bson:"_id"`
+	OrderHash string `bson:"orderHash"`
 }
 
 type OrderDaoOption = func(*OrderDao) error
@@ -33,6 +49,8 @@ func OrderDaoDBOption(dbName string) func(dao *OrderDao) error {
 func NewOrderDao(opts ...OrderDaoOption) *OrderDao {
 	dao := &OrderDao{}
 	dao.collectionName = "orders"
+	dao.clientOrderIDCollectionName = "client_order_ids"
+	dao.nonceCollectionName = "order_nonces"
 	dao.dbName = app.Config.DBName
 
 	for _, op := range opts {
@@ -80,6 +98,22 @@ func NewOrderDao(opts ...OrderDaoOption) *OrderDao {
 		Key: []string{"baseToken", "quoteToken", "side", "status"},
 	}
 
+	i9 := mgo.Index{
+		Key: []string{"userAddress", "nonce"},
+	}
+
+	i10 := mgo.Index{
+		Key: []string{"userAddress", "clientOrderId"},
+	}
+
+	i11 := mgo.Index{
+		Key: []string{"userAddress", "-createdAt", "-_id"},
+	}
+
+	i12 := mgo.Index{
+		Key: []string{"baseToken", "quoteToken", "-createdAt", "-_id"},
+	}
+
 	err := db.Session.DB(dao.dbName).C(dao.collectionName).EnsureIndex(index)
 	if err != nil {
 		panic(err)
@@ -125,6 +159,26 @@ func NewOrderDao(opts ...OrderDaoOption) *OrderDao {
 		panic(err)
 	}
 
+	err = db.Session.DB(dao.dbName).C(dao.collectionName).EnsureIndex(i9)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dao.dbName).C(dao.collectionName).EnsureIndex(i10)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dao.dbName).C(dao.collectionName).EnsureIndex(i11)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dao.dbName).C(dao.collectionName).EnsureIndex(i12)
+	if err != nil {
+		panic(err)
+	}
+
 	return dao
 }
 
@@ -169,6 +223,22 @@ func (dao *OrderDao) Delete(orders ...*types.Order) error {
 		return err
 	}
 
+	// the nonces held by the deleted orders are released
+	ids := []string{}
+	hexes := []string{}
+	for _, o := range orders {
+		if o.Nonce != nil {
+			ids = append(ids, nonceID(o.UserAddress, o.Nonce))
+			hexes = append(hexes, o.Hash.Hex())
+		}
+	}
+
+	err = db.RemoveAll(dao.dbName, dao.nonceCollectionName, bson.M{"_id": bson.M{"$in": ids}, "orderHash": bson.M{"$in": hexes}})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
 	return nil
 }
 
@@ -426,6 +496,146 @@ func (dao *OrderDao) GetByHash(hash common.Hash) (*types.Order, error) {
 	return &res[0], nil
 }
 
+// Reserve inserts a new order with the NEW status before it is sent to the matching engine,
+// which updates it once the order is processed. The nonce of the order is reserved first, which
+// rejects an order reusing the nonce of another order of the same user, including an order sent
+// at the same time that is not processed yet. The client order ID of the order, if any, is
+// reserved before so that two orders sent at the same time cannot both hold it.
+func (dao *OrderDao) Reserve(o *types.Order) error {
+	if o.ClientOrderID != "" {
+		err := dao.reserveClientOrderID(o)
+		if err != nil {
+			return err
+		}
+	}
+
+	id := nonceID(o.UserAddress, o.Nonce)
+	err := db.Create(dao.dbName, dao.nonceCollectionName, &orderNonce{ID: id, OrderHash: o.Hash.Hex()})
+	if mgo.IsDup(err) {
+		return types.ErrOrderNonceUsed
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	o.Status = "NEW"
+
+	err = dao.Create(o)
+	if err != nil {
+		// the nonce is released if the order could not be inserted
+		rmErr := db.RemoveAll(dao.dbName, dao.nonceCollectionName, bson.M{"_id": id, "orderHash": o.Hash.Hex()})
+		if rmErr != nil {
+			logger.Error(rmErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// MigrateNonces reserves the nonces of the orders inserted before the nonces were reserved. The
+// orders stored before the nonces were checked may share a nonce, in which case the nonce is
+// held by the oldest of them. The migration can be run again, the nonces already reserved are
+// left untouched.
+func (dao *OrderDao) MigrateNonces() error {
+	o := &types.Order{}
+	err := db.Iterate(dao.dbName, dao.collectionName, bson.M{}, []string{"createdAt", "_id"}, o, func() error {
+		res := *o
+		*o = types.Order{}
+		if res.Nonce == nil {
+			return nil
+		}
+
+		q := bson.M{"_id": nonceID(res.UserAddress, res.Nonce)}
+		update := bson.M{"$setOnInsert": bson.M{"orderHash": res.Hash.Hex()}}
+		return db.Upsert(dao.dbName, dao.nonceCollectionName, q, update)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func nonceID(addr common.Address, nonce *big.Int) string {
+	return addr.Hex() + ":" + nonce.String()
+}
+
+// reserveClientOrderID assigns the client order ID of an order to it. The ID is taken over from
+// the order holding it only if that order is not new, open or partially filled anymore, and only
+// if no other order took it over in the meantime. An ID held by an order that was never inserted
+// is free.
+func (dao *OrderDao) reserveClientOrderID(o *types.Order) error {
+	id := o.UserAddress.Hex() + ":" + o.ClientOrderID
+
+	err := db.Create(dao.dbName, dao.clientOrderIDCollectionName, &clientOrderID{ID: id, OrderHash: o.Hash.Hex()})
+	if err == nil {
+		return nil
+	}
+
+	if !mgo.IsDup(err) {
+		logger.Error(err)
+		return err
+	}
+
+	res := []clientOrderID{}
+	err = db.Get(dao.dbName, dao.clientOrderIDCollectionName, bson.M{"_id": id}, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if len(res) == 0 {
+		return types.ErrClientOrderIDUsed
+	}
+
+	holder, err := dao.GetByHash(common.HexToHash(res[0].OrderHash))
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if holder != nil && (holder.Status == "NEW" || holder.Status == "OPEN" || holder.Status == "PARTIAL_FILLED") {
+		return types.ErrClientOrderIDUsed
+	}
+
+	q := bson.M{"_id": id, "orderHash": res[0].OrderHash}
+	update := bson.M{"$set": bson.M{"orderHash": o.Hash.Hex()}}
+
+	err = db.Update(dao.dbName, dao.clientOrderIDCollectionName, q, update)
+	if err == mgo.ErrNotFound {
+		return types.ErrClientOrderIDUsed
+	}
+
+	return err
+}
+
+// GetByClientOrderID returns the most recent order of a user with the given client order ID
+func (dao *OrderDao) GetByClientOrderID(addr common.Address, id string) (*types.Order, error) {
+	q := bson.M{
+		"userAddress":   addr.Hex(),
+		"clientOrderId": id,
+	}
+
+	res := []types.Order{}
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"-createdAt"}, 0, 1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	return &res[0], nil
+}
+
 // GetByHashes
 func (dao *OrderDao) GetByHashes(hashes []common.Hash) ([]*types.Order, error) {
 	hexes := []string{}
@@ -498,6 +708,26 @@ func (dao *OrderDao) GetCurrentByUserAddress(addr common.Address, limit ...int)
 	return res, nil
 }
 
+// GetCurrentOrders function fetches all the open/partial orders from order collection,
+// oldest orders first.
+func (dao *OrderDao) GetCurrentOrders() ([]*types.Order, error) {
+	var res []*types.Order
+	q := bson.M{"status": bson.M{"$in": []string{"OPEN", "PARTIAL_FILLED"}}}
+	sort := []string{"createdAt"}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, sort, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if res == nil {
+		return []*types.Order{}, nil
+	}
+
+	return res, nil
+}
+
 // GetHistoryByUserAddress function fetches list of orders which are not in open/partial order status
 // from order collection based on user address.
 // Returns array of Order type struct
@@ -525,6 +755,63 @@ func (dao *OrderDao) GetHistoryByUserAddress(addr common.Address, limit ...int)
 	return res, nil
 }
 
+// GetPage fetches a page of the orders matching the query, most recent orders first
+func (dao *OrderDao) GetPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	return dao.getPage(q)
+}
+
+// GetHistoryPage fetches a page of the orders matching the query which are not in
+// open/partial order status, most recent orders first
+func (dao *OrderDao) GetHistoryPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	return dao.getPage(q, bson.M{"status": bson.M{"$nin": []string{"OPEN", "PARTIAL_FILLED"}}})
+}
+
+func (dao *OrderDao) getPage(q *types.HistoryQuery, conds ...bson.M) (*types.OrderPage, error) {
+	if (q.Address != common.Address{}) {
+		conds = append(conds, bson.M{"userAddress": q.Address.Hex()})
+	}
+
+	res := []*types.Order{}
+	query := historyFilter(q, conds...)
+
+	// one extra order is fetched to know whether there is a next page
+	err := db.GetAndSort(dao.dbName, dao.collectionName, query, historySort, 0, q.Limit+1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	page := &types.OrderPage{Orders: res}
+	if len(res) > q.Limit {
+		page.Orders = res[:q.Limit]
+		last := page.Orders[q.Limit-1]
+		page.NextCursor = types.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	return page, nil
+}
+
+// StreamByUserAddress calls fn for every order of the user address, oldest orders first.
+// Orders are read from a mongo cursor so that the whole history is never held in memory.
+func (dao *OrderDao) StreamByUserAddress(addr common.Address, fn func(o *types.Order) error) error {
+	q := bson.M{"userAddress": addr.Hex()}
+	sort := []string{"createdAt", "_id"}
+
+	o := &types.Order{}
+	err := db.Iterate(dao.dbName, dao.collectionName, q, sort, o, func() error {
+		res := *o
+		*o = types.Order{}
+		return fn(&res)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (dao *OrderDao) GetUserLockedBalance(account common.Address, token common.Address, p *types.Pair) (*big.Int, error) {
 	var orders []*types.Order
 
@@ -717,16 +1004,23 @@ func (dao *OrderDao) GetOrderBookPricePoint(p *types.Pair, pp *big.Int, side str
 	return math.ToBigInt(res[0]["amount"]), nil
 }
 
+// GetMatchingBuyOrders returns the resting buy orders matching the given sell order. Only the orders
+// settled on the same exchange contract as the given order can be matched with it. The exchange
+// contract verifies both order signatures with the maker order fees, so the orders must also
+// have been signed with the same fees.
 func (dao *OrderDao) GetMatchingBuyOrders(o *types.Order) ([]*types.Order, error) {
 	var orders []*types.Order
 
 	q := []bson.M{
 		bson.M{
 			"$match": bson.M{
-				"status":     bson.M{"$in": []string{"OPEN", "PARTIAL_FILLED"}},
-				"baseToken":  o.BaseToken.Hex(),
-				"quoteToken": o.QuoteToken.Hex(),
-				"side":       "BUY",
+				"status":          bson.M{"$in": []string{"OPEN", "PARTIAL_FILLED"}},
+				"baseToken":       o.BaseToken.Hex(),
+				"quoteToken":      o.QuoteToken.Hex(),
+				"exchangeAddress": o.ExchangeAddress.Hex(),
+				"side":            "BUY",
+				"makeFee":         o.MakeFee.String(),
+				"takeFee":         o.TakeFee.String(),
 			},
 		},
 		bson.M{
@@ -748,16 +1042,23 @@ func (dao *OrderDao) GetMatchingBuyOrders(o *types.Order) ([]*types.Order, error
 	return orders, nil
 }
 
+// GetMatchingSellOrders returns the resting sell orders matching the given buy order. Only the orders
+// settled on the same exchange contract as the given order can be matched with it. The exchange
+// contract verifies both order signatures with the maker order fees, so the orders must also
+// have been signed with the same fees.
 func (dao *OrderDao) GetMatchingSellOrders(o *types.Order) ([]*types.Order, error) {
 	var orders []*types.Order
 
 	q := []bson.M{
 		bson.M{
 			"$match": bson.M{
-				"status":     bson.M{"$in": []string{"OPEN", "PARTIAL_FILLED"}},
-				"baseToken":  o.BaseToken.Hex(),
-				"quoteToken": o.QuoteToken.Hex(),
-				"side":       "SELL",
+				"status":          bson.M{"$in": []string{"OPEN", "PARTIAL_FILLED"}},
+				"baseToken":       o.BaseToken.Hex(),
+				"quoteToken":      o.QuoteToken.Hex(),
+				"exchangeAddress": o.ExchangeAddress.Hex(),
+				"side":            "SELL",
+				"makeFee":         o.MakeFee.String(),
+				"takeFee":         o.TakeFee.String(),
 			},
 		},
 		bson.M{
@@ -787,6 +1088,18 @@ func (dao *OrderDao) Drop() error {
 		return err
 	}
 
+	err = db.RemoveAll(dao.dbName, dao.clientOrderIDCollectionName, bson.M{})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = db.RemoveAll(dao.dbName, dao.nonceCollectionName, bson.M{})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
 	return nil
 }
 
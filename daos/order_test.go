@@ -299,7 +299,7 @@ func TestGetUserLockedBalance(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1001),
 		TakeFee:         big.NewInt(50),
 		Hash:            common.HexToHash("0x12"),
 	}
@@ -317,7 +317,7 @@ func TestGetUserLockedBalance(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1002),
 		TakeFee:         big.NewInt(50),
 		Hash:            common.HexToHash("0x12"),
 	}
@@ -335,7 +335,7 @@ func TestGetUserLockedBalance(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1003),
 		TakeFee:         big.NewInt(50),
 		Hash:            common.HexToHash("0x12"),
 	}
@@ -396,7 +396,7 @@ func TestGetUserOrderHistory(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1001),
 		TakeFee:         big.NewInt(50),
 		Hash:            common.HexToHash("0x12"),
 	}
@@ -414,7 +414,7 @@ func TestGetUserOrderHistory(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1002),
 		TakeFee:         big.NewInt(50),
 		Hash:            common.HexToHash("0x12"),
 	}
@@ -432,7 +432,7 @@ func TestGetUserOrderHistory(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1003),
 		TakeFee:         big.NewInt(50),
 		Hash:            common.HexToHash("0x12"),
 	}
@@ -642,7 +642,7 @@ func TestUpdateOrderFilledAmounts(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(0),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1001),
 		TakeFee:         big.NewInt(0),
 		Hash:            hash2,
 	}
@@ -712,7 +712,7 @@ func TestOrderStatusesByHashes(t *testing.T) {
 		Side:            "BUY",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(0),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1001),
 		TakeFee:         big.NewInt(0),
 		Hash:            hash2,
 	}
@@ -737,6 +737,176 @@ func TestOrderStatusesByHashes(t *testing.T) {
 	assert.Equal(t, "INVALIDATED", orders[1].Status)
 }
 
+func TestOrderReserveNonce(t *testing.T) {
+	dao := NewOrderDao()
+	err := dao.Drop()
+	if err != nil {
+		t.Error("Could not drop previous order collection")
+	}
+
+	o1 := testutils.GetTestOrder1()
+	o2 := testutils.GetTestOrder2()
+	o2.Nonce = o1.Nonce
+
+	err = dao.Reserve(&o1)
+	if err != nil {
+		t.Error("Could not reserve order", err)
+	}
+
+	reserved, err := dao.GetByHash(o1.Hash)
+	if err != nil {
+		t.Error("Could not get order by hash", err)
+	}
+
+	assert.Equal(t, "NEW", reserved.Status)
+
+	// a second order signed with the same nonce is rejected
+	err = dao.Reserve(&o2)
+	assert.Equal(t, types.ErrOrderNonceUsed, err)
+
+	o2.Nonce = big.NewInt(2000)
+	err = dao.Reserve(&o2)
+	assert.Nil(t, err)
+
+	// the nonce of a deleted order is released
+	err = dao.Delete(&o2)
+	if err != nil {
+		t.Error("Could not delete order", err)
+	}
+
+	err = dao.Reserve(&o2)
+	assert.Nil(t, err)
+}
+
+func TestOrderMigrateNonces(t *testing.T) {
+	dao := NewOrderDao()
+	err := dao.Drop()
+	if err != nil {
+		t.Error("Could not drop previous order collection")
+	}
+
+	// orders stored before the nonces were checked may share a nonce
+	o1 := testutils.GetTestOrder1()
+	o2 := testutils.GetTestOrder2()
+	o2.Nonce = o1.Nonce
+
+	err = dao.Create(&o1)
+	if err != nil {
+		t.Error("Could not create order", err)
+	}
+
+	err = dao.Create(&o2)
+	if err != nil {
+		t.Error("Could not create order", err)
+	}
+
+	err = dao.MigrateNonces()
+	assert.Nil(t, err)
+
+	// the migration can be run again
+	err = dao.MigrateNonces()
+	assert.Nil(t, err)
+
+	o3 := testutils.GetTestOrder3()
+	o3.UserAddress = o1.UserAddress
+	o3.Nonce = o1.Nonce
+	err = dao.Reserve(&o3)
+	assert.Equal(t, types.ErrOrderNonceUsed, err)
+}
+
+func TestOrderReserveClientOrderID(t *testing.T) {
+	dao := NewOrderDao()
+	err := dao.Drop()
+	if err != nil {
+		t.Error("Could not drop previous order collection")
+	}
+
+	o1 := testutils.GetTestOrder1()
+	o2 := testutils.GetTestOrder2()
+	o1.ClientOrderID = "order-1"
+	o2.ClientOrderID = "order-1"
+	o2.Nonce = big.NewInt(2000)
+
+	err = dao.Reserve(&o1)
+	if err != nil {
+		t.Error("Could not reserve order", err)
+	}
+
+	// the client order ID is held by the first order while it is new or open
+	err = dao.Reserve(&o2)
+	assert.Equal(t, types.ErrClientOrderIDUsed, err)
+
+	err = dao.UpdateOrderStatus(o1.Hash, "CANCELLED")
+	if err != nil {
+		t.Error("Could not update order status", err)
+	}
+
+	err = dao.Reserve(&o2)
+	assert.Nil(t, err)
+
+	o, err := dao.GetByClientOrderID(o2.UserAddress, "order-1")
+	if err != nil {
+		t.Error("Could not get order by client order ID", err)
+	}
+
+	assert.Equal(t, o2.Hash, o.Hash)
+}
+
+func TestGetMatchingOrdersFees(t *testing.T) {
+	dao := NewOrderDao()
+	err := dao.Drop()
+	if err != nil {
+		t.Error("Could not drop previous order collection")
+	}
+
+	buy := testutils.GetTestOrder1()
+	err = dao.Create(&buy)
+	if err != nil {
+		t.Error("Could not create order", err)
+	}
+
+	// a sell order signed with other fees than the resting buy order is not matched with it
+	sell := testutils.GetTestOrder1()
+	sell.Side = "SELL"
+	sell.Hash = common.HexToHash("0x1")
+	sell.MakeFee = big.NewInt(20)
+	sell.TakeFee = big.NewInt(30)
+
+	orders, err := dao.GetMatchingBuyOrders(&sell)
+	if err != nil {
+		t.Error("Could not get matching orders", err)
+	}
+
+	assert.Equal(t, 0, len(orders))
+
+	sell.MakeFee = big.NewInt(50)
+	sell.TakeFee = big.NewInt(50)
+
+	orders, err = dao.GetMatchingBuyOrders(&sell)
+	if err != nil {
+		t.Error("Could not get matching orders", err)
+	}
+
+	assert.Equal(t, 1, len(orders))
+	assert.Equal(t, buy.Hash, orders[0].Hash)
+
+	// the same goes for a buy order matched against resting sell orders
+	sell.MakeFee = big.NewInt(20)
+	sell.TakeFee = big.NewInt(30)
+	err = dao.Create(&sell)
+	if err != nil {
+		t.Error("Could not create order", err)
+	}
+
+	buy.Hash = common.HexToHash("0x2")
+	orders, err = dao.GetMatchingSellOrders(&buy)
+	if err != nil {
+		t.Error("Could not get matching orders", err)
+	}
+
+	assert.Equal(t, 0, len(orders))
+}
+
 func ExampleGetOrderBook() {
 	session, err := mgo.Dial(app.Config.MongoURL)
 	if err != nil {
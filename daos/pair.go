@@ -215,3 +215,23 @@ func (dao *PairDao) GetByTokenAddress(baseToken, quoteToken common.Address) (*ty
 
 	return res[0], nil
 }
+
+// UpdateStatus sets the listed and active flags of a pair
+func (dao *PairDao) UpdateStatus(baseToken, quoteToken common.Address, listed, active bool) error {
+	q := bson.M{
+		"baseTokenAddress":  baseToken.Hex(),
+		"quoteTokenAddress": quoteToken.Hex(),
+	}
+
+	updateQuery := bson.M{
+		"$set": bson.M{"listed": listed, "active": active, "updatedAt": time.Now()},
+	}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
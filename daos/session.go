@@ -188,6 +188,26 @@ func (d *Database) GetAndSort(dbName, collection string, query interface{}, sort
 	return
 }
 
+// Iterate is a wrapper for mgo.Iter function.
+// It streams the documents matching the query with a cursor instead of loading them all
+// in memory. Each document is decoded in response before calling fn, and the iteration
+// stops at the first error returned by fn.
+func (d *Database) Iterate(dbName, collection string, query interface{}, sort []string, response interface{}, fn func() error) error {
+	sc := d.Session.Copy()
+	defer sc.Close()
+
+	iter := sc.DB(dbName).C(collection).Find(query).Sort(sort...).Iter()
+	for iter.Next(response) {
+		err := fn()
+		if err != nil {
+			iter.Close()
+			return err
+		}
+	}
+
+	return iter.Close()
+}
+
 func (d *Database) Count(dbName, collection string, query interface{}) (n int, err error) {
 	sc := d.Session.Copy()
 	defer sc.Close()
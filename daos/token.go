@@ -187,6 +187,23 @@ func (dao *TokenDao) GetByAddress(addr common.Address) (*types.Token, error) {
 	return &resp[0], nil
 }
 
+// UpdateStatus sets the listed and active flags of a token
+func (dao *TokenDao) UpdateStatus(addr common.Address, listed, active bool) error {
+	q := bson.M{"address": addr.Hex()}
+
+	updateQuery := bson.M{
+		"$set": bson.M{"listed": listed, "active": active, "updatedAt": time.Now()},
+	}
+
+	err := db.Update(dao.dbName, dao.collectionName, q, updateQuery)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 // Drop drops all the order documents in the current database
 func (dao *TokenDao) Drop() error {
 	err := db.DropCollection(dao.dbName, dao.collectionName)
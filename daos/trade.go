@@ -1,6 +1,7 @@
 package daos
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/Proofsuite/amp-matching-engine/app"
@@ -59,6 +60,18 @@ func NewTradeDao() *TradeDao {
 		Collation: &mgo.Collation{NumericOrdering: true, Locale: "en"},
 	}
 
+	i9 := mgo.Index{
+		Key: []string{"maker", "-createdAt", "-_id"},
+	}
+
+	i10 := mgo.Index{
+		Key: []string{"taker", "-createdAt", "-_id"},
+	}
+
+	i11 := mgo.Index{
+		Key: []string{"baseToken", "quoteToken", "-createdAt", "-_id"},
+	}
+
 	err := db.Session.DB(dbName).C(collection).EnsureIndex(i1)
 	if err != nil {
 		panic(err)
@@ -99,6 +112,21 @@ func NewTradeDao() *TradeDao {
 		panic(err)
 	}
 
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i9)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i10)
+	if err != nil {
+		panic(err)
+	}
+
+	err = db.Session.DB(dbName).C(collection).EnsureIndex(i11)
+	if err != nil {
+		panic(err)
+	}
+
 	return &TradeDao{collection, dbName}
 }
 
@@ -270,6 +298,55 @@ func (dao *TradeDao) Aggregate(q []bson.M) ([]*types.Tick, error) {
 	return res, nil
 }
 
+// GetAccountVolumes returns the sum of amount * pricepoint of the successful trades of an account
+// (as maker or taker) on the pairs of a quote token since the given time, indexed by base token.
+// The quote volume of a pair is obtained by dividing its sum by the pair multiplier.
+func (dao *TradeDao) GetAccountVolumes(a, qt common.Address, since time.Time) (map[common.Address]*big.Int, error) {
+	q := []bson.M{
+		bson.M{
+			"$match": bson.M{
+				"$or":        []bson.M{{"maker": a.Hex()}, {"taker": a.Hex()}},
+				"quoteToken": qt.Hex(),
+				"status":     "SUCCESS",
+				"createdAt":  bson.M{"$gte": since},
+			},
+		},
+		bson.M{
+			"$group": bson.M{
+				"_id": "$baseToken",
+				"volume": bson.M{"$sum": bson.M{"$multiply": []interface{}{
+					bson.M{"$toDecimal": "$amount"},
+					bson.M{"$toDecimal": "$pricepoint"},
+				}}},
+			},
+		},
+	}
+
+	res := []struct {
+		BaseToken string          `bson:"_id"`
+		Volume    bson.Decimal128 `bson:"volume"`
+	}{}
+
+	err := db.Aggregate(dao.dbName, dao.collectionName, q, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	volumes := map[common.Address]*big.Int{}
+	for _, r := range res {
+		v, _, err := big.ParseFloat(r.Volume.String(), 10, 256, big.ToNearestEven)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		volumes[common.HexToAddress(r.BaseToken)], _ = v.Int(nil)
+	}
+
+	return volumes, nil
+}
+
 // GetByPairName fetches all the trades corresponding to a particular pair name.
 func (dao *TradeDao) GetByPairName(name string) ([]*types.Trade, error) {
 	var res []*types.Trade
@@ -346,6 +423,67 @@ func (dao *TradeDao) GetByOrderHashes(hashes []common.Hash) ([]*types.Trade, err
 	return res, nil
 }
 
+// GetByTxHashes returns the trades that were sent in one of the given transactions
+func (dao *TradeDao) GetByTxHashes(hashes []common.Hash) ([]*types.Trade, error) {
+	hexes := []string{}
+	for _, h := range hashes {
+		hexes = append(hexes, h.Hex())
+	}
+
+	q := bson.M{"txHash": bson.M{"$in": hexes}}
+	res := []*types.Trade{}
+
+	err := db.Get(dao.dbName, dao.collectionName, q, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetPendingTradesBefore returns the trades that are still pending and were last updated before the given time
+func (dao *TradeDao) GetPendingTradesBefore(t time.Time) ([]*types.Trade, error) {
+	q := bson.M{"status": "PENDING", "updatedAt": bson.M{"$lt": t}}
+	res := []*types.Trade{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"updatedAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetUnsentTrades returns the pending trades whose settlement transaction was not sent yet, oldest first
+func (dao *TradeDao) GetUnsentTrades() ([]*types.Trade, error) {
+	q := bson.M{"status": "PENDING", "txHash": common.Hash{}.Hex()}
+	res := []*types.Trade{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"createdAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetSettledTradesBetween returns the successful trades that were last updated between the given times
+func (dao *TradeDao) GetSettledTradesBetween(start, end time.Time) ([]*types.Trade, error) {
+	q := bson.M{"status": "SUCCESS", "updatedAt": bson.M{"$gte": start, "$lte": end}}
+	res := []*types.Trade{}
+
+	err := db.GetAndSort(dao.dbName, dao.collectionName, q, []string{"updatedAt"}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (dao *TradeDao) GetSortedTrades(bt, qt common.Address, n int) ([]*types.Trade, error) {
 	res := []*types.Trade{}
 
@@ -412,6 +550,56 @@ func (dao *TradeDao) GetSortedTradesByUserAddress(a common.Address, limit ...int
 	return res, nil
 }
 
+// GetPage fetches a page of the trades matching the query, most recent trades first.
+// The query address matches both the maker and the taker of the trades.
+func (dao *TradeDao) GetPage(q *types.HistoryQuery) (*types.TradePage, error) {
+	conds := []bson.M{}
+	if (q.Address != common.Address{}) {
+		conds = append(conds, bson.M{"$or": []bson.M{{"maker": q.Address.Hex()}, {"taker": q.Address.Hex()}}})
+	}
+
+	res := []*types.Trade{}
+	query := historyFilter(q, conds...)
+
+	// one extra trade is fetched to know whether there is a next page
+	err := db.GetAndSort(dao.dbName, dao.collectionName, query, historySort, 0, q.Limit+1, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	page := &types.TradePage{Trades: res}
+	if len(res) > q.Limit {
+		page.Trades = res[:q.Limit]
+		last := page.Trades[q.Limit-1]
+		page.NextCursor = types.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	return page, nil
+}
+
+// StreamByUserAddress calls fn for every trade of the user address (as maker or taker),
+// oldest trades first. Trades are read from a mongo cursor so that the whole history is
+// never held in memory.
+func (dao *TradeDao) StreamByUserAddress(a common.Address, fn func(t *types.Trade) error) error {
+	q := bson.M{"$or": []bson.M{{"maker": a.Hex()}, {"taker": a.Hex()}}}
+	sort := []string{"createdAt", "_id"}
+
+	t := &types.Trade{}
+	err := db.Iterate(dao.dbName, dao.collectionName, q, sort, t, func() error {
+		res := *t
+		*t = types.Trade{}
+		return fn(&res)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 // GetByUserAddress fetches all the trades corresponding to a particular user address.
 func (dao *TradeDao) GetByUserAddress(a common.Address) ([]*types.Trade, error) {
 	var res []*types.Trade
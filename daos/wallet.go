@@ -110,3 +110,40 @@ func (dao *WalletDao) GetOperatorWallets() ([]*types.Wallet, error) {
 
 	return res, nil
 }
+
+// GetPrivateKeys returns the hex encoded private keys of the wallets that were stored with their
+// private key, by wallet address. Wallets are not stored with their private key anymore.
+func (dao *WalletDao) GetPrivateKeys() (map[common.Address]string, error) {
+	q := bson.M{"privateKey": bson.M{"$exists": true}}
+	res := []struct {
+		Address    string `bson:"address"`
+		PrivateKey string `bson:"privateKey"`
+	}{}
+
+	err := db.Query(dao.dbName, dao.collectionName, q, bson.M{"address": 1, "privateKey": 1}, 0, 0, &res)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	keys := make(map[common.Address]string)
+	for _, r := range res {
+		keys[common.HexToAddress(r.Address)] = r.PrivateKey
+	}
+
+	return keys, nil
+}
+
+// RemovePrivateKey removes the private key stored with the wallet of the given address
+func (dao *WalletDao) RemovePrivateKey(a common.Address) error {
+	q := bson.M{"address": a.Hex()}
+	update := bson.M{"$unset": bson.M{"privateKey": ""}}
+
+	err := db.UpdateAll(dao.dbName, dao.collectionName, q, update)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
 	"github.com/globalsign/mgo/dbtest"
 )
 
@@ -20,8 +22,8 @@ func init() {
 }
 
 func TestWalletDao(t *testing.T) {
-	key := "7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660"
-	w := types.NewWalletFromPrivateKey(key)
+	// private keys are not persisted
+	w := &types.Wallet{Address: common.HexToAddress("0xE8E84ee367BC63ddB38d3D01bCCEF106c194dc47")}
 	dao := NewWalletDao()
 
 	err := dao.Create(w)
@@ -58,8 +60,8 @@ func TestWalletDao(t *testing.T) {
 }
 
 func TestDefaultAdminWallet(t *testing.T) {
-	key := "7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660"
-	w := types.NewWalletFromPrivateKey(key)
+	// private keys are not persisted
+	w := &types.Wallet{Address: common.HexToAddress("0xE8E84ee367BC63ddB38d3D01bCCEF106c194dc47")}
 	w.Admin = true
 	dao := NewWalletDao()
 
@@ -77,3 +79,52 @@ func TestDefaultAdminWallet(t *testing.T) {
 		t.Errorf("Could not get correct admin wallet:\n Expected: %v\n, Got: %v\n", w, wallet)
 	}
 }
+
+func TestWalletPrivateKeys(t *testing.T) {
+	dao := NewWalletDao()
+	w := types.NewWallet()
+
+	// wallets used to be stored with their private key
+	err := db.Create(dao.dbName, dao.collectionName, bson.M{
+		"_id":        bson.NewObjectId(),
+		"address":    w.Address.Hex(),
+		"privateKey": w.GetPrivateKey(),
+		"operator":   true,
+	})
+
+	if err != nil {
+		t.Errorf("Could not create wallet record: %v", err)
+	}
+
+	keys, err := dao.GetPrivateKeys()
+	if err != nil {
+		t.Errorf("Could not get private keys: %v", err)
+	}
+
+	if keys[w.Address] != w.GetPrivateKey() {
+		t.Errorf("Could not get the private key of the wallet: %v", keys)
+	}
+
+	err = dao.RemovePrivateKey(w.Address)
+	if err != nil {
+		t.Errorf("Could not remove private key: %v", err)
+	}
+
+	keys, err = dao.GetPrivateKeys()
+	if err != nil {
+		t.Errorf("Could not get private keys: %v", err)
+	}
+
+	if _, ok := keys[w.Address]; ok {
+		t.Errorf("Private key was not removed")
+	}
+
+	stored, err := dao.GetByAddress(w.Address)
+	if err != nil {
+		t.Errorf("Could not get wallet by address: %v", err)
+	}
+
+	if stored == nil || !stored.Operator {
+		t.Errorf("Wallet was not kept: %v", stored)
+	}
+}
@@ -1,11 +1,13 @@
 package endpoints
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/Proofsuite/amp-matching-engine/ws"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 )
@@ -23,6 +25,7 @@ func ServeAccountResource(
 	r.HandleFunc("/account/create", e.handleCreateAccount).Methods("POST")
 	r.HandleFunc("/account/{address}", e.handleGetAccount).Methods("GET")
 	r.HandleFunc("/account/{address}/{token}", e.handleGetAccountTokenBalance).Methods("GET")
+	ws.RegisterChannel(ws.BalanceChannel, e.balanceWebsocket)
 }
 
 func (e *accountEndpoint) handleCreateAccount(w http.ResponseWriter, r *http.Request) {
@@ -102,3 +105,49 @@ func (e *accountEndpoint) handleGetAccountTokenBalance(w http.ResponseWriter, r
 
 	httputils.WriteJSON(w, http.StatusOK, b)
 }
+
+// balanceWebsocket handles the subscriptions to the private balance channel. The connection
+// must be logged in as the account owner. The current token balances are then sent and every subsequent transfer or approval is pushed as an UPDATE message.
+func (e *accountEndpoint) balanceWebsocket(input interface{}, c *ws.Client) {
+	b, _ := json.Marshal(input)
+	var ev *types.WebsocketEvent
+	if err := json.Unmarshal(b, &ev); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if ev.Type != "SUBSCRIBE" && ev.Type != "UNSUBSCRIBE" {
+		logger.Info("Event Type", ev.Type)
+		c.SendMessage(ws.BalanceChannel, "ERROR", "Invalid payload")
+		return
+	}
+
+	b, _ = json.Marshal(ev.Payload)
+	var s *types.BalanceSubscription
+	err := json.Unmarshal(b, &s)
+	if err != nil || s == nil {
+		logger.Error(err)
+		c.SendMessage(ws.BalanceChannel, "ERROR", "Invalid payload")
+		return
+	}
+
+	if ev.Type == "UNSUBSCRIBE" {
+		ws.UnsubscribeBalanceConnection(s.Address, c)
+		return
+	}
+
+	if !c.IsAuthenticated(s.Address) {
+		c.SendMessage(ws.BalanceChannel, "ERROR", ws.ErrNotAuthenticated.Error())
+		return
+	}
+
+	balances, err := e.accountService.GetTokenBalances(s.Address)
+	if err != nil {
+		logger.Error(err)
+		c.SendMessage(ws.BalanceChannel, "ERROR", "Could not retrieve token balances")
+		return
+	}
+
+	ws.RegisterBalanceConnection(s.Address, c)
+	c.SendMessage(ws.BalanceChannel, "INIT", balances)
+}
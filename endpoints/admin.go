@@ -0,0 +1,403 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	apierrors "github.com/Proofsuite/amp-matching-engine/errors"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+// defaultAuditLogLimit is the number of audit logs returned when no limit is given
+const defaultAuditLogLimit = 100
+
+type adminEndpoint struct {
+	adminService interfaces.AdminService
+}
+
+// statusRequest is the payload of the pair and token status updates. A missing flag is
+// left unchanged.
+type statusRequest struct {
+	Listed *bool `json:"listed"`
+	Active *bool `json:"active"`
+}
+
+// ServeAdminResource sets up the routing of the admin endpoints. Every admin endpoint requires
+// an API key with the admin scope.
+func ServeAdminResource(
+	r *mux.Router,
+	adminService interfaces.AdminService,
+) {
+	e := &adminEndpoint{adminService}
+	r.HandleFunc("/admin/accounts/{address}/block", e.handleBlockAccount).Methods("POST")
+	r.HandleFunc("/admin/accounts/{address}/unblock", e.handleUnblockAccount).Methods("POST")
+	r.HandleFunc("/admin/deny-list", e.handleGetDenyList).Methods("GET")
+	r.HandleFunc("/admin/deny-list/reload", e.handleReloadDenyList).Methods("POST")
+	r.HandleFunc("/admin/pairs/{baseToken}/{quoteToken}", e.handleUpdatePairStatus).Methods("PUT")
+	r.HandleFunc("/admin/tokens/{address}", e.handleUpdateTokenStatus).Methods("PUT")
+	r.HandleFunc("/admin/orders/{hash}", e.handleCancelOrder).Methods("DELETE")
+	r.HandleFunc("/admin/operator/purge", e.handlePurgeQueues).Methods("POST")
+	r.HandleFunc("/admin/engine", e.handleGetEngineState).Methods("GET")
+	r.HandleFunc("/admin/audit-logs", e.handleGetAuditLogs).Methods("GET")
+	r.HandleFunc("/admin/fees/tiers", e.handleCreateFeeTier).Methods("POST")
+	r.HandleFunc("/admin/fees/tiers", e.handleDeleteFeeTier).Methods("DELETE")
+	r.HandleFunc("/admin/fees/overrides", e.handleSetFeeOverride).Methods("PUT")
+	r.HandleFunc("/admin/fees/overrides", e.handleDeleteFeeOverride).Methods("DELETE")
+}
+
+func (e *adminEndpoint) handleBlockAccount(w http.ResponseWriter, r *http.Request) {
+	e.setAccountBlocked(w, r, true)
+}
+
+func (e *adminEndpoint) handleUnblockAccount(w http.ResponseWriter, r *http.Request) {
+	e.setAccountBlocked(w, r, false)
+}
+
+func (e *adminEndpoint) setAccountBlocked(w http.ResponseWriter, r *http.Request, blocked bool) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	addr := mux.Vars(r)["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid Address"))
+		return
+	}
+
+	acc, err := e.adminService.SetAccountBlocked(k.UserAddress, common.HexToAddress(addr), blocked)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, acc)
+}
+
+// handleGetDenyList returns the addresses that are not allowed to trade
+func (e *adminEndpoint) handleGetDenyList(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, e.adminService.GetDenyList())
+}
+
+// handleReloadDenyList reloads the deny list file and cancels the open orders of the addresses
+// added to it
+func (e *adminEndpoint) handleReloadDenyList(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	addresses, err := e.adminService.ReloadDenyList(k.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, addresses)
+}
+
+func (e *adminEndpoint) handleUpdatePairStatus(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	if !common.IsHexAddress(vars["baseToken"]) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid base token address"))
+		return
+	}
+
+	if !common.IsHexAddress(vars["quoteToken"]) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid quote token address"))
+		return
+	}
+
+	req := &statusRequest{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(req)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
+		return
+	}
+
+	bt := common.HexToAddress(vars["baseToken"])
+	qt := common.HexToAddress(vars["quoteToken"])
+
+	p, err := e.adminService.SetPairStatus(k.UserAddress, bt, qt, req.Listed, req.Active)
+	if err != nil {
+		if err == services.ErrPairNotFound {
+			httputils.WriteAPIError(w, apierrors.NotFound("Pair"))
+			return
+		}
+
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, p)
+}
+
+func (e *adminEndpoint) handleUpdateTokenStatus(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	addr := mux.Vars(r)["address"]
+	if !common.IsHexAddress(addr) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid Address"))
+		return
+	}
+
+	req := &statusRequest{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(req)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
+		return
+	}
+
+	t, err := e.adminService.SetTokenStatus(k.UserAddress, common.HexToAddress(addr), req.Listed, req.Active)
+	if err != nil {
+		if err == services.ErrTokenNotFound {
+			httputils.WriteAPIError(w, apierrors.NotFound("Token"))
+			return
+		}
+
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, t)
+}
+
+// handleCancelOrder cancels an order on behalf of its owner
+func (e *adminEndpoint) handleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	hash := mux.Vars(r)["hash"]
+	if !isHash(hash) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid Hash"))
+		return
+	}
+
+	o, err := e.adminService.CancelOrder(k.UserAddress, common.HexToHash(hash))
+	if err != nil {
+		if err == services.ErrOrderNotFound {
+			httputils.WriteAPIError(w, apierrors.NotFound("Order"))
+			return
+		}
+
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, o)
+}
+
+// handlePurgeQueues removes the pending transactions from the operator queues
+func (e *adminEndpoint) handlePurgeQueues(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	err := e.adminService.PurgeQueues(k.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]bool{"purged": true})
+}
+
+// handleGetEngineState returns the order books of the engine and the operator queues
+func (e *adminEndpoint) handleGetEngineState(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	state, err := e.adminService.GetEngineState()
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, state)
+}
+
+// handleGetAuditLogs returns the most recent admin actions, optionally restricted to a target
+// (eg. account:0x...)
+func (e *adminEndpoint) handleGetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	v := r.URL.Query()
+	limit := defaultAuditLogLimit
+	if l := v.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 {
+			httputils.WriteAPIError(w, apierrors.BadRequest("Invalid limit"))
+			return
+		}
+
+		limit = n
+	}
+
+	logs, err := e.adminService.GetAuditLogs(v.Get("target"), limit)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, logs)
+}
+
+func (e *adminEndpoint) handleCreateFeeTier(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	t := &types.FeeTier{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(t)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
+		return
+	}
+
+	err = e.adminService.CreateFeeTier(k.UserAddress, t)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, t)
+}
+
+func (e *adminEndpoint) handleDeleteFeeTier(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	v := r.URL.Query()
+	qt := v.Get("quoteToken")
+	name := v.Get("name")
+
+	if !common.IsHexAddress(qt) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid quote token address"))
+		return
+	}
+
+	if name == "" {
+		httputils.WriteAPIError(w, apierrors.BadRequest("name Parameter missing"))
+		return
+	}
+
+	err := e.adminService.DeleteFeeTier(k.UserAddress, common.HexToAddress(qt), name)
+	if err != nil {
+		if err == services.ErrFeeTierNotFound {
+			httputils.WriteAPIError(w, apierrors.NotFound("Fee tier"))
+			return
+		}
+
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]string{"quoteToken": qt, "name": name})
+}
+
+func (e *adminEndpoint) handleSetFeeOverride(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	o := &types.FeeOverride{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(o)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
+		return
+	}
+
+	err = e.adminService.SetFeeOverride(k.UserAddress, o)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, o)
+}
+
+func (e *adminEndpoint) handleDeleteFeeOverride(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeAdmin)
+	if k == nil {
+		return
+	}
+
+	v := r.URL.Query()
+	addr := v.Get("address")
+	qt := v.Get("quoteToken")
+
+	if !common.IsHexAddress(addr) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid Address"))
+		return
+	}
+
+	if !common.IsHexAddress(qt) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid quote token address"))
+		return
+	}
+
+	err := e.adminService.DeleteFeeOverride(k.UserAddress, common.HexToAddress(addr), common.HexToAddress(qt))
+	if err != nil {
+		if err == services.ErrFeeOverrideNotFound {
+			httputils.WriteAPIError(w, apierrors.NotFound("Fee override"))
+			return
+		}
+
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]string{"address": addr, "quoteToken": qt})
+}
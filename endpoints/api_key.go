@@ -0,0 +1,157 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apierrors "github.com/Proofsuite/amp-matching-engine/errors"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+type apiKeyEndpoint struct {
+	apiKeyService interfaces.APIKeyService
+}
+
+// apiKeyRequest is the payload of the challenge and API key creation requests
+type apiKeyRequest struct {
+	Address   string                 `json:"address"`
+	Scopes    []string               `json:"scopes"`
+	Nonce     string                 `json:"nonce"`
+	Signature *types.SignatureRecord `json:"signature"`
+	Label     string                 `json:"label"`
+}
+
+// ServeAPIKeyResource sets up the routing of the API key endpoints
+func ServeAPIKeyResource(
+	r *mux.Router,
+	apiKeyService interfaces.APIKeyService,
+) {
+	e := &apiKeyEndpoint{apiKeyService}
+	r.HandleFunc("/api-keys/challenge", e.handleCreateChallenge).Methods("POST")
+	r.HandleFunc("/api-keys", e.handleCreateAPIKey).Methods("POST")
+	r.HandleFunc("/api-keys", e.handleGetAPIKeys).Methods("GET")
+	r.HandleFunc("/api-keys/{key}", e.handleDeleteAPIKey).Methods("DELETE")
+}
+
+// handleCreateChallenge issues the challenge an account signs to create an API key
+func (e *apiKeyEndpoint) handleCreateChallenge(w http.ResponseWriter, r *http.Request) {
+	req := &apiKeyRequest{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(req)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
+		return
+	}
+
+	if !common.IsHexAddress(req.Address) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid Address"))
+		return
+	}
+
+	c, err := e.apiKeyService.CreateChallenge(common.HexToAddress(req.Address), req.Scopes)
+	if err != nil {
+		if err == services.ErrAPIKeyAdminScope {
+			httputils.WriteAPIError(w, apierrors.Forbidden(err.Error()))
+			return
+		}
+
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, c)
+}
+
+// handleCreateAPIKey creates an API key from a signed challenge. The key secret is only
+// returned in this response.
+func (e *apiKeyEndpoint) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	req := &apiKeyRequest{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(req)
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
+		return
+	}
+
+	if !common.IsHexAddress(req.Address) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid Address"))
+		return
+	}
+
+	if !isHash(req.Nonce) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid nonce"))
+		return
+	}
+
+	if req.Signature == nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("signature Parameter missing"))
+		return
+	}
+
+	sig := &types.Signature{
+		V: req.Signature.V,
+		R: common.HexToHash(req.Signature.R),
+		S: common.HexToHash(req.Signature.S),
+	}
+
+	k, err := e.apiKeyService.CreateAPIKey(common.HexToAddress(req.Address), common.HexToHash(req.Nonce), sig, req.Label)
+	if err != nil {
+		if err == services.ErrAPIKeyChallengeNotFound {
+			httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+			return
+		}
+
+		httputils.WriteAPIError(w, apierrors.Unauthorized(err.Error()))
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, map[string]interface{}{"apiKey": k, "secret": k.Secret})
+}
+
+// handleGetAPIKeys returns the API keys of the account owning the request API key
+func (e *apiKeyEndpoint) handleGetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeRead)
+	if k == nil {
+		return
+	}
+
+	keys, err := e.apiKeyService.GetAPIKeys(k.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, keys)
+}
+
+// handleDeleteAPIKey revokes an API key of the account owning the request API key
+func (e *apiKeyEndpoint) handleDeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	k := authenticate(w, r, types.APIKeyScopeRead)
+	if k == nil {
+		return
+	}
+
+	err := e.apiKeyService.DeleteAPIKey(k.UserAddress, mux.Vars(r)["key"])
+	if err != nil {
+		if err == services.ErrAPIKeyNotFound {
+			httputils.WriteAPIError(w, apierrors.NotFound("API key"))
+			return
+		}
+
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, map[string]string{"key": mux.Vars(r)["key"]})
+}
@@ -0,0 +1,112 @@
+package endpoints
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	apierrors "github.com/Proofsuite/amp-matching-engine/errors"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+type contextKey int
+
+const apiKeyContextKey contextKey = iota
+
+// maxRequestBodySize is the maximum size in bytes of a request body
+const maxRequestBodySize = 1 << 20
+
+// NewAuthMiddleware authenticates the requests signed with an API key and adds the key to the
+// request context. Requests without API key header go through anonymously, the private endpoints
+// then refuse them. Requests with an invalid key, timestamp or signature are refused. The request
+// bodies are limited to maxRequestBodySize bytes.
+func NewAuthMiddleware(apiKeyService interfaces.APIKeyService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+
+			key := r.Header.Get(types.APIKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
+				return
+			}
+
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			k, err := apiKeyService.Authenticate(
+				key,
+				r.Header.Get(types.APIKeyTimestampHeader),
+				r.Header.Get(types.APIKeySignatureHeader),
+				r.Method,
+				r.URL.RequestURI(),
+				body,
+			)
+
+			if err != nil {
+				switch err {
+				case services.ErrAPIKeyNotFound, services.ErrInvalidRequestTimestamp, services.ErrInvalidRequestSignature:
+					httputils.WriteAPIError(w, apierrors.Unauthorized(err.Error()))
+				default:
+					logger.Error(err)
+					httputils.WriteAPIError(w, apierrors.InternalServerError(err))
+				}
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey, k)))
+		})
+	}
+}
+
+// requestAPIKey returns the API key the request was authenticated with, or nil
+func requestAPIKey(r *http.Request) *types.APIKey {
+	k, _ := r.Context().Value(apiKeyContextKey).(*types.APIKey)
+	return k
+}
+
+// authenticate returns the API key of the request if it was granted the given scope.
+// Otherwise the error is written to the client and the returned key is nil.
+func authenticate(w http.ResponseWriter, r *http.Request, scope string) *types.APIKey {
+	k := requestAPIKey(r)
+	if k == nil {
+		httputils.WriteAPIError(w, apierrors.Unauthorized("API key authentication required"))
+		return nil
+	}
+
+	if !k.HasScope(scope) {
+		httputils.WriteAPIError(w, apierrors.Forbidden("API key is missing the '"+scope+"' scope"))
+		return nil
+	}
+
+	return k
+}
+
+// authorize returns true if the request was authenticated with an API key of the account a
+// granted the given scope. Otherwise the error is written to the client.
+func authorize(w http.ResponseWriter, r *http.Request, a common.Address, scope string) bool {
+	k := authenticate(w, r, scope)
+	if k == nil {
+		return false
+	}
+
+	if k.UserAddress != a {
+		httputils.WriteAPIError(w, apierrors.Forbidden("API key does not belong to "+a.Hex()))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,212 @@
+package endpoints
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+// Export formats
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportFlushInterval is the number of records after which the export is flushed to the client
+const exportFlushInterval = 100
+
+type exportEndpoint struct {
+	exportService interfaces.ExportService
+}
+
+// ServeExportResource sets up the routing of the account history export endpoints
+func ServeExportResource(
+	r *mux.Router,
+	exportService interfaces.ExportService,
+) {
+	e := &exportEndpoint{exportService}
+	r.HandleFunc("/exports/orders", e.handleExportOrders).Methods("GET")
+	r.HandleFunc("/exports/trades", e.handleExportTrades).Methods("GET")
+}
+
+// handleExportOrders streams the full order history of the address query parameter
+func (e *exportEndpoint) handleExportOrders(w http.ResponseWriter, r *http.Request) {
+	a, ew := newExportWriter(w, r, "orders", types.OrderExportHeader)
+	if ew == nil {
+		return
+	}
+
+	err := e.exportService.ExportOrders(a, func(o *types.OrderExport) error {
+		return ew.write(o)
+	})
+
+	ew.close(err)
+}
+
+// handleExportTrades streams the full trade history of the address query parameter
+func (e *exportEndpoint) handleExportTrades(w http.ResponseWriter, r *http.Request) {
+	a, ew := newExportWriter(w, r, "trades", types.TradeExportHeader)
+	if ew == nil {
+		return
+	}
+
+	err := e.exportService.ExportTrades(a, func(t *types.TradeExport) error {
+		return ew.write(t)
+	})
+
+	ew.close(err)
+}
+
+type exportRecord interface {
+	CSVRecord() []string
+}
+
+// exportWriter writes export records as CSV or NDJSON. The response headers are only
+// sent with the first record so that an error occuring before can still be reported
+// with an error status.
+type exportWriter struct {
+	w        http.ResponseWriter
+	format   string
+	filename string
+	header   []string
+	csv      *csv.Writer
+	json     *json.Encoder
+	started  bool
+	count    int
+}
+
+// newExportWriter parses the address and format query parameters. If they are invalid,
+// the error is written to the client and the returned writer is nil.
+func newExportWriter(w http.ResponseWriter, r *http.Request, kind string, header []string) (common.Address, *exportWriter) {
+	v := r.URL.Query()
+	addr := v.Get("address")
+	format := v.Get("format")
+
+	if addr == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
+		return common.Address{}, nil
+	}
+
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return common.Address{}, nil
+	}
+
+	if format == "" {
+		format = exportFormatCSV
+	}
+
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		httputils.WriteError(w, http.StatusBadRequest, "format should be csv or ndjson")
+		return common.Address{}, nil
+	}
+
+	a := common.HexToAddress(addr)
+	if !authorize(w, r, a, types.APIKeyScopeRead) {
+		return common.Address{}, nil
+	}
+
+	ew := &exportWriter{
+		w:        w,
+		format:   format,
+		filename: fmt.Sprintf("%v-%v.%v", kind, a.Hex(), format),
+		header:   header,
+	}
+
+	return a, ew
+}
+
+func (ew *exportWriter) start() error {
+	ew.started = true
+
+	if ew.format == exportFormatNDJSON {
+		ew.w.Header().Set("Content-Type", "application/x-ndjson")
+	} else {
+		ew.w.Header().Set("Content-Type", "text/csv")
+	}
+
+	ew.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", ew.filename))
+	ew.w.WriteHeader(http.StatusOK)
+
+	if ew.format == exportFormatNDJSON {
+		ew.json = json.NewEncoder(ew.w)
+		return nil
+	}
+
+	ew.csv = csv.NewWriter(ew.w)
+	return ew.csv.Write(ew.header)
+}
+
+func (ew *exportWriter) write(r exportRecord) error {
+	if !ew.started {
+		err := ew.start()
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if ew.format == exportFormatNDJSON {
+		err = ew.json.Encode(r)
+	} else {
+		err = ew.csv.Write(r.CSVRecord())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ew.count++
+	if ew.count%exportFlushInterval == 0 {
+		return ew.flush()
+	}
+
+	return nil
+}
+
+func (ew *exportWriter) flush() error {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		err := ew.csv.Error()
+		if err != nil {
+			return err
+		}
+	}
+
+	if f, ok := ew.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// close terminates the export. If the export failed after the first record was sent,
+// the response is aborted so that the client does not mistake it for a complete export.
+func (ew *exportWriter) close(err error) {
+	if err == nil && !ew.started {
+		err = ew.start()
+	}
+
+	if err == nil {
+		err = ew.flush()
+	}
+
+	if err == nil {
+		return
+	}
+
+	logger.Error(err)
+	if !ew.started {
+		httputils.WriteError(ew.w, http.StatusInternalServerError, "")
+		return
+	}
+
+	panic(http.ErrAbortHandler)
+}
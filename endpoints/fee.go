@@ -0,0 +1,108 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+type feeEndpoint struct {
+	feeService interfaces.FeeService
+}
+
+// ServeFeeResource sets up the routing of the fee tier endpoints
+func ServeFeeResource(
+	r *mux.Router,
+	feeService interfaces.FeeService,
+) {
+	e := &feeEndpoint{feeService}
+	r.HandleFunc("/fees/tiers", e.handleGetFeeTiers).Methods("GET")
+	r.HandleFunc("/fees/account", e.handleGetAccountFees).Methods("GET")
+}
+
+// handleGetFeeTiers returns the fee tiers of a quote token
+func (e *feeEndpoint) handleGetFeeTiers(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	qt := v.Get("quoteToken")
+
+	if qt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter missing")
+		return
+	}
+
+	if !common.IsHexAddress(qt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid quote token address")
+		return
+	}
+
+	tiers, err := e.feeService.GetFeeTiers(common.HexToAddress(qt))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, tiers)
+}
+
+// handleGetAccountFees returns the fee tier of an account on a pair, and the fees the account
+// has to sign in its orders
+func (e *feeEndpoint) handleGetAccountFees(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	addr := v.Get("address")
+	bt := v.Get("baseToken")
+	qt := v.Get("quoteToken")
+
+	if addr == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
+		return
+	}
+
+	if bt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "baseToken Parameter missing")
+		return
+	}
+
+	if qt == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter missing")
+		return
+	}
+
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	if !common.IsHexAddress(bt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid base token address")
+		return
+	}
+
+	if !common.IsHexAddress(qt) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid quote token address")
+		return
+	}
+
+	if !authorize(w, r, common.HexToAddress(addr), types.APIKeyScopeRead) {
+		return
+	}
+
+	fees, err := e.feeService.GetAccountFeesByPair(common.HexToAddress(addr), common.HexToAddress(bt), common.HexToAddress(qt))
+	if err != nil {
+		if err == services.ErrPairNotFound {
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, fees)
+}
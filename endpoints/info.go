@@ -15,6 +15,7 @@ type infoEndpoint struct {
 	walletService interfaces.WalletService
 	tokenService  interfaces.TokenService
 	infoService   interfaces.InfoService
+	operator      interfaces.Operator
 }
 
 func ServeInfoResource(
@@ -22,9 +23,10 @@ func ServeInfoResource(
 	walletService interfaces.WalletService,
 	tokenService interfaces.TokenService,
 	infoService interfaces.InfoService,
+	operator interfaces.Operator,
 ) {
 
-	e := &infoEndpoint{walletService, tokenService, infoService}
+	e := &infoEndpoint{walletService, tokenService, infoService, operator}
 	r.HandleFunc("/info", e.handleGetInfo)
 	r.HandleFunc("/info/exchange", e.handleGetExchangeInfo)
 	r.HandleFunc("/info/operators", e.handleGetOperatorsInfo)
@@ -75,7 +77,13 @@ func (e *infoEndpoint) handleGetExchangeInfo(w http.ResponseWriter, r *http.Requ
 	httputils.WriteJSON(w, http.StatusOK, res)
 }
 
+// handleGetOperatorsInfo returns the operator wallets along with their balance and health. It
+// requires an API key with the admin scope.
 func (e *infoEndpoint) handleGetOperatorsInfo(w http.ResponseWriter, r *http.Request) {
+	if authenticate(w, r, types.APIKeyScopeAdmin) == nil {
+		return
+	}
+
 	addresses, err := e.walletService.GetOperatorAddresses()
 	if err != nil {
 		logger.Error(err)
@@ -83,7 +91,20 @@ func (e *infoEndpoint) handleGetOperatorsInfo(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	res := map[string][]common.Address{"operators": addresses}
+	statuses := e.operator.GetWalletStatuses()
+	alerts := []string{}
+	for _, s := range statuses {
+		if s.LowBalance || !s.Healthy {
+			alerts = append(alerts, s.Address.Hex()+": "+s.Message)
+		}
+	}
+
+	res := map[string]interface{}{
+		"operators": addresses,
+		"wallets":   statuses,
+		"alerts":    alerts,
+	}
+
 	httputils.WriteJSON(w, http.StatusOK, res)
 }
 
@@ -1,5 +1,99 @@
 package endpoints
 
-import "github.com/Proofsuite/amp-matching-engine/utils"
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
 
 var logger = utils.Logger
+
+// isHash returns true if the string is a 0x prefixed hex encoded 32 bytes hash
+func isHash(s string) bool {
+	b, err := hexutil.Decode(s)
+	return err == nil && len(b) == 32
+}
+
+// parseHistoryQuery parses the filters and pagination parameters shared by the order and
+// trade list endpoints: address, baseToken, quoteToken, side, status, from, to (unix
+// timestamps in seconds), cursor and limit.
+func parseHistoryQuery(v url.Values, defaultLimit int) (*types.HistoryQuery, error) {
+	q := &types.HistoryQuery{
+		Side:   v.Get("side"),
+		Status: v.Get("status"),
+		Limit:  defaultLimit,
+	}
+
+	if addr := v.Get("address"); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, errors.New("Invalid Address")
+		}
+
+		q.Address = common.HexToAddress(addr)
+	}
+
+	if bt := v.Get("baseToken"); bt != "" {
+		if !common.IsHexAddress(bt) {
+			return nil, errors.New("Invalid base token address")
+		}
+
+		q.BaseToken = common.HexToAddress(bt)
+	}
+
+	if qt := v.Get("quoteToken"); qt != "" {
+		if !common.IsHexAddress(qt) {
+			return nil, errors.New("Invalid quote token address")
+		}
+
+		q.QuoteToken = common.HexToAddress(qt)
+	}
+
+	if l := v.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil {
+			return nil, errors.New("Invalid limit")
+		}
+
+		q.Limit = limit
+	}
+
+	if from := v.Get("from"); from != "" {
+		t, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid 'from' timestamp")
+		}
+
+		q.From = time.Unix(t, 0)
+	}
+
+	if to := v.Get("to"); to != "" {
+		t, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid 'to' timestamp")
+		}
+
+		q.To = time.Unix(t, 0)
+	}
+
+	if c := v.Get("cursor"); c != "" {
+		cursor, err := types.DecodeCursor(c)
+		if err != nil {
+			return nil, err
+		}
+
+		q.Cursor = cursor
+	}
+
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
@@ -6,8 +6,11 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
+	apierrors "github.com/Proofsuite/amp-matching-engine/errors"
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/services"
 	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
@@ -16,6 +19,10 @@ import (
 	"github.com/Proofsuite/amp-matching-engine/ws"
 )
 
+// engineResponseTimeout is the time the order endpoints wait for the matching engine to accept
+// an order or an order cancel
+const engineResponseTimeout = 10 * time.Second
+
 type orderEndpoint struct {
 	orderService   interfaces.OrderService
 	accountService interfaces.AccountService
@@ -33,47 +40,39 @@ func ServeOrderResource(
 	r.HandleFunc("/orders/history", e.handleGetOrderHistory).Methods("GET")
 	r.HandleFunc("/orders/positions", e.handleGetPositions).Methods("GET")
 	r.HandleFunc("/orders", e.handleGetOrders).Methods("GET")
+	r.HandleFunc("/orders/client/{clientOrderId}", e.handleGetOrderByClientOrderID).Methods("GET")
+	r.HandleFunc("/orders/{hash}", e.handleGetOrder).Methods("GET")
+	r.HandleFunc("/orders", e.handlePostOrder).Methods("POST")
+	r.HandleFunc("/orders/cancel", e.handlePostCancelOrder).Methods("POST")
+	r.HandleFunc("/orders/{hash}", e.handleDeleteOrder).Methods("DELETE")
 	ws.RegisterChannel(ws.OrderChannel, e.ws)
 }
 
 func (e *orderEndpoint) handleGetOrders(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
-	addr := v.Get("address")
-	limit := v.Get("limit")
-
-	if addr == "" {
+	if v.Get("address") == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "address Parameter Missing")
 		return
 	}
 
-	if !common.IsHexAddress(addr) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+	q, err := parseHistoryQuery(v, types.DefaultPageLimit)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	var err error
-	var orders []*types.Order
-	address := common.HexToAddress(addr)
-
-	if limit == "" {
-		orders, err = e.orderService.GetByUserAddress(address)
-	} else {
-		lim, _ := strconv.Atoi(limit)
-		orders, err = e.orderService.GetByUserAddress(address, lim)
+	if !authorize(w, r, q.Address, types.APIKeyScopeRead) {
+		return
 	}
 
+	page, err := e.orderService.GetPage(q)
 	if err != nil {
 		logger.Error(err)
 		httputils.WriteError(w, http.StatusInternalServerError, "")
 		return
 	}
 
-	if orders == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Order{})
-		return
-	}
-
-	httputils.WriteJSON(w, http.StatusOK, orders)
+	httputils.WriteJSON(w, http.StatusOK, page)
 }
 
 func (e *orderEndpoint) handleGetPositions(w http.ResponseWriter, r *http.Request) {
@@ -94,6 +93,9 @@ func (e *orderEndpoint) handleGetPositions(w http.ResponseWriter, r *http.Reques
 	var err error
 	var orders []*types.Order
 	address := common.HexToAddress(addr)
+	if !authorize(w, r, address, types.APIKeyScopeRead) {
+		return
+	}
 
 	if limit == "" {
 		orders, err = e.orderService.GetCurrentByUserAddress(address)
@@ -118,8 +120,63 @@ func (e *orderEndpoint) handleGetPositions(w http.ResponseWriter, r *http.Reques
 
 func (e *orderEndpoint) handleGetOrderHistory(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
-	addr := v.Get("address")
-	limit := v.Get("limit")
+	if v.Get("address") == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
+		return
+	}
+
+	q, err := parseHistoryQuery(v, types.DefaultPageLimit)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !authorize(w, r, q.Address, types.APIKeyScopeRead) {
+		return
+	}
+
+	page, err := e.orderService.GetHistoryPage(q)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, page)
+}
+
+func (e *orderEndpoint) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	h := vars["hash"]
+	if !isHash(h) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Hash")
+		return
+	}
+
+	o, err := e.orderService.GetByHash(common.HexToHash(h))
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if o == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Order not found")
+		return
+	}
+
+	if !authorize(w, r, o.UserAddress, types.APIKeyScopeRead) {
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, o)
+}
+
+// handleGetOrderByClientOrderID returns the most recent order of an account with the given client order ID
+func (e *orderEndpoint) handleGetOrderByClientOrderID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	addr := r.URL.Query().Get("address")
 
 	if addr == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
@@ -131,29 +188,160 @@ func (e *orderEndpoint) handleGetOrderHistory(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	var err error
-	var orders []*types.Order
 	address := common.HexToAddress(addr)
+	if !authorize(w, r, address, types.APIKeyScopeRead) {
+		return
+	}
 
-	if limit == "" {
-		orders, err = e.orderService.GetHistoryByUserAddress(address)
-	} else {
-		lim, _ := strconv.Atoi(limit)
-		orders, err = e.orderService.GetHistoryByUserAddress(address, lim)
+	o, err := e.orderService.GetByClientOrderID(address, vars["clientOrderId"])
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	if o == nil {
+		httputils.WriteError(w, http.StatusNotFound, "Order not found")
+		return
 	}
 
+	httputils.WriteJSON(w, http.StatusOK, o)
+}
+
+// handlePostOrder places a signed order. The order goes through the same validation as the orders
+// sent on the websocket order channel. The response is sent once the matching engine has accepted
+// the order, with the matches of the order if any.
+func (e *orderEndpoint) handlePostOrder(w http.ResponseWriter, r *http.Request) {
+	o := &types.Order{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(o)
 	if err != nil {
 		logger.Error(err)
-		httputils.WriteError(w, http.StatusInternalServerError, "Internal Server Error")
+		httputils.WriteAPIError(w, apierrors.BadRequest("Invalid payload"))
 		return
 	}
 
-	if orders == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Order{})
+	err = o.Validate()
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
 		return
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, orders)
+	if !authorize(w, r, o.UserAddress, types.APIKeyScopeTrade) {
+		return
+	}
+
+	o.Hash = o.ComputeHash()
+
+	_, err = e.accountService.FindOrCreate(o.UserAddress)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteAPIError(w, apierrors.InternalServerError(err))
+		return
+	}
+
+	res, err := e.orderService.NewOrderSync(o, engineResponseTimeout)
+	if err != nil {
+		logger.Error(err)
+		apiErr := engineError(err)
+		apiErr.Details = map[string]interface{}{"hash": o.Hash.Hex(), "clientOrderId": o.ClientOrderID}
+		httputils.WriteAPIError(w, apiErr)
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusCreated, res)
+}
+
+// handleDeleteOrder cancels the order of the given hash. The request body is the order cancel
+// signed by the order maker.
+func (e *orderEndpoint) handleDeleteOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	oc := &types.OrderCancel{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(oc)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+		return
+	}
+
+	if oc.OrderHash != common.HexToHash(vars["hash"]) {
+		httputils.WriteAPIError(w, apierrors.BadRequest("Order cancel 'orderHash' does not match the order hash"))
+		return
+	}
+
+	e.cancelOrder(w, r, oc)
+}
+
+// handlePostCancelOrder cancels an order. The request body is the order cancel signed by the order maker.
+func (e *orderEndpoint) handlePostCancelOrder(w http.ResponseWriter, r *http.Request) {
+	oc := &types.OrderCancel{}
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	err := decoder.Decode(oc)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+		return
+	}
+
+	e.cancelOrder(w, r, oc)
+}
+
+// cancelOrder cancels an order once the request API key is checked against the signer of the cancel
+func (e *orderEndpoint) cancelOrder(w http.ResponseWriter, r *http.Request, oc *types.OrderCancel) {
+	if oc.Signature == nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest("signature Parameter missing"))
+		return
+	}
+
+	addr, err := oc.GetSenderAddress()
+	if err != nil {
+		httputils.WriteAPIError(w, apierrors.BadRequest(err.Error()))
+		return
+	}
+
+	if !authorize(w, r, addr, types.APIKeyScopeTrade) {
+		return
+	}
+
+	res, err := e.orderService.CancelOrderSync(oc, engineResponseTimeout)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteAPIError(w, engineError(err))
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, res)
+}
+
+// clientOrderID returns the client order ID of the order of the given hash, if any
+func (e *orderEndpoint) clientOrderID(h common.Hash) string {
+	o, err := e.orderService.GetByHash(h)
+	if err != nil || o == nil {
+		return ""
+	}
+
+	return o.ClientOrderID
+}
+
+// engineError converts an error returned by the order service into an API error
+func engineError(err error) *apierrors.APIError {
+	if err == services.ErrEngineTimeout {
+		return apierrors.EngineTimeout()
+	}
+
+	if err == services.ErrAccountBlocked {
+		return apierrors.Forbidden(err.Error())
+	}
+
+	return apierrors.OrderRejected(err)
 }
 
 // ws function handles incoming websocket messages on the order channel
@@ -190,27 +378,27 @@ func (e *orderEndpoint) handleNewOrder(ev *types.WebsocketEvent, c *ws.Client) {
 	err = json.Unmarshal(bytes, &o)
 	if err != nil {
 		logger.Error(err)
-		c.SendOrderErrorMessage(err, o.Hash)
+		c.SendOrderErrorMessage(err, o.Hash, o.ClientOrderID)
 		return
 	}
 
 	o.Hash = o.ComputeHash()
-	ws.RegisterOrderConnection(o.UserAddress, c)
+	if !c.IsAuthenticated(o.UserAddress) {
+		c.SendOrderErrorMessage(ws.ErrNotAuthenticated, o.Hash, o.ClientOrderID)
+		return
+	}
 
-	acc, err := e.accountService.FindOrCreate(o.UserAddress)
+	_, err = e.accountService.FindOrCreate(o.UserAddress)
 	if err != nil {
 		logger.Error(err)
-		c.SendOrderErrorMessage(err, o.Hash)
-	}
-
-	if acc.IsBlocked {
-		c.SendMessage(ws.OrderChannel, "ERROR", errors.New("Account is blocked"))
+		c.SendOrderErrorMessage(err, o.Hash, o.ClientOrderID)
+		return
 	}
 
 	err = e.orderService.NewOrder(o)
 	if err != nil {
 		logger.Error(err)
-		c.SendOrderErrorMessage(err, o.Hash)
+		c.SendOrderErrorMessage(err, o.Hash, o.ClientOrderID)
 		return
 	}
 }
@@ -223,21 +411,31 @@ func (e *orderEndpoint) handleCancelOrder(ev *types.WebsocketEvent, c *ws.Client
 	err = oc.UnmarshalJSON(bytes)
 	if err != nil {
 		logger.Error(err)
-		c.SendOrderErrorMessage(err, oc.OrderHash)
+		c.SendOrderErrorMessage(err, oc.OrderHash, e.clientOrderID(oc.OrderHash))
+		return
+	}
+
+	if oc.Signature == nil {
+		c.SendOrderErrorMessage(errors.New("Signature is missing"), oc.OrderHash, e.clientOrderID(oc.OrderHash))
+		return
 	}
 
 	addr, err := oc.GetSenderAddress()
 	if err != nil {
 		logger.Error(err)
-		c.SendOrderErrorMessage(err, oc.OrderHash)
+		c.SendOrderErrorMessage(err, oc.OrderHash, e.clientOrderID(oc.OrderHash))
+		return
 	}
 
-	ws.RegisterOrderConnection(addr, c)
+	if !c.IsAuthenticated(addr) {
+		c.SendOrderErrorMessage(ws.ErrNotAuthenticated, oc.OrderHash, e.clientOrderID(oc.OrderHash))
+		return
+	}
 
 	orderErr := e.orderService.CancelOrder(oc)
 	if orderErr != nil {
 		logger.Error(err)
-		c.SendOrderErrorMessage(orderErr, oc.OrderHash)
+		c.SendOrderErrorMessage(orderErr, oc.OrderHash, e.clientOrderID(oc.OrderHash))
 		return
 	}
 }
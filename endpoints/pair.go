@@ -30,7 +30,13 @@ func ServePairResource(
 	r.HandleFunc("/pairs/data", e.HandleGetPairData).Methods("GET")
 }
 
+// HandleCreatePairs creates the pairs of a base token against all the quote tokens. It requires
+// an API key with the admin scope.
 func (e *pairEndpoint) HandleCreatePairs(w http.ResponseWriter, r *http.Request) {
+	if authenticate(w, r, types.APIKeyScopeAdmin) == nil {
+		return
+	}
+
 	token := types.Token{}
 	decoder := json.NewDecoder(r.Body)
 
@@ -61,6 +67,9 @@ func (e *pairEndpoint) HandleCreatePairs(w http.ResponseWriter, r *http.Request)
 		case services.ErrNoContractCode:
 			httputils.WriteError(w, http.StatusBadRequest, "Contract not found at given address")
 			return
+		case services.ErrTokenNoSupply, services.ErrTokenTransferFailed:
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
 		default:
 			logger.Error(err)
 			httputils.WriteError(w, http.StatusInternalServerError, "Internal server error")
@@ -76,7 +85,12 @@ func (e *pairEndpoint) HandleCreatePairs(w http.ResponseWriter, r *http.Request)
 	httputils.WriteJSON(w, http.StatusCreated, pairs)
 }
 
+// HandleCreatePair creates a pair. It requires an API key with the admin scope.
 func (e *pairEndpoint) HandleCreatePair(w http.ResponseWriter, r *http.Request) {
+	if authenticate(w, r, types.APIKeyScopeAdmin) == nil {
+		return
+	}
+
 	p := &types.Pair{}
 
 	decoder := json.NewDecoder(r.Body)
@@ -114,6 +128,9 @@ func (e *pairEndpoint) HandleCreatePair(w http.ResponseWriter, r *http.Request)
 		case services.ErrNoContractCode:
 			httputils.WriteError(w, http.StatusBadRequest, "Contract not found at given address")
 			return
+		case services.ErrTokenNoSupply, services.ErrTokenTransferFailed:
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
 		default:
 			logger.Error(err)
 			httputils.WriteError(w, http.StatusInternalServerError, "")
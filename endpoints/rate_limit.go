@@ -0,0 +1,96 @@
+package endpoints
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "github.com/Proofsuite/amp-matching-engine/errors"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/Proofsuite/amp-matching-engine/utils/ratelimit"
+	"github.com/gorilla/mux"
+)
+
+// NewIPRateLimitMiddleware limits the requests of each client IP. It must run before the
+// authentication middleware so that requests with bogus API keys, which are refused by the
+// authentication, still take a token from the budget of their IP.
+func NewIPRateLimitMiddleware(limits ratelimit.Limits, trustProxy bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			category := rateLimitCategory(r)
+			res := limits.Allow(category, "ip:"+httputils.ClientIP(r, trustProxy))
+			if !writeRateLimit(w, res, category) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// NewRateLimitMiddleware limits the requests of each API key and account. Order placements,
+// order cancels and the other requests have separate budgets. It must run after the
+// authentication middleware so that the API key of the request is known, the client IP is
+// limited by NewIPRateLimitMiddleware.
+func NewRateLimitMiddleware(limits ratelimit.Limits) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			k := requestAPIKey(r)
+			if k == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			category := rateLimitCategory(r)
+			res := limits.Allow(category, "key:"+k.Key, "address:"+k.UserAddress.Hex())
+			if !writeRateLimit(w, res, category) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeRateLimit returns the state of the most constrained bucket seen so far in the
+// X-RateLimit-* headers. Refused requests get a 429 error with a Retry-After header and false
+// is returned.
+func writeRateLimit(w http.ResponseWriter, res ratelimit.Result, category string) bool {
+	if res.Limit > 0 {
+		remaining, err := strconv.Atoi(w.Header().Get("X-RateLimit-Remaining"))
+		if err != nil || res.Remaining <= remaining {
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(res.ResetAfter.Seconds()))))
+		}
+	}
+
+	if !res.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+		httputils.WriteAPIError(w, apierrors.TooManyRequests(category))
+		return false
+	}
+
+	return true
+}
+
+// rateLimitCategory returns the rate limit budget of a request
+func rateLimitCategory(r *http.Request) string {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/orders":
+		return ratelimit.Orders
+	case r.Method == http.MethodPost && r.URL.Path == "/orders/cancel":
+		return ratelimit.Cancels
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/orders/"):
+		return ratelimit.Cancels
+	default:
+		return ratelimit.MarketData
+	}
+}
+
+// seconds rounds a duration up to the second, with a minimum of one second
+func seconds(d time.Duration) int {
+	return int(math.Max(1, math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,79 @@
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/gorilla/mux"
+)
+
+type reconciliationEndpoint struct {
+	reconciliationService interfaces.ReconciliationService
+}
+
+// ServeReconciliationResource sets up the routing of the settlement reconciliation endpoint
+func ServeReconciliationResource(
+	r *mux.Router,
+	reconciliationService interfaces.ReconciliationService,
+) {
+	e := &reconciliationEndpoint{reconciliationService}
+	r.HandleFunc("/reconciliation", e.handleGetReconciliationReport).Methods("GET")
+}
+
+// handleGetReconciliationReport compares the trades collection with the indexed exchange contract
+// trade events between the startBlock and endBlock query parameters (included). It requires
+// an API key with the admin scope.
+func (e *reconciliationEndpoint) handleGetReconciliationReport(w http.ResponseWriter, r *http.Request) {
+	if authenticate(w, r, types.APIKeyScopeAdmin) == nil {
+		return
+	}
+
+	v := r.URL.Query()
+	sb := v.Get("startBlock")
+	eb := v.Get("endBlock")
+
+	if sb == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "startBlock Parameter missing")
+		return
+	}
+
+	if eb == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "endBlock Parameter missing")
+		return
+	}
+
+	start, err := strconv.ParseUint(sb, 10, 64)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid startBlock")
+		return
+	}
+
+	end, err := strconv.ParseUint(eb, 10, 64)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid endBlock")
+		return
+	}
+
+	if end < start {
+		httputils.WriteError(w, http.StatusBadRequest, "endBlock should be greater than startBlock")
+		return
+	}
+
+	report, err := e.reconciliationService.Reconcile(start, end)
+	if err == services.ErrBlockRangeNotIndexed {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,93 @@
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+)
+
+type statementEndpoint struct {
+	statementService interfaces.StatementService
+}
+
+// ServeStatementResource sets up the routing of the account statement endpoint
+func ServeStatementResource(
+	r *mux.Router,
+	statementService interfaces.StatementService,
+) {
+	e := &statementEndpoint{statementService}
+	r.HandleFunc("/statements", e.handleGetStatement).Methods("GET")
+}
+
+// handleGetStatement returns the fees, volumes, average prices and realized PnL of an address
+// by pair and period. The optional from and to query parameters are unix timestamps in seconds.
+func (e *statementEndpoint) handleGetStatement(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	addr := v.Get("address")
+	period := v.Get("period")
+
+	if addr == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
+		return
+	}
+
+	if !common.IsHexAddress(addr) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+		return
+	}
+
+	if !authorize(w, r, common.HexToAddress(addr), types.APIKeyScopeRead) {
+		return
+	}
+
+	if period == "" {
+		period = types.StatementPeriodMonth
+	}
+
+	err := types.ValidateStatementPeriod(period)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var from, to time.Time
+	if f := v.Get("from"); f != "" {
+		t, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid 'from' timestamp")
+			return
+		}
+
+		from = time.Unix(t, 0)
+	}
+
+	if t := v.Get("to"); t != "" {
+		ts, err := strconv.ParseInt(t, 10, 64)
+		if err != nil {
+			httputils.WriteError(w, http.StatusBadRequest, "Invalid 'to' timestamp")
+			return
+		}
+
+		to = time.Unix(ts, 0)
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		httputils.WriteError(w, http.StatusBadRequest, "'to' must not be before 'from'")
+		return
+	}
+
+	statement, err := e.statementService.GetStatement(common.HexToAddress(addr), period, from, to)
+	if err != nil {
+		logger.Error(err)
+		httputils.WriteError(w, http.StatusInternalServerError, "")
+		return
+	}
+
+	httputils.WriteJSON(w, http.StatusOK, statement)
+}
@@ -29,7 +29,12 @@ func ServeTokenResource(
 	r.HandleFunc("/tokens", e.HandleCreateTokens).Methods("POST")
 }
 
+// HandleCreateTokens registers a token. It requires an API key with the admin scope.
 func (e *tokenEndpoint) HandleCreateTokens(w http.ResponseWriter, r *http.Request) {
+	if authenticate(w, r, types.APIKeyScopeAdmin) == nil {
+		return
+	}
+
 	var t types.Token
 	decoder := json.NewDecoder(r.Body)
 
@@ -37,16 +42,26 @@ func (e *tokenEndpoint) HandleCreateTokens(w http.ResponseWriter, r *http.Reques
 	if err != nil {
 		logger.Error(err)
 		httputils.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		return
 	}
 
 	defer r.Body.Close()
 
+	if (t.Address == common.Address{}) {
+		httputils.WriteError(w, http.StatusBadRequest, "Token 'address' parameter is required")
+		return
+	}
+
 	err = e.tokenService.Create(&t)
 	if err != nil {
-		if err == services.ErrTokenExists {
+		switch err {
+		case services.ErrTokenExists:
 			httputils.WriteError(w, http.StatusBadRequest, "")
 			return
-		} else {
+		case services.ErrNoContractCode, services.ErrTokenMetadataMismatch, services.ErrTokenNoSupply, services.ErrTokenTransferFailed:
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		default:
 			logger.Error(err)
 			httputils.WriteError(w, http.StatusInternalServerError, "")
 			return
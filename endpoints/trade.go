@@ -3,7 +3,7 @@ package endpoints
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
+	"net/url"
 
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/types"
@@ -32,87 +32,61 @@ func ServeTradeResource(
 // history is reponsible for handling pair's trade history requests
 func (e *tradeEndpoint) HandleGetTradeHistory(w http.ResponseWriter, r *http.Request) {
 	v := r.URL.Query()
-	bt := v.Get("baseToken")
-	qt := v.Get("quoteToken")
-	l := v.Get("limit")
-
-	if bt == "" {
+	if v.Get("baseToken") == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "baseToken Parameter missing")
 		return
 	}
 
-	if qt == "" {
+	if v.Get("quoteToken") == "" {
 		httputils.WriteError(w, http.StatusBadRequest, "quoteToken Parameter missing")
 		return
 	}
 
-	if !common.IsHexAddress(bt) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid base token address")
-		return
-	}
+	e.writeTradePage(w, v, 20)
+}
 
-	if !common.IsHexAddress(qt) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid quote token address")
+// get is reponsible for handling user's trade history requests
+func (e *tradeEndpoint) HandleGetTrades(w http.ResponseWriter, r *http.Request) {
+	v := r.URL.Query()
+	if v.Get("address") == "" {
+		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
 		return
 	}
 
-	limit := 20
-	if l != "" {
-		limit, _ = strconv.Atoi(l)
-	}
-
-	baseToken := common.HexToAddress(bt)
-	quoteToken := common.HexToAddress(qt)
-	res, err := e.tradeService.GetSortedTrades(baseToken, quoteToken, limit)
-	if err != nil {
-		logger.Error(err)
-		httputils.WriteError(w, http.StatusInternalServerError, "")
+	if !common.IsHexAddress(v.Get("address")) {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
 		return
 	}
 
-	if res == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Trade{})
+	if !authorize(w, r, common.HexToAddress(v.Get("address")), types.APIKeyScopeRead) {
 		return
 	}
 
-	httputils.WriteJSON(w, http.StatusOK, res)
+	e.writeTradePage(w, v, types.DefaultPageLimit)
 }
 
-// get is reponsible for handling user's trade history requests
-func (e *tradeEndpoint) HandleGetTrades(w http.ResponseWriter, r *http.Request) {
-	v := r.URL.Query()
-	addr := v.Get("address")
-	limit := v.Get("limit")
-
-	if addr == "" {
-		httputils.WriteError(w, http.StatusBadRequest, "address Parameter missing")
+// writeTradePage parses the trade list parameters and writes the corresponding page of trades.
+// Trades have no side of their own, a side filter is therefore rejected.
+func (e *tradeEndpoint) writeTradePage(w http.ResponseWriter, v url.Values, defaultLimit int) {
+	if v.Get("side") != "" {
+		httputils.WriteError(w, http.StatusBadRequest, "side filter is not supported for trades")
 		return
 	}
 
-	if !common.IsHexAddress(addr) {
-		httputils.WriteError(w, http.StatusBadRequest, "Invalid Address")
+	q, err := parseHistoryQuery(v, defaultLimit)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	lim := 100
-	if limit != "" {
-		lim, _ = strconv.Atoi(limit)
-	}
-
-	address := common.HexToAddress(addr)
-	res, err := e.tradeService.GetSortedTradesByUserAddress(address, lim)
+	page, err := e.tradeService.GetPage(q)
 	if err != nil {
 		logger.Error(err)
 		httputils.WriteError(w, http.StatusInternalServerError, "")
 		return
 	}
 
-	if res == nil {
-		httputils.WriteJSON(w, http.StatusOK, []types.Trade{})
-		return
-	}
-
-	httputils.WriteJSON(w, http.StatusOK, res)
+	httputils.WriteJSON(w, http.StatusOK, page)
 }
 
 func (e *tradeEndpoint) tradeWebsocket(input interface{}, c *ws.Client) {
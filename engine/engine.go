@@ -18,6 +18,7 @@ type Engine struct {
 	orderDao     interfaces.OrderDao
 	tradeDao     interfaces.TradeDao
 	pairDao      interfaces.PairDao
+	compliance   interfaces.ComplianceService
 }
 
 var logger = utils.EngineLogger
@@ -28,6 +29,7 @@ func NewEngine(
 	orderDao interfaces.OrderDao,
 	tradeDao interfaces.TradeDao,
 	pairDao interfaces.PairDao,
+	compliance interfaces.ComplianceService,
 ) *Engine {
 	pairs, err := pairDao.GetAll()
 
@@ -41,6 +43,7 @@ func NewEngine(
 			rabbitMQConn: rabbitMQConn,
 			orderDao:     orderDao,
 			tradeDao:     tradeDao,
+			compliance:   compliance,
 			pair:         &p,
 			mutex:        &sync.Mutex{},
 		}
@@ -54,11 +57,22 @@ func NewEngine(
 		orderDao,
 		tradeDao,
 		pairDao,
+		compliance,
 	}
 
 	return engine
 }
 
+// OrderBookCodes returns the codes of the pairs whose order book is loaded in the engine
+func (e *Engine) OrderBookCodes() []string {
+	codes := []string{}
+	for code := range e.orderbooks {
+		codes = append(codes, code)
+	}
+
+	return codes
+}
+
 // HandleOrders parses incoming rabbitmq order messages and redirects them to the appropriate
 // engine function
 func (e *Engine) HandleOrders(msg *rabbitmq.Message) error {
@@ -153,6 +167,7 @@ func (e *Engine) handleNewOrder(bytes []byte) error {
 			rabbitMQConn: e.rabbitMQConn,
 			orderDao:     e.orderDao,
 			tradeDao:     e.tradeDao,
+			compliance:   e.compliance,
 			pair:         p,
 			mutex:        &sync.Mutex{},
 		}
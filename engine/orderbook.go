@@ -38,6 +38,7 @@ type OrderBook struct {
 	rabbitMQConn *rabbitmq.Connection
 	orderDao     interfaces.OrderDao
 	tradeDao     interfaces.TradeDao
+	compliance   interfaces.ComplianceService
 	pair         *types.Pair
 	mutex        *sync.Mutex
 }
@@ -50,7 +51,18 @@ func (ob *OrderBook) newOrder(o *types.Order) (err error) {
 	defer ob.mutex.Unlock()
 
 	res := &types.EngineResponse{}
-	if o.Side == "SELL" {
+	if ob.compliance.IsBlocked(o.UserAddress) {
+		// the account was blocked after the order was accepted by the order service
+		o.Status = "ERROR"
+		err = ob.orderDao.UpdateOrderStatus(o.Hash, "ERROR")
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		res.Status = "ERROR"
+		res.Order = o
+	} else if o.Side == "SELL" {
 		res, err = ob.sellOrder(o)
 		if err != nil {
 			logger.Error(err)
@@ -104,6 +116,8 @@ func (ob *OrderBook) buyOrder(o *types.Order) (*types.EngineResponse, error) {
 		return nil, err
 	}
 
+	matchingOrders = ob.filterBlockedMakers(matchingOrders)
+
 	// case where no order is matched
 	if len(matchingOrders) == 0 {
 		ob.addOrder(o)
@@ -168,6 +182,8 @@ func (ob *OrderBook) sellOrder(o *types.Order) (*types.EngineResponse, error) {
 		return nil, err
 	}
 
+	matchingOrders = ob.filterBlockedMakers(matchingOrders)
+
 	if len(matchingOrders) == 0 {
 		o.Status = "OPEN"
 		ob.addOrder(o)
@@ -268,6 +284,19 @@ func (ob *OrderBook) execute(takerOrder *types.Order, makerOrder *types.Order) (
 	return trade, nil
 }
 
+// filterBlockedMakers removes the orders of the blocked accounts from the matching orders. The
+// orders of a blocked account stay in the orderbook until their cancel messages are processed.
+func (ob *OrderBook) filterBlockedMakers(orders []*types.Order) []*types.Order {
+	res := []*types.Order{}
+	for _, o := range orders {
+		if !ob.compliance.IsBlocked(o.UserAddress) {
+			res = append(res, o)
+		}
+	}
+
+	return res
+}
+
 // CancelOrder is used to cancel the order from orderbook
 func (ob *OrderBook) cancelOrder(o *types.Order) error {
 	ob.mutex.Lock()
@@ -296,35 +325,62 @@ func (ob *OrderBook) cancelOrder(o *types.Order) error {
 }
 
 // cancelTrades revertTrades and reintroduces the taker orders in the orderbook
+// invalidateMakerOrders invalidates the maker orders of the matches. The trades of the matches are
+// cancelled and the filled amounts of their taker orders restored. Maker orders without trade (orders
+// whose maker balance or allowance dropped) are only invalidated while they rest in the orderbook: orders
+// that have been filled or cancelled since the invalidation was requested are left untouched.
 func (ob *OrderBook) invalidateMakerOrders(matches types.Matches) error {
 	ob.mutex.Lock()
 	defer ob.mutex.Unlock()
 
-	orders := matches.MakerOrders
 	trades := matches.Trades
 	tradeAmounts := matches.TradeAmounts()
 	makerOrderHashes := []common.Hash{}
 	takerOrderHashes := []common.Hash{}
+	traded := map[common.Hash]bool{}
 
-	for i, _ := range orders {
+	for i, _ := range trades {
 		makerOrderHashes = append(makerOrderHashes, trades[i].MakerOrderHash)
 		takerOrderHashes = append(takerOrderHashes, trades[i].TakerOrderHash)
+		traded[trades[i].MakerOrderHash] = true
 	}
 
-	takerOrders, err := ob.orderDao.UpdateOrderFilledAmounts(takerOrderHashes, tradeAmounts)
-	if err != nil {
-		logger.Error(err)
-		return err
+	untradedOrderHashes := []common.Hash{}
+	for _, o := range matches.MakerOrders {
+		if !traded[o.Hash] {
+			untradedOrderHashes = append(untradedOrderHashes, o.Hash)
+		}
 	}
 
-	makerOrders, err := ob.orderDao.UpdateOrderStatusesByHashes("INVALIDATED", makerOrderHashes...)
+	restingOrderHashes, err := ob.restingOrderHashes(untradedOrderHashes)
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
 
-	//TODO in the case the trades are not in the database they should not be created.
-	cancelledTrades, err := ob.tradeDao.UpdateTradeStatusesByOrderHashes("CANCELLED", takerOrderHashes...)
+	makerOrderHashes = append(makerOrderHashes, restingOrderHashes...)
+	if len(makerOrderHashes) == 0 {
+		return nil
+	}
+
+	takerOrders := []*types.Order{}
+	cancelledTrades := []*types.Trade{}
+	if len(trades) > 0 {
+		takerOrders, err = ob.orderDao.UpdateOrderFilledAmounts(takerOrderHashes, tradeAmounts)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		//TODO in the case the trades are not in the database they should not be created.
+		cancelledTrades, err = ob.tradeDao.UpdateTradeStatusesByOrderHashes("CANCELLED", takerOrderHashes...)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	makerOrders, err := ob.orderDao.UpdateOrderStatusesByHashes("INVALIDATED", makerOrderHashes...)
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -405,6 +461,28 @@ func (ob *OrderBook) invalidateTakerOrders(matches types.Matches) error {
 	return nil
 }
 
+// restingOrderHashes returns the hashes of the given orders that are still open or partially filled
+func (ob *OrderBook) restingOrderHashes(hashes []common.Hash) ([]common.Hash, error) {
+	if len(hashes) == 0 {
+		return []common.Hash{}, nil
+	}
+
+	current, err := ob.orderDao.GetByHashes(hashes)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	resting := []common.Hash{}
+	for _, o := range current {
+		if o.Status == "OPEN" || o.Status == "PARTIAL_FILLED" {
+			resting = append(resting, o.Hash)
+		}
+	}
+
+	return resting, nil
+}
+
 func (ob *OrderBook) InvalidateOrder(o *types.Order) (*types.EngineResponse, error) {
 	ob.mutex.Lock()
 	defer ob.mutex.Unlock()
@@ -14,6 +14,7 @@ import (
 	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
 	"github.com/Proofsuite/amp-matching-engine/utils/units"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
 )
 
 var db *daos.Database
@@ -49,8 +50,10 @@ func setupTest() (
 	pairDao := new(mocks.PairDao)
 	tradeDao := new(mocks.TradeDao)
 	pairDao.On("GetAll").Return([]types.Pair{*pair}, nil)
+	compliance := new(mocks.ComplianceService)
+	compliance.On("IsBlocked", mock.Anything).Return(false)
 
-	eng := NewEngine(rabbitConn, orderDao, tradeDao, pairDao)
+	eng := NewEngine(rabbitConn, orderDao, tradeDao, pairDao, compliance)
 	ex := testutils.GetTestAddress1()
 	maker := testutils.GetTestWallet1()
 	taker := testutils.GetTestWallet2()
@@ -117,6 +120,38 @@ func TestBuyOrder(t *testing.T) {
 	testutils.CompareEngineResponse(t, expected, res)
 }
 
+func TestBlockedMakerOrder(t *testing.T) {
+	_, ob, _, maker, _, _, _, _, factory1, factory2 := setupTest()
+
+	compliance := new(mocks.ComplianceService)
+	compliance.On("IsBlocked", maker.Address).Return(true)
+	compliance.On("IsBlocked", mock.Anything).Return(false)
+	ob.compliance = compliance
+
+	o1, _ := factory1.NewSellOrder(1e3, 1e8)
+	o2, _ := factory2.NewBuyOrder(1e3, 1e8)
+
+	_, err := ob.sellOrder(&o1)
+	if err != nil {
+		t.Error("Error in sell order: ", err)
+	}
+
+	exp2 := o2
+	exp2.Status = "OPEN"
+	expected := &types.EngineResponse{
+		Status:  "ORDER_ADDED",
+		Order:   &exp2,
+		Matches: nil,
+	}
+
+	res, err := ob.buyOrder(&o2)
+	if err != nil {
+		t.Error("Error in buy order: ", err)
+	}
+
+	testutils.CompareEngineResponse(t, expected, res)
+}
+
 func TestFillOrder1(t *testing.T) {
 	_, ob, _, _, _, _, _, _, factory1, factory2 := setupTest()
 
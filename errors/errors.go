@@ -27,6 +27,32 @@ func Unauthorized(err string) *APIError {
 	return NewHTTPError(http.StatusUnauthorized, "UNAUTHORIZED", Params{"error": err})
 }
 
+// BadRequest creates a new API error representing a malformed request (HTTP 400)
+func BadRequest(err string) *APIError {
+	return NewHTTPError(http.StatusBadRequest, "BAD_REQUEST", Params{"error": err})
+}
+
+// Forbidden creates a new API error representing a request that is not allowed (HTTP 403)
+func Forbidden(err string) *APIError {
+	return NewHTTPError(http.StatusForbidden, "FORBIDDEN", Params{"error": err})
+}
+
+// OrderRejected creates a new API error representing an order or an order cancel refused by the
+// order service or by the matching engine (HTTP 422)
+func OrderRejected(err error) *APIError {
+	return NewHTTPError(http.StatusUnprocessableEntity, "ORDER_REJECTED", Params{"error": err.Error()})
+}
+
+// TooManyRequests creates a new API error representing a request refused by a rate limit (HTTP 429)
+func TooManyRequests(category string) *APIError {
+	return NewHTTPError(http.StatusTooManyRequests, "RATE_LIMITED", Params{"category": category})
+}
+
+// EngineTimeout creates a new API error representing a matching engine response that did not arrive in time (HTTP 504)
+func EngineTimeout() *APIError {
+	return NewHTTPError(http.StatusGatewayTimeout, "ENGINE_TIMEOUT", nil)
+}
+
 // InvalidData converts a data validation error into an API error (HTTP 400)
 func InvalidData(errs validation.Errors) *APIError {
 	result := []validationError{}
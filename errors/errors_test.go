@@ -29,3 +29,23 @@ func TestInvalidData(t *testing.T) {
 func TestNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, NotFound("abc").Status)
 }
+
+func TestBadRequest(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, BadRequest("abc").Status)
+}
+
+func TestForbidden(t *testing.T) {
+	assert.Equal(t, http.StatusForbidden, Forbidden("abc").Status)
+}
+
+func TestTooManyRequests(t *testing.T) {
+	assert.Equal(t, http.StatusTooManyRequests, TooManyRequests("orders").Status)
+}
+
+func TestOrderRejected(t *testing.T) {
+	assert.Equal(t, http.StatusUnprocessableEntity, OrderRejected(errs.New("abc")).Status)
+}
+
+func TestEngineTimeout(t *testing.T) {
+	assert.Equal(t, http.StatusGatewayTimeout, EngineTimeout().Status)
+}
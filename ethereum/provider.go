@@ -3,19 +3,25 @@ package ethereum
 import (
 	"context"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/Proofsuite/amp-matching-engine/contracts/contractsinterfaces"
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/utils"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+var transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
 type EthereumProvider struct {
 	Client interfaces.EthereumClient
 	Config interfaces.EthereumConfig
@@ -127,6 +133,44 @@ func (e *EthereumProvider) GetPendingNonceAt(a common.Address) (uint64, error) {
 	return nonce, nil
 }
 
+// BlockNumber returns the number of the latest block
+func (e *EthereumProvider) BlockNumber() (uint64, error) {
+	header, err := e.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+// GetTransactionReceipt returns the receipt of a mined transaction, or nil if the transaction
+// is not mined
+func (e *EthereumProvider) GetTransactionReceipt(h common.Hash) (*eth.Receipt, error) {
+	receipt, err := e.Client.TransactionReceipt(context.Background(), h)
+	if err == ethereum.NotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// GetBlockTime returns the timestamp of a block
+func (e *EthereumProvider) GetBlockTime(n uint64) (time.Time, error) {
+	header, err := e.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(n))
+	if err != nil {
+		logger.Error(err)
+		return time.Time{}, err
+	}
+
+	return time.Unix(header.Time.Int64(), 0), nil
+}
+
 func (e *EthereumProvider) Decimals(token common.Address) (uint8, error) {
 	var tokenInterface *contractsinterfaces.ERC20
 	var err error
@@ -185,6 +229,97 @@ func (e *EthereumProvider) Symbol(token common.Address) (string, error) {
 	return symbol, nil
 }
 
+// Name returns the name of a token. The name is optional in the ERC20 standard
+func (e *EthereumProvider) Name(token common.Address) (string, error) {
+	tokenInterface, err := contractsinterfaces.NewERC20(token, e.Client)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	opts := &bind.CallOpts{Pending: true}
+	name, err := tokenInterface.Name(opts)
+	if err != nil {
+		logger.Error(err)
+		return "", err
+	}
+
+	return name, nil
+}
+
+func (e *EthereumProvider) TotalSupply(token common.Address) (*big.Int, error) {
+	tokenInterface, err := contractsinterfaces.NewERC20(token, e.Client)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	opts := &bind.CallOpts{Pending: true}
+	supply, err := tokenInterface.TotalSupply(opts)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return supply, nil
+}
+
+// CallTransfer simulates the transfer of an amount of token from an account and returns the raw
+// data returned by the transfer function. The data is empty for the tokens that do not return a
+// boolean. An error is returned if the transfer reverts.
+func (e *EthereumProvider) CallTransfer(token, from, to common.Address, amount *big.Int) ([]byte, error) {
+	erc20, err := abi.JSON(strings.NewReader(contractsinterfaces.ERC20ABI))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	data, err := erc20.Pack("transfer", to, amount)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	call := ethereum.CallMsg{From: from, To: &token, Data: data}
+	res, err := e.Client.CallContract(context.Background(), call, nil)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RecentTransfers returns the Transfer events emitted by a token contract in the given number of
+// most recent blocks
+func (e *EthereumProvider) RecentTransfers(token common.Address, blocks int64) ([]eth.Log, error) {
+	ctx := context.Background()
+	header, err := e.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	from := new(big.Int).Sub(header.Number, big.NewInt(blocks))
+	if from.Sign() < 0 {
+		from = big.NewInt(0)
+	}
+
+	query := ethereum.FilterQuery{
+		FromBlock: from,
+		Addresses: []common.Address{token},
+		Topics:    [][]common.Hash{{transferEventTopic}},
+	}
+
+	logs, err := e.Client.FilterLogs(ctx, query)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return logs, nil
+}
+
 func (e *EthereumProvider) BalanceOf(owner common.Address, token common.Address) (*big.Int, error) {
 	tokenInterface, err := contractsinterfaces.NewERC20(token, e.Client)
 	if err != nil {
@@ -218,6 +353,7 @@ func (e *EthereumProvider) Allowance(owner, spender, token common.Address) (*big
 	return a, nil
 }
 
+// ExchangeAllowance returns the allowance of the owner to the default exchange contract
 func (e *EthereumProvider) ExchangeAllowance(owner, token common.Address) (*big.Int, error) {
 	tokenInterface, err := contractsinterfaces.NewERC20(token, e.Client)
 	if err != nil {
@@ -28,10 +28,17 @@ type OrderDao interface {
 	GetByID(id bson.ObjectId) (*types.Order, error)
 	GetByHash(h common.Hash) (*types.Order, error)
 	GetByHashes(hashes []common.Hash) ([]*types.Order, error)
+	GetByClientOrderID(addr common.Address, id string) (*types.Order, error)
+	Reserve(o *types.Order) error
+	MigrateNonces() error
 
 	GetByUserAddress(addr common.Address, limit ...int) ([]*types.Order, error)
 	GetCurrentByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
+	GetCurrentOrders() ([]*types.Order, error)
 	GetHistoryByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
+	GetPage(q *types.HistoryQuery) (*types.OrderPage, error)
+	GetHistoryPage(q *types.HistoryQuery) (*types.OrderPage, error)
+	StreamByUserAddress(addr common.Address, fn func(o *types.Order) error) error
 	GetMatchingBuyOrders(o *types.Order) ([]*types.Order, error)
 	GetMatchingSellOrders(o *types.Order) ([]*types.Order, error)
 	UpdateOrderFilledAmount(h common.Hash, value *big.Int) error
@@ -58,6 +65,10 @@ type AccountDao interface {
 	UpdateBalance(owner common.Address, token common.Address, balance *big.Int) (err error)
 	FindOrCreate(addr common.Address) (*types.Account, error)
 	UpdateAllowance(owner common.Address, token common.Address, allowance *big.Int) (err error)
+	SyncTokenBalance(owner common.Address, token common.Address, tokenBalance *types.TokenBalance) (err error)
+	UnsyncTokenBalances(token common.Address) error
+	GetBlockedAddresses() ([]common.Address, error)
+	UpdateIsBlocked(owner common.Address, blocked bool) error
 	Drop()
 }
 
@@ -68,6 +79,8 @@ type WalletDao interface {
 	GetByAddress(addr common.Address) (*types.Wallet, error)
 	GetDefaultAdminWallet() (*types.Wallet, error)
 	GetOperatorWallets() ([]*types.Wallet, error)
+	GetPrivateKeys() (map[common.Address]string, error)
+	RemovePrivateKey(a common.Address) error
 }
 
 type PairDao interface {
@@ -81,6 +94,7 @@ type PairDao interface {
 	GetDefaultPairs() ([]types.Pair, error)
 	GetListedPairs() ([]types.Pair, error)
 	GetUnlistedPairs() ([]types.Pair, error)
+	UpdateStatus(baseToken, quoteToken common.Address, listed, active bool) error
 }
 
 type TradeDao interface {
@@ -95,8 +109,15 @@ type TradeDao interface {
 	GetByMakerOrderHash(h common.Hash) ([]*types.Trade, error)
 	GetByTakerOrderHash(h common.Hash) ([]*types.Trade, error)
 	GetByOrderHashes(hashes []common.Hash) ([]*types.Trade, error)
+	GetByTxHashes(hashes []common.Hash) ([]*types.Trade, error)
+	GetPendingTradesBefore(t time.Time) ([]*types.Trade, error)
+	GetUnsentTrades() ([]*types.Trade, error)
+	GetSettledTradesBetween(start, end time.Time) ([]*types.Trade, error)
 	GetSortedTrades(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetSortedTradesByUserAddress(a common.Address, limit ...int) ([]*types.Trade, error)
+	GetPage(q *types.HistoryQuery) (*types.TradePage, error)
+	StreamByUserAddress(a common.Address, fn func(t *types.Trade) error) error
+	GetAccountVolumes(a, qt common.Address, since time.Time) (map[common.Address]*big.Int, error)
 	GetNTradesByPairAddress(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetTradesByPairAddress(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetAllTradesByPairAddress(bt, qt common.Address) ([]*types.Trade, error)
@@ -118,9 +139,46 @@ type TokenDao interface {
 	GetListedTokens() ([]types.Token, error)
 	GetUnlistedTokens() ([]types.Token, error)
 	GetListedBaseTokens() ([]types.Token, error)
+	UpdateStatus(addr common.Address, listed, active bool) error
 	Drop() error
 }
 
+type EventDao interface {
+	Upsert(e *types.ContractEvent) error
+	GetByTxHash(h common.Hash) ([]*types.ContractEvent, error)
+	GetByBlockRange(eventType string, start, end uint64) ([]*types.ContractEvent, error)
+	RemoveReorged(contract common.Address, start, end uint64, blockHashes []common.Hash) error
+	GetLastProcessedBlock(contract common.Address) (uint64, error)
+	SetLastProcessedBlock(contract common.Address, n uint64) error
+	Drop() error
+}
+
+type FeeTierDao interface {
+	Create(t *types.FeeTier) error
+	GetAll() ([]*types.FeeTier, error)
+	GetByQuoteToken(qt common.Address) ([]*types.FeeTier, error)
+	DeleteByName(qt common.Address, name string) error
+}
+
+type FeeOverrideDao interface {
+	Upsert(o *types.FeeOverride) error
+	GetByUserAddress(a, qt common.Address) (*types.FeeOverride, error)
+	Delete(a, qt common.Address) error
+}
+
+type AuditLogDao interface {
+	Create(l *types.AuditLog) error
+	GetLatest(target string, limit int) ([]*types.AuditLog, error)
+	Delete(id bson.ObjectId) error
+}
+
+type APIKeyDao interface {
+	Create(k *types.APIKey) error
+	GetByKey(key string) (*types.APIKey, error)
+	GetByUserAddress(a common.Address) ([]*types.APIKey, error)
+	Delete(a common.Address, key string) error
+}
+
 type Exchange interface {
 	GetAddress() common.Address
 	GetTxCallOptions() *bind.CallOpts
@@ -128,13 +186,19 @@ type Exchange interface {
 	SetOperator(a common.Address, isOperator bool, txOpts *bind.TransactOpts) (*eth.Transaction, error)
 	CallTrade(m *types.Matches, call *ethereum.CallMsg) (uint64, error)
 	CallBatchTrades(m *types.Matches, txOpts *ethereum.CallMsg) (uint64, error)
+	CallTradeBatch(b *types.PendingTradeBatch, call *ethereum.CallMsg) (uint64, error)
+	SimulateTradeBatch(b *types.PendingTradeBatch, call *ethereum.CallMsg) (bool, error)
 	FeeAccount() (common.Address, error)
 	Operator(a common.Address) (bool, error)
 	Trade(m *types.Matches, txOpts *bind.TransactOpts) (*eth.Transaction, error)
 	ExecuteBatchTrades(m *types.Matches, txOpts *bind.TransactOpts) (*eth.Transaction, error)
+	ExecuteTradeBatch(b *types.PendingTradeBatch, txOpts *bind.TransactOpts) (*eth.Transaction, error)
 	ListenToErrors() (chan *contractsinterfaces.ExchangeLogError, error)
 	ListenToTrades() (chan *contractsinterfaces.ExchangeLogTrade, error)
 	ListenToBatchTrades() (chan *contractsinterfaces.ExchangeLogBatchTrades, error)
+	FilterTrades(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogTrade, error)
+	FilterBatchTrades(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogBatchTrades, error)
+	FilterErrors(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogError, error)
 	GetErrorEvents(logs chan *contractsinterfaces.ExchangeLogError) error
 	GetTrades(logs chan *contractsinterfaces.ExchangeLogTrade) error
 	PrintTrades() error
@@ -143,6 +207,7 @@ type Exchange interface {
 
 type Engine interface {
 	HandleOrders(msg *rabbitmq.Message) error
+	OrderBookCodes() []string
 	// RecoverOrders(matches types.Matches) error
 	// CancelOrder(order *types.Order) (*types.EngineResponse, error)
 	// DeleteOrder(o *types.Order) error
@@ -161,6 +226,8 @@ type WalletService interface {
 	GetOperatorAddresses() ([]common.Address, error)
 	GetAll() ([]types.Wallet, error)
 	GetByAddress(addr common.Address) (*types.Wallet, error)
+	UnlockWallets(passphrase string) error
+	MigratePrivateKeys(passphrase string) error
 }
 
 type OHLCVService interface {
@@ -180,11 +247,20 @@ type OrderService interface {
 	GetByID(id bson.ObjectId) (*types.Order, error)
 	GetByHash(h common.Hash) (*types.Order, error)
 	GetByHashes(hashes []common.Hash) ([]*types.Order, error)
+	GetByClientOrderID(a common.Address, id string) (*types.Order, error)
 	GetByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
 	GetCurrentByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
 	GetHistoryByUserAddress(a common.Address, limit ...int) ([]*types.Order, error)
+	GetPage(q *types.HistoryQuery) (*types.OrderPage, error)
+	GetHistoryPage(q *types.HistoryQuery) (*types.OrderPage, error)
 	NewOrder(o *types.Order) error
 	CancelOrder(oc *types.OrderCancel) error
+	ForceCancelOrder(h common.Hash) (*types.Order, error)
+	BlockAccount(a common.Address) ([]*types.Order, error)
+	UnblockAccount(a common.Address)
+	ReloadDenyList() ([]*types.Order, error)
+	NewOrderSync(o *types.Order, timeout time.Duration) (*types.EngineResponse, error)
+	CancelOrderSync(oc *types.OrderCancel, timeout time.Duration) (*types.EngineResponse, error)
 	HandleEngineResponse(res *types.EngineResponse) error
 }
 
@@ -230,11 +306,13 @@ type TradeService interface {
 	GetAllTradesByPairAddress(bt, qt common.Address) ([]*types.Trade, error)
 	GetSortedTrades(bt, qt common.Address, n int) ([]*types.Trade, error)
 	GetSortedTradesByUserAddress(a common.Address, limit ...int) ([]*types.Trade, error)
+	GetPage(q *types.HistoryQuery) (*types.TradePage, error)
 	GetByUserAddress(a common.Address) ([]*types.Trade, error)
 	GetByHash(h common.Hash) (*types.Trade, error)
 	GetByOrderHashes(h []common.Hash) ([]*types.Trade, error)
 	GetByMakerOrderHash(h common.Hash) ([]*types.Trade, error)
 	GetByTakerOrderHash(h common.Hash) ([]*types.Trade, error)
+	GetUnsentTrades() ([]*types.Trade, error)
 	UpdateTradeTxHash(tr *types.Trade, txh common.Hash) error
 	UpdateSuccessfulTrade(t *types.Trade) (*types.Trade, error)
 	UpdatePendingTrade(t *types.Trade, txh common.Hash) (*types.Trade, error)
@@ -248,7 +326,7 @@ type TxService interface {
 	GetTxSendOptions() (*bind.TransactOpts, error)
 	GetTxDefaultSendOptions() (*bind.TransactOpts, error)
 	SetTxSender(w *types.Wallet)
-	GetCustomTxSendOptions(w *types.Wallet) *bind.TransactOpts
+	GetCustomTxSendOptions(w *types.Wallet) (*bind.TransactOpts, error)
 }
 
 type AccountService interface {
@@ -264,6 +342,70 @@ type AccountService interface {
 type ValidatorService interface {
 	ValidateBalance(o *types.Order) error
 	ValidateAvailableBalance(o *types.Order) error
+	GetBalanceAndAllowance(owner, token, exchange common.Address) (*big.Int, *big.Int, error)
+}
+
+type InvalidationService interface {
+	NotifyBalanceChange(owner, token common.Address)
+	CheckOrders(owner, token common.Address) error
+	SweepOrders() error
+}
+
+type ReconciliationService interface {
+	Reconcile(start, end uint64) (*types.ReconciliationReport, error)
+}
+
+type ExportService interface {
+	ExportOrders(a common.Address, fn func(e *types.OrderExport) error) error
+	ExportTrades(a common.Address, fn func(e *types.TradeExport) error) error
+}
+
+type FeeService interface {
+	GetAccountFees(a common.Address, p *types.Pair) (*types.AccountFees, error)
+	GetAccountFeesByPair(a, bt, qt common.Address) (*types.AccountFees, error)
+	GetFeeTiers(qt common.Address) ([]*types.FeeTier, error)
+	CreateFeeTier(t *types.FeeTier) error
+	DeleteFeeTier(qt common.Address, name string) error
+	SetFeeOverride(o *types.FeeOverride) error
+	DeleteFeeOverride(a, qt common.Address) error
+}
+
+type APIKeyService interface {
+	CreateChallenge(a common.Address, scopes []string) (*types.APIKeyChallenge, error)
+	CreateAPIKey(a common.Address, nonce common.Hash, sig *types.Signature, label string) (*types.APIKey, error)
+	GetAPIKeys(a common.Address) ([]*types.APIKey, error)
+	DeleteAPIKey(a common.Address, key string) error
+	Authenticate(key, timestamp, signature, method, uri string, body []byte) (*types.APIKey, error)
+}
+
+type AdminService interface {
+	SetAccountBlocked(actor, a common.Address, blocked bool) (*types.Account, error)
+	SetPairStatus(actor, bt, qt common.Address, listed, active *bool) (*types.Pair, error)
+	SetTokenStatus(actor, a common.Address, listed, active *bool) (*types.Token, error)
+	CancelOrder(actor common.Address, h common.Hash) (*types.Order, error)
+	PurgeQueues(actor common.Address) error
+	CreateFeeTier(actor common.Address, t *types.FeeTier) error
+	DeleteFeeTier(actor, qt common.Address, name string) error
+	SetFeeOverride(actor common.Address, o *types.FeeOverride) error
+	DeleteFeeOverride(actor, a, qt common.Address) error
+	GetEngineState() (*types.EngineState, error)
+	ReloadDenyList(actor common.Address) ([]common.Address, error)
+	GetDenyList() []common.Address
+	GetAuditLogs(target string, limit int) ([]*types.AuditLog, error)
+}
+
+type ComplianceService interface {
+	LoadBlockedAccounts() error
+	LoadDenyList() ([]common.Address, error)
+	ReadDenyList() ([]common.Address, error)
+	SetOrdersCancelled(a common.Address)
+	IsBlocked(a common.Address) bool
+	SetBlocked(a common.Address, blocked bool)
+	GetDenyList() []common.Address
+}
+
+type StatementService interface {
+	GetStatement(a common.Address, period string, from, to time.Time) (*types.AccountStatement, error)
 }
 
 type PriceService interface {
@@ -271,6 +413,17 @@ type PriceService interface {
 	GetMultipleMarketPrices(baseCurrencies []string, quoteCurrencies []string) (map[string]map[string]float64, error)
 }
 
+type Operator interface {
+	GetWalletStatuses() []*types.OperatorWalletStatus
+	PendingMatchesCount() int
+	PurgeQueues() error
+}
+
+type Signer interface {
+	Address() common.Address
+	SignTx(tx *eth.Transaction) (*eth.Transaction, error)
+}
+
 type EthereumConfig interface {
 	GetURL() string
 	ExchangeAddress() common.Address
@@ -286,6 +439,7 @@ type EthereumClient interface {
 	SendTransaction(ctx context.Context, tx *eth.Transaction) error
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 	BalanceAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*eth.Header, error)
 	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]eth.Log, error)
 	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- eth.Log) (ethereum.Subscription, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
@@ -295,9 +449,16 @@ type EthereumProvider interface {
 	WaitMined(h common.Hash) (*eth.Receipt, error)
 	GetBalanceAt(a common.Address) (*big.Int, error)
 	GetPendingNonceAt(a common.Address) (uint64, error)
+	BlockNumber() (uint64, error)
+	GetTransactionReceipt(h common.Hash) (*eth.Receipt, error)
+	GetBlockTime(n uint64) (time.Time, error)
 	BalanceOf(owner common.Address, token common.Address) (*big.Int, error)
 	Allowance(owner, spender, token common.Address) (*big.Int, error)
 	ExchangeAllowance(owner, token common.Address) (*big.Int, error)
 	Decimals(token common.Address) (uint8, error)
 	Symbol(token common.Address) (string, error)
+	Name(token common.Address) (string, error)
+	TotalSupply(token common.Address) (*big.Int, error)
+	CallTransfer(token, from, to common.Address, amount *big.Int) ([]byte, error)
+	RecentTransfers(token common.Address, blocks int64) ([]eth.Log, error)
 }
@@ -1,7 +1,16 @@
 package main
 
-import "github.com/Proofsuite/amp-matching-engine/server"
+import (
+	"os"
+
+	"github.com/Proofsuite/amp-matching-engine/server"
+)
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		server.Reconcile(os.Args[2:])
+		return
+	}
+
 	server.Start()
 }
@@ -0,0 +1,171 @@
+package operator
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// tradeGas is the gas each trade of a batch is estimated to cost in the tests
+const tradeGas = 100
+
+func newTestMatches(exchange common.Address, n int, seed int64) *types.Matches {
+	taker := &types.Order{ExchangeAddress: exchange, Hash: common.BigToHash(big.NewInt(seed))}
+	m := types.NewMatches([]*types.Order{}, taker, []*types.Trade{})
+
+	for i := 0; i < n; i++ {
+		maker := &types.Order{ExchangeAddress: exchange, Hash: common.BigToHash(big.NewInt(seed + int64(i) + 1))}
+		trade := &types.Trade{
+			Hash:           common.BigToHash(big.NewInt(seed + int64(i) + 100)),
+			MakerOrderHash: maker.Hash,
+			TakerOrderHash: taker.Hash,
+		}
+
+		m.AppendMatch(maker, trade)
+	}
+
+	return m
+}
+
+func newTestExchange(address common.Address) *mocks.Exchange {
+	ex := new(mocks.Exchange)
+	ex.On("GetAddress").Return(address)
+	ex.On("CallTradeBatch", mock.Anything, mock.Anything).Return(
+		func(b *types.PendingTradeBatch, call *ethereum.CallMsg) uint64 {
+			return uint64(b.Length() * tradeGas)
+		},
+		nil,
+	)
+
+	return ex
+}
+
+func newTestTxQueue(exchanges ...interfaces.Exchange) *TxQueue {
+	index := make(map[common.Address]interfaces.Exchange)
+	for _, ex := range exchanges {
+		index[ex.GetAddress()] = ex
+	}
+
+	return &TxQueue{
+		Name:      "queue",
+		Wallet:    testutils.GetTestWallet1(),
+		Exchange:  exchanges[0],
+		Exchanges: index,
+		health:    newWalletHealth(),
+	}
+}
+
+func TestBuildBatchSplitsLargeMatches(t *testing.T) {
+	app.Config.MaxBatchGas = 2*tradeGas + tradeGas/2
+
+	exchange := common.HexToAddress("0x1")
+	txq := newTestTxQueue(newTestExchange(exchange))
+
+	// the matches do not fit in one batch so they are split into single trades
+	m := newTestMatches(exchange, 3, 1000)
+	batch, remaining, invalid := txq.BuildBatch([]*types.Matches{m})
+
+	assert.Equal(t, 2, batch.Length())
+	assert.Equal(t, m.Trades[0].Hash, batch.Matches[0].Trades[0].Hash)
+	assert.Equal(t, m.Trades[1].Hash, batch.Matches[1].Trades[0].Hash)
+	assert.Equal(t, 1, len(remaining))
+	assert.Equal(t, m.Trades[2].Hash, remaining[0].Trades[0].Hash)
+	assert.Equal(t, 0, len(invalid))
+}
+
+func TestBuildBatchGreedy(t *testing.T) {
+	app.Config.MaxBatchGas = 3 * tradeGas
+
+	exchange := common.HexToAddress("0x1")
+	txq := newTestTxQueue(newTestExchange(exchange))
+
+	m1 := newTestMatches(exchange, 1, 1000)
+	m2 := newTestMatches(exchange, 2, 2000)
+	m3 := newTestMatches(exchange, 1, 3000)
+	batch, remaining, invalid := txq.BuildBatch([]*types.Matches{m1, m2, m3})
+
+	// the matches are added in order until the gas ceiling is reached
+	assert.Equal(t, []*types.Matches{m1, m2}, batch.Matches)
+	assert.Equal(t, []*types.Matches{m3}, remaining)
+	assert.Equal(t, 0, len(invalid))
+}
+
+func TestBuildBatchPerExchange(t *testing.T) {
+	app.Config.MaxBatchGas = 10 * tradeGas
+
+	exchange := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	txq := newTestTxQueue(newTestExchange(exchange), newTestExchange(other))
+
+	m1 := newTestMatches(exchange, 1, 1000)
+	m2 := newTestMatches(other, 1, 2000)
+	m3 := newTestMatches(exchange, 1, 3000)
+	batch, remaining, invalid := txq.BuildBatch([]*types.Matches{m1, m2, m3})
+
+	// a batch only holds the matches settled on the exchange contract of its first match
+	assert.Equal(t, []*types.Matches{m1, m3}, batch.Matches)
+	assert.Equal(t, exchange, batch.ExchangeAddress())
+	assert.Equal(t, []*types.Matches{m2}, remaining)
+	assert.Equal(t, 0, len(invalid))
+
+	batch, remaining, _ = txq.BuildBatch(remaining)
+	assert.Equal(t, []*types.Matches{m2}, batch.Matches)
+	assert.Equal(t, other, batch.ExchangeAddress())
+	assert.Equal(t, 0, len(remaining))
+}
+
+func TestRecoverPendingMatches(t *testing.T) {
+	tradeService := new(mocks.TradeService)
+	orderService := new(mocks.OrderService)
+
+	exchange := common.HexToAddress("0x1")
+	m := newTestMatches(exchange, 2, 1000)
+
+	tradeService.On("GetUnsentTrades").Return(m.Trades, nil)
+	orderService.On("GetByHash", m.TakerOrder.Hash).Return(m.TakerOrder, nil)
+	for _, mo := range m.MakerOrders {
+		orderService.On("GetByHash", mo.Hash).Return(mo, nil)
+	}
+
+	op := &Operator{
+		TradeService:    tradeService,
+		OrderService:    orderService,
+		pendingMatches:  []*types.Matches{},
+		recoveredTrades: make(map[common.Hash]bool),
+		mutex:           &sync.Mutex{},
+	}
+
+	err := op.RecoverPendingMatches()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 1, op.PendingMatchesCount())
+	assert.Equal(t, m.Trades, op.pendingMatches[0].Trades)
+	assert.Equal(t, m.MakerOrders, op.pendingMatches[0].MakerOrders)
+
+	// the same matches received again from the broker are not settled twice
+	err = op.QueueTrade(m)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 1, op.PendingMatchesCount())
+
+	err = op.QueueTrade(newTestMatches(exchange, 1, 2000))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 2, op.PendingMatchesCount())
+}
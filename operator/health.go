@@ -0,0 +1,237 @@
+package operator
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/math"
+)
+
+const (
+	// healthCheckInterval is the interval at which the operator wallets balances are checked
+	healthCheckInterval = 30 * time.Second
+	// failureWindow is the number of most recent settlement transactions the failure rate is computed on
+	failureWindow = 20
+	// minFailureSamples is the number of settlement transactions required before the failure rate is considered
+	minFailureSamples = 5
+	// maxFailureRate is the failure rate above which an operator wallet is removed from rotation
+	maxFailureRate = 0.5
+	// failureCooldown is the duration after which a wallet removed from rotation because of its failure
+	// rate is tried again, with its failure window cleared
+	failureCooldown = 10 * time.Minute
+)
+
+// walletHealth tracks the balance and the settlement transactions outcomes of an operator wallet
+type walletHealth struct {
+	mutex       *sync.Mutex
+	outcomes    []bool
+	sent        int
+	failed      int
+	pending     int
+	balance     *big.Int
+	lowBalance  bool
+	healthy     bool
+	message     string
+	lastCheckAt time.Time
+	// failingSince is the time at which the failure rate went above maxFailureRate
+	failingSince time.Time
+}
+
+func newWalletHealth() *walletHealth {
+	return &walletHealth{
+		mutex:    &sync.Mutex{},
+		outcomes: []bool{},
+		healthy:  true,
+	}
+}
+
+// failureRate returns the failure rate of the most recent settlement transactions
+func (h *walletHealth) failureRate() float64 {
+	if len(h.outcomes) == 0 {
+		return 0
+	}
+
+	failed := 0
+	for _, ok := range h.outcomes {
+		if !ok {
+			failed++
+		}
+	}
+
+	return float64(failed) / float64(len(h.outcomes))
+}
+
+// TxSent records a settlement transaction sent from the queue wallet
+func (txq *TxQueue) TxSent() {
+	txq.health.mutex.Lock()
+	defer txq.health.mutex.Unlock()
+
+	txq.health.sent++
+	txq.health.pending++
+}
+
+// TxDone records the outcome of a settlement transaction. Transactions that could not be sent are
+// recorded as failures as well, with pending set to false.
+func (txq *TxQueue) TxDone(success bool, pending bool) {
+	h := txq.health
+	h.mutex.Lock()
+
+	if pending && h.pending > 0 {
+		h.pending--
+	}
+
+	if !success {
+		h.failed++
+	}
+
+	h.outcomes = append(h.outcomes, success)
+	if len(h.outcomes) > failureWindow {
+		h.outcomes = h.outcomes[len(h.outcomes)-failureWindow:]
+	}
+
+	h.mutex.Unlock()
+
+	txq.updateHealth()
+}
+
+// CheckHealth fetches the queue wallet ETH balance and updates the wallet health. A wallet whose balance
+// can not be fetched is considered unhealthy. A wallet removed from rotation because of its failure rate
+// is put back in rotation once the failure cool-down has elapsed, and removed again if its next
+// transactions keep failing.
+func (txq *TxQueue) CheckHealth() {
+	balance, err := txq.EthereumProvider.GetBalanceAt(txq.Wallet.Address)
+
+	h := txq.health
+	h.mutex.Lock()
+	h.lastCheckAt = time.Now()
+	if err != nil {
+		logger.Error(err)
+		h.balance = nil
+	} else {
+		h.balance = balance
+	}
+
+	if !h.failingSince.IsZero() && time.Since(h.failingSince) >= failureCooldown {
+		logger.Infof("Operator wallet %v failure cool-down elapsed, clearing its failure window", txq.Wallet.Address.Hex())
+		h.outcomes = []bool{}
+		h.failingSince = time.Time{}
+	}
+	h.mutex.Unlock()
+
+	txq.updateHealth()
+}
+
+// updateHealth evaluates whether the wallet should stay in the settlement rotation
+func (txq *TxQueue) updateHealth() {
+	h := txq.health
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	wasHealthy := h.healthy
+	h.healthy = true
+	h.message = ""
+	h.lowBalance = false
+
+	if h.balance == nil && !h.lastCheckAt.IsZero() {
+		h.healthy = false
+		h.message = "Could not retrieve wallet balance"
+	}
+
+	if h.balance != nil {
+		if math.IsStrictlySmallerThan(h.balance, math.ToBigInt(app.Config.LowOperatorBalance)) {
+			h.lowBalance = true
+			h.message = fmt.Sprintf("Low balance: %v wei", h.balance)
+		}
+
+		if math.IsStrictlySmallerThan(h.balance, math.ToBigInt(app.Config.MinOperatorBalance)) {
+			h.healthy = false
+			h.message = fmt.Sprintf("Insufficient balance: %v wei", h.balance)
+		}
+	}
+
+	if len(h.outcomes) >= minFailureSamples && h.failureRate() > maxFailureRate {
+		h.healthy = false
+		h.message = fmt.Sprintf("High failure rate: %.2f", h.failureRate())
+
+		if h.failingSince.IsZero() {
+			h.failingSince = time.Now()
+		}
+	} else {
+		h.failingSince = time.Time{}
+	}
+
+	if h.lowBalance {
+		logger.Warningf("Operator wallet %v balance is low: %v wei", txq.Wallet.Address.Hex(), h.balance)
+	}
+
+	if wasHealthy && !h.healthy {
+		logger.Errorf("Operator wallet %v removed from rotation: %v", txq.Wallet.Address.Hex(), h.message)
+	}
+
+	if !wasHealthy && h.healthy {
+		logger.Infof("Operator wallet %v back in rotation", txq.Wallet.Address.Hex())
+	}
+}
+
+// Healthy returns true if the queue wallet can be used to settle trades
+func (txq *TxQueue) Healthy() bool {
+	txq.health.mutex.Lock()
+	defer txq.health.mutex.Unlock()
+
+	return txq.health.healthy
+}
+
+// Status returns the health status of the queue wallet
+func (txq *TxQueue) Status() *types.OperatorWalletStatus {
+	ln := txq.Length()
+
+	h := txq.health
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return &types.OperatorWalletStatus{
+		Address:     txq.Wallet.Address,
+		Queue:       txq.Name,
+		Balance:     h.balance,
+		QueueLength: ln,
+		PendingTxs:  h.pending,
+		SentTxs:     h.sent,
+		FailedTxs:   h.failed,
+		FailureRate: h.failureRate(),
+		LowBalance:  h.lowBalance,
+		Healthy:     h.healthy,
+		Message:     h.message,
+		LastCheckAt: h.lastCheckAt,
+	}
+}
+
+// HandleHealthChecks periodically checks the health of the operator wallets
+func (op *Operator) HandleHealthChecks() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		op.CheckWalletHealth()
+	}
+}
+
+// CheckWalletHealth checks the health of every operator wallet
+func (op *Operator) CheckWalletHealth() {
+	for _, txq := range op.TxQueues {
+		txq.CheckHealth()
+	}
+}
+
+// GetWalletStatuses returns the health status of every operator wallet
+func (op *Operator) GetWalletStatuses() []*types.OperatorWalletStatus {
+	statuses := []*types.OperatorWalletStatus{}
+	for _, txq := range op.TxQueues {
+		statuses = append(statuses, txq.Status())
+	}
+
+	return statuses
+}
@@ -0,0 +1,83 @@
+package operator
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletHealth(t *testing.T) {
+	app.Config.MinOperatorBalance = "100"
+	app.Config.LowOperatorBalance = "1000"
+
+	w := testutils.GetTestWallet1()
+	provider := new(mocks.EthereumProvider)
+	txq := &TxQueue{Name: "queue", Wallet: w, EthereumProvider: provider, health: newWalletHealth()}
+
+	provider.On("GetBalanceAt", w.Address).Return(big.NewInt(500), nil).Once()
+	txq.CheckHealth()
+	assert.True(t, txq.Healthy())
+	assert.True(t, txq.health.lowBalance)
+
+	provider.On("GetBalanceAt", w.Address).Return(big.NewInt(50), nil).Once()
+	txq.CheckHealth()
+	assert.False(t, txq.Healthy())
+
+	provider.On("GetBalanceAt", w.Address).Return(big.NewInt(5000), nil).Once()
+	txq.CheckHealth()
+	assert.True(t, txq.Healthy())
+	assert.False(t, txq.health.lowBalance)
+
+	for i := 0; i < minFailureSamples; i++ {
+		txq.TxSent()
+		txq.TxDone(false, true)
+	}
+
+	assert.False(t, txq.Healthy())
+	assert.Equal(t, 0, txq.health.pending)
+
+	for i := 0; i < failureWindow; i++ {
+		txq.TxSent()
+		txq.TxDone(true, true)
+	}
+
+	assert.True(t, txq.Healthy())
+	assert.Equal(t, minFailureSamples, txq.health.failed)
+}
+
+func TestWalletHealthFailureCooldown(t *testing.T) {
+	app.Config.MinOperatorBalance = "100"
+	app.Config.LowOperatorBalance = "1000"
+
+	w := testutils.GetTestWallet1()
+	provider := new(mocks.EthereumProvider)
+	txq := &TxQueue{Name: "queue", Wallet: w, EthereumProvider: provider, health: newWalletHealth()}
+	provider.On("GetBalanceAt", w.Address).Return(big.NewInt(5000), nil)
+
+	for i := 0; i < minFailureSamples; i++ {
+		txq.TxSent()
+		txq.TxDone(false, true)
+	}
+
+	// the wallet is not sent transactions anymore so its failure rate cannot improve by itself
+	txq.CheckHealth()
+	assert.False(t, txq.Healthy())
+
+	txq.health.failingSince = time.Now().Add(-failureCooldown)
+	txq.CheckHealth()
+	assert.True(t, txq.Healthy())
+	assert.Equal(t, 0, len(txq.health.outcomes))
+
+	// the wallet is removed again if the next transactions keep failing
+	for i := 0; i < minFailureSamples; i++ {
+		txq.TxSent()
+		txq.TxDone(false, true)
+	}
+
+	assert.False(t, txq.Healthy())
+}
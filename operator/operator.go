@@ -4,9 +4,13 @@ import (
 	"errors"
 	"strconv"
 	"sync"
+	"time"
 
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/contracts/contractsinterfaces"
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/rabbitmq"
+	"github.com/Proofsuite/amp-matching-engine/signer"
 	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/Proofsuite/amp-matching-engine/utils"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -16,6 +20,10 @@ import (
 
 var logger = utils.Logger
 
+// ErrPendingMatchesFull is returned when the matches can not be settled because too many
+// matches are waiting for a transaction queue
+var ErrPendingMatchesFull = errors.New("Too many matches waiting to be settled")
+
 // Operator manages the transaction queue that will eventually be
 // sent to the exchange contract. The Operator Wallet must be equal to the
 // account that initially deployed the exchange contract or an address with operator rights
@@ -27,21 +35,27 @@ type Operator struct {
 	OrderService      interfaces.OrderService
 	EthereumProvider  interfaces.EthereumProvider
 	Exchange          interfaces.Exchange
+	Exchanges         []interfaces.Exchange
 	TxQueues          []*TxQueue
 	QueueAddressIndex map[common.Address]*TxQueue
 	Broker            *rabbitmq.Connection
+	pendingMatches    []*types.Matches
+	recoveredTrades   map[common.Hash]bool
 	mutex             *sync.Mutex
 }
 
+// batchInterval is the interval at which pending matches are grouped into settlement batches
+const batchInterval = 500 * time.Millisecond
+
 type OperatorInterface interface {
-	QueueTrade(o *types.Order, t *types.Trade) error
+	QueueTrade(m *types.Matches) error
 	GetShortestQueue() (*TxQueue, int, error)
 	FeeAccount() (common.Address, error)
 	Operator(addr common.Address) (bool, error)
 }
 
-// NewOperator creates a new operator struct. It creates an exchange contract instance from the
-// provided address. The error and trade events are received in the ErrorChannel and TradeChannel.
+// NewOperator creates a new operator struct. Trades are settled on the provided exchange contract
+// instances, the first of which is the default exchange contract. The error and trade events are received in the ErrorChannel and TradeChannel.
 // Upon receiving errors and trades in their respective channels, event payloads are sent to the
 // associated order maker and taker sockets through the through the event channel on the Order and Trade struct.
 // In addition, an error event cancels the trade in the trading engine and makes the order available again.
@@ -50,9 +64,13 @@ func NewOperator(
 	tradeService interfaces.TradeService,
 	orderService interfaces.OrderService,
 	provider interfaces.EthereumProvider,
-	exchange interfaces.Exchange,
+	exchanges []interfaces.Exchange,
 	conn *rabbitmq.Connection,
 ) (*Operator, error) {
+	if len(exchanges) == 0 {
+		return nil, errors.New("No exchange contract")
+	}
+
 	txqueues := []*TxQueue{}
 	addressIndex := make(map[common.Address]*TxQueue)
 	wallets, err := walletService.GetOperatorWallets()
@@ -75,7 +93,7 @@ func NewOperator(
 			provider,
 			orderService,
 			w,
-			exchange,
+			exchanges,
 			conn,
 		)
 
@@ -91,13 +109,27 @@ func NewOperator(
 		TradeService:      tradeService,
 		OrderService:      orderService,
 		EthereumProvider:  provider,
-		Exchange:          exchange,
+		Exchange:          exchanges[0],
+		Exchanges:         exchanges,
 		TxQueues:          txqueues,
 		QueueAddressIndex: addressIndex,
+		Broker:            conn,
+		pendingMatches:    []*types.Matches{},
+		recoveredTrades:   make(map[common.Hash]bool),
 		mutex:             &sync.Mutex{},
 	}
 
+	err = op.RecoverPendingMatches()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	op.CheckWalletHealth()
+
 	go op.HandleEvents()
+	go op.HandleBatches()
+	go op.HandleHealthChecks()
 	return op, nil
 }
 
@@ -129,10 +161,19 @@ func (op *Operator) HandleTxSuccess(m *types.Matches, receipt *eth.Receipt) {
 // order hash in the ordertrade mapping. I suspect this is because the event listener catches events from previous
 // tests. It might be helpful to see how to listen to events from up to a certain block.
 func (op *Operator) HandleEvents() error {
-	errorEvents, err := op.Exchange.ListenToErrors()
-	if err != nil {
-		logger.Error(err)
-		return err
+	errorEvents := make(chan *contractsinterfaces.ExchangeLogError)
+	for _, ex := range op.Exchanges {
+		events, err := ex.ListenToErrors()
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		go func(events chan *contractsinterfaces.ExchangeLogError) {
+			for e := range events {
+				errorEvents <- e
+			}
+		}(events)
 	}
 
 	for {
@@ -180,51 +221,204 @@ func (op *Operator) HandleTrades(msg *types.OperatorMessage) error {
 	return nil
 }
 
-// QueueTrade
+// QueueTrade adds the matches to the pending matches. Pending matches are grouped into
+// settlement batches and published on the transaction queues by HandleBatches. When every
+// transaction queue is full, the pending matches are held back until a queue is available,
+// and new matches are rejected once too many matches are held back.
+// Matches whose trades were already recovered from the database are ignored.
 func (op *Operator) QueueTrade(m *types.Matches) error {
 	op.mutex.Lock()
 	defer op.mutex.Unlock()
 
-	txq, len, err := op.GetShortestQueue()
-	if err != nil {
-		logger.Error(err)
-		return err
+	if len(m.Trades) > 0 && op.recoveredTrades[m.Trades[0].Hash] {
+		for _, t := range m.Trades {
+			delete(op.recoveredTrades, t.Hash)
+		}
+
+		return nil
 	}
 
-	if len > 10 {
-		logger.Warning("Transaction queue is full")
-		return errors.New("Transaction queue is full")
+	if len(op.pendingMatches) >= app.Config.MaxPendingMatches {
+		return ErrPendingMatchesFull
 	}
 
-	logger.Infof("Queuing Trade on queue: %v (previous queue length = %v)", txq.Name, len)
+	op.pendingMatches = append(op.pendingMatches, m)
+	return nil
+}
 
-	err = txq.PublishPendingTrades(m)
+// RecoverPendingMatches rebuilds the pending matches from the trades stored as pending whose settlement
+// transaction was not sent yet, so that the matches held in memory or in the transaction queues when the
+// operator stopped are settled. The trades are stored before being published to the operator, which might
+// then receive the same matches again: these are ignored by QueueTrade.
+func (op *Operator) RecoverPendingMatches() error {
+	trades, err := op.TradeService.GetUnsentTrades()
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
 
+	matches := []*types.Matches{}
+	index := make(map[common.Hash]*types.Matches)
+	orders := make(map[common.Hash]*types.Order)
+
+	getOrder := func(h common.Hash) (*types.Order, error) {
+		if o, ok := orders[h]; ok {
+			return o, nil
+		}
+
+		o, err := op.OrderService.GetByHash(h)
+		if err != nil {
+			return nil, err
+		}
+
+		orders[h] = o
+		return o, nil
+	}
+
+	for _, t := range trades {
+		taker, err := getOrder(t.TakerOrderHash)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		maker, err := getOrder(t.MakerOrderHash)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if taker == nil || maker == nil {
+			logger.Warningf("Could not recover trade %v: order not found", t.Hash.Hex())
+			continue
+		}
+
+		m := index[t.TakerOrderHash]
+		if m == nil {
+			m = types.NewMatches([]*types.Order{}, taker, []*types.Trade{})
+			index[t.TakerOrderHash] = m
+			matches = append(matches, m)
+		}
+
+		m.MakerOrders = append(m.MakerOrders, maker)
+		m.Trades = append(m.Trades, t)
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	for _, m := range matches {
+		for _, t := range m.Trades {
+			op.recoveredTrades[t.Hash] = true
+		}
+	}
+
+	op.pendingMatches = append(matches, op.pendingMatches...)
+	logger.Infof("Recovered %v pending matches", len(matches))
 	return nil
 }
 
-// GetShortestQueue
+// PendingMatchesCount returns the number of matches waiting to be published on a transaction queue
+func (op *Operator) PendingMatchesCount() int {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	return len(op.pendingMatches)
+}
+
+// HandleBatches periodically groups the pending matches into settlement batches
+func (op *Operator) HandleBatches() {
+	ticker := time.NewTicker(batchInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		op.PublishBatches()
+	}
+}
+
+// PublishBatches builds at most one gas-bounded batch per transaction queue out of the
+// pending matches and publishes each batch on the shortest queue. The matches that did not
+// fit in a batch stay pending, and so does everything while the queues are full.
+func (op *Operator) PublishBatches() {
+	for i := 0; i < len(op.TxQueues); i++ {
+		op.mutex.Lock()
+		pending := op.pendingMatches
+		op.pendingMatches = []*types.Matches{}
+		op.mutex.Unlock()
+
+		if len(pending) == 0 {
+			return
+		}
+
+		txq, ln, err := op.GetShortestQueue()
+		if err != nil {
+			logger.Error(err)
+			op.holdBack(pending)
+			return
+		}
+
+		if ln >= app.Config.MaxQueueLength {
+			logger.Warningf("Transaction queues are full, holding back %v pending matches", len(pending))
+			op.holdBack(pending)
+			return
+		}
+
+		batch, remaining, invalid := txq.BuildBatch(pending)
+		for _, m := range invalid {
+			txq.HandleTradeInvalid(m)
+		}
+
+		op.holdBack(remaining)
+		if batch.Length() == 0 {
+			continue
+		}
+
+		logger.Infof("Queuing %v trades on queue: %v (previous queue length = %v)", batch.Length(), txq.Name, ln)
+
+		err = txq.PublishPendingTrades(batch)
+		if err != nil {
+			logger.Error(err)
+			for _, m := range batch.Matches {
+				op.HandleError(m)
+			}
+		}
+	}
+}
+
+// holdBack puts the matches back in front of the pending matches
+func (op *Operator) holdBack(matches []*types.Matches) {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	op.pendingMatches = append(append([]*types.Matches{}, matches...), op.pendingMatches...)
+}
+
+// GetShortestQueue returns the shortest transaction queue among the queues whose wallet is
+// healthy, along with its length. Unhealthy wallets are out of rotation.
 func (op *Operator) GetShortestQueue() (*TxQueue, int, error) {
-	shortest := &TxQueue{}
-	min := 1000
+	var shortest *TxQueue
+	min := 0
 
 	for _, txq := range op.TxQueues {
-		if shortest == nil {
-			shortest = txq
-			min = txq.Length()
+		if !txq.Healthy() {
+			continue
 		}
 
 		ln := txq.Length()
-		if ln < min {
+		if shortest == nil || ln < min {
 			shortest = txq
 			min = ln
 		}
 	}
 
+	if shortest == nil {
+		return nil, 0, errors.New("No healthy operator wallet available")
+	}
+
 	return shortest, min, nil
 }
 
@@ -269,5 +463,11 @@ func (op *Operator) GetTxSendOptions() (*bind.TransactOpts, error) {
 		return nil, err
 	}
 
-	return bind.NewKeyedTransactor(wallet.PrivateKey), nil
+	s, err := signer.GetSigner(wallet)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return signer.NewTransactor(s), nil
 }
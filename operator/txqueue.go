@@ -3,13 +3,18 @@ package operator
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"sync"
 
+	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/rabbitmq"
+	"github.com/Proofsuite/amp-matching-engine/signer"
 	"github.com/Proofsuite/amp-matching-engine/types"
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	eth "github.com/ethereum/go-ethereum/core/types"
 	"github.com/streadway/amqp"
 )
@@ -17,34 +22,59 @@ import (
 type TxQueue struct {
 	Name             string
 	Wallet           *types.Wallet
+	Signer           interfaces.Signer
 	TradeService     interfaces.TradeService
 	OrderService     interfaces.OrderService
 	EthereumProvider interfaces.EthereumProvider
 	Exchange         interfaces.Exchange
+	Exchanges        map[common.Address]interfaces.Exchange
 	Broker           *rabbitmq.Connection
+	health           *walletHealth
+	// sendMutex serializes the nonce retrieval and sending of the settlement transactions
+	sendMutex *sync.Mutex
 }
 
-// NewTxQueue
+// NewTxQueue returns a transaction queue sending the transactions of the given wallet. The queue
+// settles trades on the given exchange contracts, the first of which is the default exchange contract
 func NewTxQueue(
 	n string,
 	tr interfaces.TradeService,
 	p interfaces.EthereumProvider,
 	o interfaces.OrderService,
 	w *types.Wallet,
-	ex interfaces.Exchange,
+	exchanges []interfaces.Exchange,
 	rabbitConn *rabbitmq.Connection,
 ) (*TxQueue, error) {
+	if len(exchanges) == 0 {
+		return nil, errors.New("No exchange contract")
+	}
+
+	exchangeIndex := make(map[common.Address]interfaces.Exchange)
+	for _, ex := range exchanges {
+		exchangeIndex[ex.GetAddress()] = ex
+	}
+
+	s, err := signer.GetSigner(w)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
 	txq := &TxQueue{
 		Name:             n,
 		TradeService:     tr,
 		OrderService:     o,
 		EthereumProvider: p,
 		Wallet:           w,
-		Exchange:         ex,
+		Signer:           s,
+		Exchange:         exchanges[0],
+		Exchanges:        exchangeIndex,
 		Broker:           rabbitConn,
+		health:           newWalletHealth(),
+		sendMutex:        &sync.Mutex{},
 	}
 
-	err := txq.PurgePendingTrades()
+	err = txq.PurgePendingTrades()
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -71,8 +101,9 @@ func (txq *TxQueue) GetChannel() *amqp.Channel {
 	return txq.Broker.GetChannel(name)
 }
 
+// GetTxSendOptions returns transaction options signing through the queue signer
 func (txq *TxQueue) GetTxSendOptions() *bind.TransactOpts {
-	return bind.NewKeyedTransactor(txq.Wallet.PrivateKey)
+	return signer.NewTransactor(txq.Signer)
 }
 
 func (txq *TxQueue) GetTxCallOptions() *ethereum.CallMsg {
@@ -81,6 +112,16 @@ func (txq *TxQueue) GetTxCallOptions() *ethereum.CallMsg {
 	return &ethereum.CallMsg{From: txq.Wallet.Address, To: &address}
 }
 
+// GetExchange returns the instance of the exchange contract deployed at the given address
+func (txq *TxQueue) GetExchange(a common.Address) (interfaces.Exchange, error) {
+	ex := txq.Exchanges[a]
+	if ex == nil {
+		return nil, fmt.Errorf("Unknown exchange contract %v", a.Hex())
+	}
+
+	return ex, nil
+}
+
 // Length
 func (txq *TxQueue) Length() int {
 	name := "TX_QUEUES:" + txq.Name
@@ -93,84 +134,394 @@ func (txq *TxQueue) Length() int {
 	return q.Messages
 }
 
-// ExecuteTrade send a trade execution order to the smart contract interface. After sending the
-// trade message, the trade is updated on the database and is published to the operator subscribers
-// (order service)
-func (txq *TxQueue) ExecuteTrade(m *types.Matches, tag uint64) error {
-	logger.Infof("Executing trades")
+// EstimateBatchGas returns the gas required to settle the given batch with the queue wallet.
+// An error is returned if the settlement transaction would be reverted.
+func (txq *TxQueue) EstimateBatchGas(b *types.PendingTradeBatch) (uint64, error) {
+	ex, err := txq.GetExchange(b.ExchangeAddress())
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
 
 	callOpts := txq.GetTxCallOptions()
-	gasLimit, err := txq.Exchange.CallBatchTrades(m, callOpts)
+	address := ex.GetAddress()
+	callOpts.To = &address
+
+	gasLimit, err := ex.CallTradeBatch(b, callOpts)
 	if err != nil {
-		txq.HandleTradeInvalid(m)
 		logger.Error(err)
-		return err
+		return 0, err
 	}
 
-	//a low gas limit means that the transaction returned before being completed
-	//and is therefore not valid.
-	if gasLimit < 140000 {
-		txq.HandleTradeInvalid(m)
+	return gasLimit, nil
+}
+
+// SimulateBatch returns true if every trade of the given batch would be settled by the exchange contract
+func (txq *TxQueue) SimulateBatch(b *types.PendingTradeBatch) (bool, error) {
+	ex, err := txq.GetExchange(b.ExchangeAddress())
+	if err != nil {
 		logger.Error(err)
-		return errors.New("Invalid Trade")
+		return false, err
 	}
 
-	nonce, err := txq.EthereumProvider.GetPendingNonceAt(txq.Wallet.Address)
+	callOpts := txq.GetTxCallOptions()
+	address := ex.GetAddress()
+	callOpts.To = &address
+
+	success, err := ex.SimulateTradeBatch(b, callOpts)
 	if err != nil {
-		txq.HandleError(m)
 		logger.Error(err)
-		return err
+		return false, err
 	}
 
-	txOpts := txq.GetTxSendOptions()
-	txOpts.Nonce = big.NewInt(int64(nonce))
-	tx, err := txq.Exchange.ExecuteBatchTrades(m, txOpts)
+	return success, nil
+}
+
+// SimulateTrades simulates the settlement of the batch before it is sent. Each time the simulation
+// fails, the offending trade is found by bisecting the batch and the order at fault is invalidated
+// through the engine. The returned batch only contains trades that are expected to settle.
+func (txq *TxQueue) SimulateTrades(b *types.PendingTradeBatch) (*types.PendingTradeBatch, error) {
+	for len(b.Matches) > 0 {
+		success, err := txq.SimulateBatch(b)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if success {
+			return b, nil
+		}
+
+		m, err := txq.FindInvalidTrade(b)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		// every trade settles on its own but not together with the others. The first trade
+		// is settled alone and the other trades are sent back to the queue.
+		if m == nil {
+			trades := b.SingleTrades()
+			err := txq.PublishPendingTrades(types.NewPendingTradeBatch(trades[1:]...))
+			if err != nil {
+				logger.Error(err)
+				return nil, err
+			}
+
+			b = types.NewPendingTradeBatch(trades[0])
+			continue
+		}
+
+		b = txq.InvalidateOrder(b, m)
+	}
+
+	return b, nil
+}
+
+// FindInvalidTrade bisects the batch until it finds a trade that can not be settled. It returns nil
+// if the trades of the batch only fail when they are settled together.
+func (txq *TxQueue) FindInvalidTrade(b *types.PendingTradeBatch) (*types.Matches, error) {
+	trades := b.SingleTrades()
+
+	for len(trades) > 1 {
+		half := len(trades) / 2
+
+		success, err := txq.SimulateBatch(types.NewPendingTradeBatch(trades[:half]...))
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if success {
+			trades = trades[half:]
+		} else {
+			trades = trades[:half]
+		}
+	}
+
+	success, err := txq.SimulateBatch(types.NewPendingTradeBatch(trades[0]))
 	if err != nil {
-		txq.HandleError(m)
 		logger.Error(err)
-		return err
+		return nil, err
 	}
 
-	updatedTrades := []*types.Trade{}
-	for _, t := range m.Trades {
-		updated, err := txq.TradeService.UpdatePendingTrade(t, tx.Hash())
+	if success {
+		return nil, nil
+	}
+
+	return trades[0], nil
+}
+
+// InvalidateOrder invalidates the order at fault in the given invalid trade through the engine
+// and returns the batch without the trades of this order.
+// The maker order is at fault if its signature is invalid or if the other trades of the taker
+// order settle. The taker order is at fault if its signature is invalid or if the other trades
+// of the maker order settle. Otherwise the maker order is invalidated since the taker order has
+// been validated when it was matched.
+func (txq *TxQueue) InvalidateOrder(b *types.PendingTradeBatch, m *types.Matches) *types.PendingTradeBatch {
+	mo := m.MakerOrders[0]
+	to := m.TakerOrder
+
+	withoutMaker, makerMatches := b.SplitByMakerOrder(mo.Hash)
+	withoutTaker, takerMatches := b.SplitByTakerOrder(to.Hash)
+
+	makerAtFault := true
+	if valid, _ := mo.VerifySignature(); valid {
+		if valid, _ := to.VerifySignature(); !valid {
+			makerAtFault = false
+		} else if !txq.settles(otherTrades(takerMatches, mo.Hash)) && txq.settles(otherTrades(makerMatches, to.Hash)) {
+			makerAtFault = false
+		}
+	}
+
+	if makerAtFault {
+		logger.Errorf("Invalidating maker order: %v", mo.Hash.Hex())
+		for _, matches := range makerMatches {
+			err := txq.Broker.PublishInvalidateMakerOrdersMessage(*matches)
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+
+		return withoutMaker
+	}
+
+	logger.Errorf("Invalidating taker order: %v", to.Hash.Hex())
+	for _, matches := range takerMatches {
+		err := txq.Broker.PublishInvalidateTakerOrdersMessage(*matches)
 		if err != nil {
 			logger.Error(err)
 		}
+	}
 
-		updatedTrades = append(updatedTrades, updated)
+	return withoutTaker
+}
+
+// settles returns true if the given trades exist and would be settled
+func (txq *TxQueue) settles(matches []*types.Matches) bool {
+	if len(matches) == 0 {
+		return false
 	}
 
-	m.Trades = updatedTrades
-	err = txq.Broker.PublishTradeSentMessage(m)
+	success, err := txq.SimulateBatch(types.NewPendingTradeBatch(matches...))
 	if err != nil {
 		logger.Error(err)
-		return errors.New("Could not update")
+		return false
 	}
 
-	receipt, err := txq.EthereumProvider.WaitMined(tx.Hash())
+	return success
+}
+
+// otherTrades returns the given matches without the trades of the given counterpart order
+func otherTrades(matches []*types.Matches, counterpart common.Hash) []*types.Matches {
+	others := []*types.Matches{}
+	for _, m := range matches {
+		for i := 0; i < m.Length(); i++ {
+			nth := m.NthMatch(i)
+			if nth.MakerOrders[0].Hash != counterpart && nth.TakerOrder.Hash != counterpart {
+				others = append(others, nth)
+			}
+		}
+	}
+
+	return others
+}
+
+// BuildBatch greedily adds the pending matches to a batch as long as the estimated gas of the batch
+// stays under the configured gas ceiling. The batch only holds matches settled on the exchange contract
+// of the first match. Matches that can not be settled are split per maker order
+// so that only the invalid trades are returned as invalid. Matches that do not fit in the batch are
+// returned as remaining, in their original order.
+func (txq *TxQueue) BuildBatch(pending []*types.Matches) (*types.PendingTradeBatch, []*types.Matches, []*types.Matches) {
+	batch := types.NewPendingTradeBatch()
+	remaining := []*types.Matches{}
+	invalid := []*types.Matches{}
+
+	queue := append([]*types.Matches{}, pending...)
+	for i := 0; i < len(queue); i++ {
+		m := queue[i]
+
+		// a batch is settled on a single exchange contract
+		if len(batch.Matches) > 0 && m.TakerOrder.ExchangeAddress != batch.ExchangeAddress() {
+			remaining = append(remaining, m)
+			continue
+		}
+
+		gas, err := txq.EstimateBatchGas(batch.With(m))
+		if err == nil && gas <= app.Config.MaxBatchGas {
+			batch = batch.With(m)
+			continue
+		}
+
+		if err == nil && len(batch.Matches) > 0 {
+			remaining = append(remaining, queue[i:]...)
+			break
+		}
+
+		if err != nil && len(batch.Matches) > 0 {
+			_, err = txq.EstimateBatchGas(types.NewPendingTradeBatch(m))
+			if err == nil {
+				remaining = append(remaining, m)
+				continue
+			}
+		}
+
+		// the matches are either invalid or too large to be settled in one transaction
+		if m.Length() > 1 {
+			split := []*types.Matches{}
+			for j := 0; j < m.Length(); j++ {
+				split = append(split, m.NthMatch(j))
+			}
+
+			queue = append(queue[:i+1:i+1], append(split, queue[i+1:]...)...)
+			continue
+		}
+
+		if err != nil {
+			invalid = append(invalid, m)
+			continue
+		}
+
+		logger.Warningf("Trade exceeds the batch gas ceiling (%v gas): %v", gas, m)
+		batch = batch.With(m)
+	}
+
+	return batch, remaining, invalid
+}
+
+// ExecuteTrade send a trade batch execution order to the smart contract interface. After sending the
+// trade message, the trades are updated on the database and are published to the operator subscribers
+// (order service). The settlement is simulated beforehand: the orders at fault are invalidated and
+// the rest of the batch is still settled.
+func (txq *TxQueue) ExecuteTrade(b *types.PendingTradeBatch, tag uint64) error {
+	logger.Infof("Executing trades: %v", b)
+
+	_, err := txq.EstimateBatchGas(b)
+	if err != nil {
+		batch, remaining, invalid := txq.BuildBatch(b.Matches)
+		for _, m := range invalid {
+			txq.HandleTradeInvalid(m)
+		}
+
+		if len(remaining) > 0 {
+			err := txq.PublishPendingTrades(types.NewPendingTradeBatch(remaining...))
+			if err != nil {
+				logger.Error(err)
+				for _, m := range remaining {
+					txq.HandleError(m)
+				}
+			}
+		}
+
+		if len(batch.Matches) == 0 {
+			return errors.New("Invalid Trade")
+		}
+
+		b = batch
+	}
+
+	simulated, err := txq.SimulateTrades(b)
 	if err != nil {
 		logger.Error(err)
+		for _, m := range b.Matches {
+			txq.HandleError(m)
+		}
+
 		return err
 	}
 
-	if receipt.Status == 0 {
+	b = simulated
+
+	if len(b.Matches) == 0 {
+		return errors.New("Invalid Trade")
+	}
+
+	ex, err := txq.GetExchange(b.ExchangeAddress())
+	if err != nil {
+		logger.Error(err)
+		for _, m := range b.Matches {
+			txq.HandleError(m)
+		}
 
-		err := txq.HandleTxError(m)
+		return err
+	}
+
+	txq.sendMutex.Lock()
+	nonce, err := txq.EthereumProvider.GetPendingNonceAt(txq.Wallet.Address)
+	if err != nil {
+		txq.sendMutex.Unlock()
+		logger.Error(err)
+		for _, m := range b.Matches {
+			txq.HandleError(m)
+		}
+
+		return err
+	}
+
+	txOpts := txq.GetTxSendOptions()
+	txOpts.Nonce = big.NewInt(int64(nonce))
+	tx, err := ex.ExecuteTradeBatch(b, txOpts)
+	txq.sendMutex.Unlock()
+	if err != nil {
+		logger.Error(err)
+		txq.TxDone(false, false)
+		for _, m := range b.Matches {
+			txq.HandleError(m)
+		}
+
+		return err
+	}
+
+	txq.TxSent()
+
+	for _, m := range b.Matches {
+		updatedTrades := []*types.Trade{}
+		for _, t := range m.Trades {
+			updated, err := txq.TradeService.UpdatePendingTrade(t, tx.Hash())
+			if err != nil {
+				logger.Error(err)
+			}
+
+			updatedTrades = append(updatedTrades, updated)
+		}
+
+		m.Trades = updatedTrades
+		err = txq.Broker.PublishTradeSentMessage(m)
 		if err != nil {
 			logger.Error(err)
-			return err
+			return errors.New("Could not update")
 		}
-
-		return errors.New("Transaction Error")
 	}
 
-	err = txq.HandleTxSuccess(m, receipt)
+	receipt, err := txq.EthereumProvider.WaitMined(tx.Hash())
 	if err != nil {
 		logger.Error(err)
+		txq.TxDone(false, true)
 		return err
 	}
 
+	txq.TxDone(receipt.Status != 0, true)
+
+	if receipt.Status == 0 {
+		for _, m := range b.Matches {
+			err := txq.HandleTxError(m)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+		}
+
+		return errors.New("Transaction Error")
+	}
+
+	for _, m := range b.Matches {
+		err = txq.HandleTxSuccess(m, receipt)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -221,17 +572,17 @@ func (txq *TxQueue) HandleError(m *types.Matches) error {
 	return nil
 }
 
-func (txq *TxQueue) PublishPendingTrades(m *types.Matches) error {
+func (txq *TxQueue) PublishPendingTrades(b *types.PendingTradeBatch) error {
 	name := "TX_QUEUES:" + txq.Name
 	ch := txq.Broker.GetChannel(name)
 	q := txq.Broker.GetQueue(ch, name)
 
-	b, err := json.Marshal(m)
+	bytes, err := json.Marshal(b)
 	if err != nil {
 		return errors.New("Failed to marshal trade object")
 	}
 
-	err = txq.Broker.Publish(ch, q, b)
+	err = txq.Broker.Publish(ch, q, bytes)
 	if err != nil {
 		logger.Error(err)
 		return err
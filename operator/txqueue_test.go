@@ -0,0 +1,217 @@
+package operator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/rabbitmq"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var simulationExchange = common.HexToAddress("0x10")
+
+// newSignedTestOrder returns an order signed by the given wallet. The orders at fault are found
+// by checking the signatures, the orders of the tests therefore carry a valid signature.
+func newSignedTestOrder(t *testing.T, w *types.Wallet, side string, nonce int64) *types.Order {
+	o := &types.Order{
+		ExchangeAddress: simulationExchange,
+		UserAddress:     w.Address,
+		BaseToken:       testutils.GetTestAddress1(),
+		QuoteToken:      testutils.GetTestAddress2(),
+		Amount:          big.NewInt(1e18),
+		PricePoint:      big.NewInt(1e8),
+		Side:            side,
+		Nonce:           big.NewInt(nonce),
+		MakeFee:         big.NewInt(0),
+		TakeFee:         big.NewInt(0),
+	}
+
+	err := w.SignOrder(o)
+	if err != nil {
+		t.Fatalf("Could not sign order: %v", err)
+	}
+
+	return o
+}
+
+// newTestTrade returns the match of the given maker and taker orders
+func newTestTrade(maker, taker *types.Order) *types.Matches {
+	trade := &types.Trade{
+		Hash:           common.BytesToHash(append(maker.Hash.Bytes()[:16], taker.Hash.Bytes()[:16]...)),
+		MakerOrderHash: maker.Hash,
+		TakerOrderHash: taker.Hash,
+	}
+
+	return types.NewMatches([]*types.Order{maker}, taker, []*types.Trade{trade})
+}
+
+// newSimulationExchange returns an exchange contract whose simulation fails for the batches that
+// contain a trade of one of the given orders
+func newSimulationExchange(invalid ...common.Hash) *mocks.Exchange {
+	ex := newTestExchange(simulationExchange)
+	ex.On("SimulateTradeBatch", mock.Anything, mock.Anything).Return(
+		func(b *types.PendingTradeBatch, call *ethereum.CallMsg) bool {
+			for _, trade := range b.Trades() {
+				for _, h := range invalid {
+					if trade.MakerOrderHash == h || trade.TakerOrderHash == h {
+						return false
+					}
+				}
+			}
+
+			return true
+		},
+		nil,
+	)
+
+	return ex
+}
+
+// newSimulationBatch returns a batch of n trades, each between a different maker and taker order
+func newSimulationBatch(t *testing.T, n int) (*types.PendingTradeBatch, []*types.Order) {
+	maker := testutils.GetTestWallet2()
+	taker := testutils.GetTestWallet3()
+
+	b := types.NewPendingTradeBatch()
+	makers := []*types.Order{}
+	for i := 0; i < n; i++ {
+		mo := newSignedTestOrder(t, maker, "SELL", int64(2*i))
+		to := newSignedTestOrder(t, taker, "BUY", int64(2*i+1))
+
+		b = b.With(newTestTrade(mo, to))
+		makers = append(makers, mo)
+	}
+
+	return b, makers
+}
+
+func newSimulationTxQueue(ex *mocks.Exchange) *TxQueue {
+	err := app.LoadConfig("../config", "")
+	if err != nil {
+		panic(err)
+	}
+
+	txq := newTestTxQueue(ex)
+	txq.Broker = rabbitmq.InitConnection(app.Config.RabbitMQURL)
+	return txq
+}
+
+func TestFindInvalidTrade(t *testing.T) {
+	n := 7
+
+	for _, index := range []int{0, n / 2, n - 1} {
+		b, makers := newSimulationBatch(t, n)
+		txq := newTestTxQueue(newSimulationExchange(makers[index].Hash))
+
+		m, err := txq.FindInvalidTrade(b)
+		if err != nil {
+			t.Fatalf("Could not find the invalid trade: %v", err)
+		}
+
+		if assert.NotNil(t, m, "trade %v", index) {
+			assert.Equal(t, makers[index].Hash, m.MakerOrders[0].Hash, "trade %v", index)
+		}
+	}
+}
+
+func TestFindInvalidTradeValidBatch(t *testing.T) {
+	b, _ := newSimulationBatch(t, 5)
+	txq := newTestTxQueue(newSimulationExchange())
+
+	m, err := txq.FindInvalidTrade(b)
+	if err != nil {
+		t.Fatalf("Could not simulate the trades: %v", err)
+	}
+
+	assert.Nil(t, m)
+}
+
+func TestSimulateTrades(t *testing.T) {
+	n := 5
+
+	for _, index := range []int{0, n / 2, n - 1} {
+		b, makers := newSimulationBatch(t, n)
+		ex := newSimulationExchange(makers[index].Hash)
+		txq := newSimulationTxQueue(ex)
+
+		settled, err := txq.SimulateTrades(b)
+		if err != nil {
+			t.Fatalf("Could not simulate the trades: %v", err)
+		}
+
+		// only the trade of the invalid maker order is left out of the batch
+		assert.Equal(t, n-1, settled.Length(), "trade %v", index)
+		for _, trade := range settled.Trades() {
+			assert.NotEqual(t, makers[index].Hash, trade.MakerOrderHash, "trade %v", index)
+		}
+	}
+}
+
+func TestSimulateTradesValidBatch(t *testing.T) {
+	b, _ := newSimulationBatch(t, 5)
+	ex := newSimulationExchange()
+	txq := newTestTxQueue(ex)
+
+	settled, err := txq.SimulateTrades(b)
+	if err != nil {
+		t.Fatalf("Could not simulate the trades: %v", err)
+	}
+
+	// a batch that settles is simulated once and is not bisected
+	assert.Equal(t, b, settled)
+	ex.AssertNumberOfCalls(t, "SimulateTradeBatch", 1)
+}
+
+func TestInvalidateOrder(t *testing.T) {
+	maker := testutils.GetTestWallet2()
+	taker := testutils.GetTestWallet3()
+
+	mo1 := newSignedTestOrder(t, maker, "SELL", 1)
+	mo2 := newSignedTestOrder(t, maker, "SELL", 2)
+	to1 := newSignedTestOrder(t, taker, "BUY", 3)
+	to2 := newSignedTestOrder(t, taker, "BUY", 4)
+
+	// the maker order is at fault when the other trades of the taker order settle
+	b := types.NewPendingTradeBatch(newTestTrade(mo1, to1), newTestTrade(mo2, to1))
+	txq := newSimulationTxQueue(newSimulationExchange(mo1.Hash))
+
+	m, err := txq.FindInvalidTrade(b)
+	if err != nil {
+		t.Fatalf("Could not find the invalid trade: %v", err)
+	}
+
+	valid := txq.InvalidateOrder(b, m)
+	assert.Equal(t, 1, valid.Length())
+	assert.Equal(t, mo2.Hash, valid.Trades()[0].MakerOrderHash)
+
+	// the taker order is at fault when the other trades of the maker order settle
+	b = types.NewPendingTradeBatch(newTestTrade(mo1, to1), newTestTrade(mo1, to2))
+	txq = newSimulationTxQueue(newSimulationExchange(to1.Hash))
+
+	m, err = txq.FindInvalidTrade(b)
+	if err != nil {
+		t.Fatalf("Could not find the invalid trade: %v", err)
+	}
+
+	valid = txq.InvalidateOrder(b, m)
+	assert.Equal(t, 1, valid.Length())
+	assert.Equal(t, to2.Hash, valid.Trades()[0].TakerOrderHash)
+
+	// the maker order is at fault when its signature is invalid
+	unsigned := newSignedTestOrder(t, maker, "SELL", 5)
+	unsigned.Signature = nil
+
+	b = types.NewPendingTradeBatch(newTestTrade(unsigned, to2), newTestTrade(mo2, to2))
+	txq = newSimulationTxQueue(newSimulationExchange(unsigned.Hash))
+
+	valid = txq.InvalidateOrder(b, b.SingleTrades()[0])
+	assert.Equal(t, 1, valid.Length())
+	assert.Equal(t, mo2.Hash, valid.Trades()[0].MakerOrderHash)
+}
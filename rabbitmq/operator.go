@@ -230,7 +230,7 @@ func (c *Connection) PublishTradeSentMessage(matches *types.Matches) error {
 	return nil
 }
 
-func (c *Connection) ConsumeQueuedTrades(ch *amqp.Channel, q *amqp.Queue, fn func(*types.Matches, uint64) error) error {
+func (c *Connection) ConsumeQueuedTrades(ch *amqp.Channel, q *amqp.Queue, fn func(*types.PendingTradeBatch, uint64) error) error {
 	go func() {
 		msgs, err := ch.Consume(
 			q.Name, // queue
@@ -251,22 +251,22 @@ func (c *Connection) ConsumeQueuedTrades(ch *amqp.Channel, q *amqp.Queue, fn fun
 		//TODO add more error handling
 		go func() {
 			for d := range msgs {
-				m := &types.Matches{}
-				err := json.Unmarshal(d.Body, &m)
+				b := &types.PendingTradeBatch{}
+				err := json.Unmarshal(d.Body, &b)
 				if err != nil {
 					logger.Error(err)
 					continue
 				}
 
-				logger.Info("Receiving pending trade")
+				logger.Info("Receiving pending trade batch")
 
-				err = m.Validate()
+				err = b.Validate()
 				if err != nil {
 					logger.Error(err)
 					d.Nack(false, false)
 
 				} else {
-					err = fn(m, d.DeliveryTag)
+					err = fn(b, d.DeliveryTag)
 					if err != nil {
 						logger.Error(err)
 						d.Nack(false, false)
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/contracts"
+	"github.com/Proofsuite/amp-matching-engine/daos"
+	"github.com/Proofsuite/amp-matching-engine/ethereum"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils"
+)
+
+// Reconcile runs the settlement reconciliation over a block range and prints the report.
+// Usage: reconcile -start <block> -end <block>
+// The process exits with status 1 if mismatches were found.
+func Reconcile(args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	start := flags.Uint64("start", 0, "first block of the range")
+	end := flags.Uint64("end", 0, "last block of the range (included)")
+	flags.Parse(args)
+
+	env := os.Getenv("GO_ENV")
+	if err := app.LoadConfig("./config", env); err != nil {
+		panic(err)
+	}
+
+	_, err := daos.InitSession(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	provider := ethereum.NewDefaultEthereumProvider()
+	walletService := services.NewWalletService(daos.NewWalletDao())
+
+	exchanges := []interfaces.Exchange{}
+	for _, a := range types.ExchangeAddresses() {
+		ex, err := contracts.NewExchange(walletService, a, provider.Client)
+		if err != nil {
+			panic(err)
+		}
+
+		exchanges = append(exchanges, ex)
+	}
+
+	reconciliationService := services.NewReconciliationService(daos.NewTradeDao(), daos.NewEventDao(), exchanges, provider)
+	report, err := reconciliationService.Reconcile(*start, *end)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(utils.JSON(report))
+
+	if !report.Reconciled() {
+		os.Exit(1)
+	}
+}
@@ -12,10 +12,15 @@ import (
 	"github.com/Proofsuite/amp-matching-engine/endpoints"
 	"github.com/Proofsuite/amp-matching-engine/errors"
 	"github.com/Proofsuite/amp-matching-engine/ethereum"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/operator"
 	"github.com/Proofsuite/amp-matching-engine/rabbitmq"
 	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils"
+	"github.com/Proofsuite/amp-matching-engine/utils/ratelimit"
 	"github.com/Proofsuite/amp-matching-engine/ws"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
@@ -24,6 +29,8 @@ import (
 	"github.com/Proofsuite/amp-matching-engine/engine"
 )
 
+var logger = utils.Logger
+
 func Start() {
 	env := os.Getenv("GO_ENV")
 
@@ -54,8 +61,17 @@ func Start() {
 	// 	Cache:      autocert.DirCache("/certs"),
 	// }
 
-	allowedHeaders := handlers.AllowedHeaders([]string{"Content-Type", "Accept", "Authorization", "Access-Control-Allow-Origin"})
+	allowedHeaders := handlers.AllowedHeaders([]string{
+		"Content-Type",
+		"Accept",
+		"Authorization",
+		"Access-Control-Allow-Origin",
+		types.APIKeyHeader,
+		types.APIKeyTimestampHeader,
+		types.APIKeySignatureHeader,
+	})
 	allowedOrigins := handlers.AllowedOrigins([]string{"*"})
+	exposedHeaders := handlers.ExposedHeaders([]string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"})
 	allowedMethods := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"})
 
 	// start the server
@@ -64,7 +80,7 @@ func Start() {
 		err := http.ListenAndServeTLS(":443",
 			"/etc/ssl/matching-engine/server_certificate.pem",
 			"/etc/ssl/matching-engine/server_key.pem",
-			handlers.CORS(allowedHeaders, allowedOrigins, allowedMethods)(router),
+			handlers.CORS(allowedHeaders, allowedOrigins, allowedMethods, exposedHeaders)(router),
 		)
 
 		if err != nil {
@@ -86,7 +102,7 @@ func Start() {
 	} else {
 		address := fmt.Sprintf(":%v", app.Config.ServerPort)
 		log.Printf("server %v starting at %v\n", app.Version, address)
-		err := http.ListenAndServe(address, handlers.CORS(allowedHeaders, allowedOrigins, allowedMethods)(router))
+		err := http.ListenAndServe(address, handlers.CORS(allowedHeaders, allowedOrigins, allowedMethods, exposedHeaders)(router))
 		if err != nil {
 			log.Fatal("The process exited with error:", err.Error())
 		}
@@ -115,23 +131,63 @@ func NewRouter(
 	tradeDao := daos.NewTradeDao()
 	accountDao := daos.NewAccountDao()
 	walletDao := daos.NewWalletDao()
+	eventDao := daos.NewEventDao()
+	feeTierDao := daos.NewFeeTierDao()
+	feeOverrideDao := daos.NewFeeOverrideDao()
+	apiKeyDao := daos.NewAPIKeyDao()
+	auditLogDao := daos.NewAuditLogDao()
+
+	// reserve the nonces of the orders stored before the order nonces were checked
+	if err := orderDao.MigrateNonces(); err != nil {
+		panic(err)
+	}
+
+	// accounts blocked by an admin and deny-listed addresses are not allowed to trade
+	complianceService := services.NewComplianceService(accountDao, app.Config.DenyListFile)
+	if err := complianceService.LoadBlockedAccounts(); err != nil {
+		panic(err)
+	}
+
+	// the server does not start without the deny list it is configured with
+	if _, err := complianceService.LoadDenyList(); err != nil {
+		panic(err)
+	}
 
 	// instantiate engine
-	eng := engine.NewEngine(rabbitConn, orderDao, tradeDao, pairDao)
+	eng := engine.NewEngine(rabbitConn, orderDao, tradeDao, pairDao, complianceService)
 
 	// get services for injection
 	accountService := services.NewAccountService(accountDao, tokenDao)
 	ohlcvService := services.NewOHLCVService(tradeDao)
-	tokenService := services.NewTokenService(tokenDao)
+	tokenService := services.NewTokenService(tokenDao, provider)
 	tradeService := services.NewTradeService(tradeDao)
 	validatorService := services.NewValidatorService(provider, accountDao, orderDao, pairDao)
 	priceService := services.NewPriceService()
 
 	infoService := services.NewInfoService(pairDao, tokenDao, tradeDao, orderDao, priceService)
 	pairService := services.NewPairService(pairDao, tokenDao, tradeDao, orderDao, eng, provider)
-	orderService := services.NewOrderService(orderDao, pairDao, accountDao, tradeDao, eng, validatorService, rabbitConn)
+	feeService := services.NewFeeService(feeTierDao, feeOverrideDao, tradeDao, pairDao)
+	orderService := services.NewOrderService(orderDao, pairDao, tokenDao, accountDao, tradeDao, eng, validatorService, feeService, complianceService, rabbitConn)
 	orderBookService := services.NewOrderBookService(pairDao, tokenDao, orderDao, eng)
-	walletService := services.NewWalletService(walletDao)
+	exportService := services.NewExportService(orderDao, tradeDao, pairDao)
+	statementService := services.NewStatementService(exportService)
+	apiKeyService := services.NewAPIKeyService(apiKeyDao, walletDao)
+
+	// operator and admin accounts are stored in an encrypted keystore
+	ks := keystore.NewKeyStore(app.Config.KeystoreDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	walletService := services.NewKeystoreWalletService(walletDao, ks)
+
+	// wallets used to be stored with their plaintext private key
+	err := walletService.MigratePrivateKeys(app.Config.KeystorePassphrase)
+	if err != nil {
+		panic(err)
+	}
+
+	err = walletService.UnlockWallets(app.Config.KeystorePassphrase)
+	if err != nil {
+		panic(err)
+	}
+
 	// cronService := crons.NewCronService(ohlcvService)
 
 	// get exchange contract instance
@@ -146,13 +202,48 @@ func NewRouter(
 		panic(err)
 	}
 
+	// the other exchange contract deployments settling the pairs bound to them
+	exchanges := []interfaces.Exchange{exchange}
+	for _, a := range types.ExchangeAddresses()[1:] {
+		ex, err := contracts.NewExchange(walletService, a, provider.Client)
+		if err != nil {
+			panic(err)
+		}
+
+		exchanges = append(exchanges, ex)
+	}
+
+	// index the exchange contract events
+	indexerService := services.NewIndexerService(eventDao, exchanges, provider)
+	go func() {
+		err := indexerService.Start()
+		if err != nil {
+			log.Print(err)
+		}
+	}()
+
+	reconciliationService := services.NewReconciliationService(tradeDao, eventDao, exchanges, provider)
+
+	// remove resting orders that are not backed by a sufficient balance or allowance anymore
+	invalidationService := services.NewInvalidationService(orderDao, pairDao, validatorService, rabbitConn)
+	go invalidationService.Start()
+
+	// keep account balances and allowances in sync with the token contracts
+	balanceService := services.NewBalanceService(accountDao, tokenDao, provider, provider.Client, invalidationService)
+	go func() {
+		err := balanceService.Start()
+		if err != nil {
+			log.Print(err)
+		}
+	}()
+
 	// deploy operator
 	op, err := operator.NewOperator(
 		walletService,
 		tradeService,
 		orderService,
 		provider,
-		exchange,
+		exchanges,
 		rabbitConn,
 	)
 
@@ -160,8 +251,43 @@ func NewRouter(
 		panic(err)
 	}
 
+	adminService := services.NewAdminService(
+		accountDao,
+		pairDao,
+		tokenDao,
+		orderDao,
+		feeOverrideDao,
+		auditLogDao,
+		orderService,
+		feeService,
+		complianceService,
+		eng,
+		op,
+	)
+
+	// cancel the open orders of the denied addresses. The addresses whose orders could not be
+	// cancelled are still denied, and their orders are cancelled again on the next reload.
+	if _, err := orderService.ReloadDenyList(); err != nil {
+		logger.Error("Could not cancel the orders of the denied addresses: ", err)
+	}
+
+	// limit the requests of each IP before the authentication of the requests signed with an API
+	// key, then limit the requests of each API key and account
+	limits := ratelimit.Limits{
+		ratelimit.Orders:     ratelimit.NewLimiter(app.Config.OrderRateLimit.Rate, app.Config.OrderRateLimit.Burst),
+		ratelimit.Cancels:    ratelimit.NewLimiter(app.Config.CancelRateLimit.Rate, app.Config.CancelRateLimit.Burst),
+		ratelimit.MarketData: ratelimit.NewLimiter(app.Config.MarketDataRateLimit.Rate, app.Config.MarketDataRateLimit.Burst),
+	}
+
+	r.Use(endpoints.NewIPRateLimitMiddleware(limits, app.Config.TrustProxyHeaders))
+	r.Use(endpoints.NewAuthMiddleware(apiKeyService))
+	r.Use(endpoints.NewRateLimitMiddleware(limits))
+	ws.SetRateLimits(limits, app.Config.TrustProxyHeaders)
+
 	// deploy http and ws endpoints
-	endpoints.ServeInfoResource(r, walletService, tokenService, infoService)
+	ws.RegisterChannel(ws.AuthChannel, ws.HandleAuthMessage)
+	endpoints.ServeAPIKeyResource(r, apiKeyService)
+	endpoints.ServeInfoResource(r, walletService, tokenService, infoService, op)
 	endpoints.ServeAccountResource(r, accountService)
 	endpoints.ServeTokenResource(r, tokenService)
 	endpoints.ServePairResource(r, pairService)
@@ -169,6 +295,11 @@ func NewRouter(
 	endpoints.ServeOHLCVResource(r, ohlcvService)
 	endpoints.ServeTradeResource(r, tradeService)
 	endpoints.ServeOrderResource(r, orderService, accountService, eng)
+	endpoints.ServeReconciliationResource(r, reconciliationService)
+	endpoints.ServeExportResource(r, exportService)
+	endpoints.ServeStatementResource(r, statementService)
+	endpoints.ServeFeeResource(r, feeService)
+	endpoints.ServeAdminResource(r, adminService)
 
 	//initialize rabbitmq subscriptions
 	rabbitConn.SubscribeOrders(eng.HandleOrders)
@@ -0,0 +1,406 @@
+package services
+
+import (
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AdminService performs the administration actions of the exchange: blocking accounts,
+// listing pairs and tokens, force-cancelling orders, purging the operator queues and
+// managing the fee tiers. Every action is recorded in the audit log with the address of
+// the admin that performed it and the state of the target before and after the action. The
+// audit log is written before the action is applied, and removed if the action fails.
+type AdminService struct {
+	accountDao     interfaces.AccountDao
+	pairDao        interfaces.PairDao
+	tokenDao       interfaces.TokenDao
+	orderDao       interfaces.OrderDao
+	feeOverrideDao interfaces.FeeOverrideDao
+	auditLogDao    interfaces.AuditLogDao
+	orderService   interfaces.OrderService
+	feeService     interfaces.FeeService
+	compliance     interfaces.ComplianceService
+	engine         interfaces.Engine
+	operator       interfaces.Operator
+}
+
+// NewAdminService returns a new instance of AdminService
+func NewAdminService(
+	accountDao interfaces.AccountDao,
+	pairDao interfaces.PairDao,
+	tokenDao interfaces.TokenDao,
+	orderDao interfaces.OrderDao,
+	feeOverrideDao interfaces.FeeOverrideDao,
+	auditLogDao interfaces.AuditLogDao,
+	orderService interfaces.OrderService,
+	feeService interfaces.FeeService,
+	compliance interfaces.ComplianceService,
+	engine interfaces.Engine,
+	operator interfaces.Operator,
+) *AdminService {
+	return &AdminService{
+		accountDao,
+		pairDao,
+		tokenDao,
+		orderDao,
+		feeOverrideDao,
+		auditLogDao,
+		orderService,
+		feeService,
+		compliance,
+		engine,
+		operator,
+	}
+}
+
+// SetAccountBlocked blocks or unblocks an account. Accounts that never traded can be blocked.
+// The open orders of a blocked account are cancelled, each cancel being recorded in the audit log.
+func (s *AdminService) SetAccountBlocked(actor, a common.Address, blocked bool) (*types.Account, error) {
+	acc, err := s.accountDao.FindOrCreate(a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	updated := *acc
+	updated.IsBlocked = blocked
+
+	action := types.AuditActionBlockAccount
+	if !blocked {
+		action = types.AuditActionUnblockAccount
+	}
+
+	err = s.audited(actor, action, "account:"+a.Hex(), acc, &updated, func() error {
+		return s.accountDao.UpdateIsBlocked(a, blocked)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !blocked {
+		s.orderService.UnblockAccount(a)
+		return &updated, nil
+	}
+
+	cancelled, err := s.orderService.BlockAccount(a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	s.auditCancelledOrders(actor, cancelled)
+	return &updated, nil
+}
+
+// ReloadDenyList reloads the deny list file and cancels the open orders of the addresses added
+// to it. The deny list is returned.
+func (s *AdminService) ReloadDenyList(actor common.Address) ([]common.Address, error) {
+	denyList, err := s.compliance.ReadDenyList()
+	if err != nil {
+		return nil, err
+	}
+
+	before := map[string]interface{}{"addresses": s.compliance.GetDenyList()}
+	after := map[string]interface{}{"addresses": denyList}
+
+	var cancelled []*types.Order
+	err = s.audited(actor, types.AuditActionReloadDenyList, "denyList", before, after, func() error {
+		cancelled, err = s.orderService.ReloadDenyList()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditCancelledOrders(actor, cancelled)
+	return s.compliance.GetDenyList(), nil
+}
+
+// GetDenyList returns the addresses of the deny list
+func (s *AdminService) GetDenyList() []common.Address {
+	return s.compliance.GetDenyList()
+}
+
+// SetPairStatus updates the listed and active flags of a pair. A nil flag is left unchanged.
+func (s *AdminService) SetPairStatus(actor, bt, qt common.Address, listed, active *bool) (*types.Pair, error) {
+	p, err := s.pairDao.GetByTokenAddress(bt, qt)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, ErrPairNotFound
+	}
+
+	updated := *p
+	if listed != nil {
+		updated.Listed = *listed
+	}
+
+	if active != nil {
+		updated.Active = *active
+	}
+
+	err = s.audited(actor, types.AuditActionUpdatePairStatus, "pair:"+p.AddressCode(), p, &updated, func() error {
+		return s.pairDao.UpdateStatus(bt, qt, updated.Listed, updated.Active)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// SetTokenStatus updates the listed and active flags of a token. A nil flag is left unchanged.
+func (s *AdminService) SetTokenStatus(actor, a common.Address, listed, active *bool) (*types.Token, error) {
+	t, err := s.tokenDao.GetByAddress(a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if t == nil {
+		return nil, ErrTokenNotFound
+	}
+
+	updated := *t
+	if listed != nil {
+		updated.Listed = *listed
+	}
+
+	if active != nil {
+		updated.Active = *active
+	}
+
+	err = s.audited(actor, types.AuditActionUpdateTokenStatus, "token:"+a.Hex(), t, &updated, func() error {
+		return s.tokenDao.UpdateStatus(a, updated.Listed, updated.Active)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// CancelOrder sends the cancel message of an order to the matching engine on behalf of its owner
+func (s *AdminService) CancelOrder(actor common.Address, h common.Hash) (*types.Order, error) {
+	o, err := s.orderService.GetByHash(h)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if o == nil {
+		return nil, ErrOrderNotFound
+	}
+
+	cancelled := *o
+	cancelled.Status = "CANCEL"
+
+	err = s.audited(actor, types.AuditActionCancelOrder, "order:"+h.Hex(), o, &cancelled, func() error {
+		o, err = s.orderService.ForceCancelOrder(h)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// PurgeQueues removes the pending transactions from the operator queues. The audit log records
+// the length of each queue before the purge and the empty queues.
+func (s *AdminService) PurgeQueues(actor common.Address) error {
+	before := queueLengths(s.operator.GetWalletStatuses())
+
+	after := map[string]int{}
+	for q := range before {
+		after[q] = 0
+	}
+
+	return s.audited(actor, types.AuditActionPurgeQueues, "operator", before, after, s.operator.PurgeQueues)
+}
+
+// CreateFeeTier creates a fee tier of a quote token
+func (s *AdminService) CreateFeeTier(actor common.Address, t *types.FeeTier) error {
+	return s.audited(actor, types.AuditActionCreateFeeTier, feeTierTarget(t.QuoteToken, t.Name), nil, t, func() error {
+		return s.feeService.CreateFeeTier(t)
+	})
+}
+
+// DeleteFeeTier removes a fee tier of a quote token
+func (s *AdminService) DeleteFeeTier(actor, qt common.Address, name string) error {
+	tiers, err := s.feeService.GetFeeTiers(qt)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	var tier *types.FeeTier
+	for _, t := range tiers {
+		if t.Name == name {
+			tier = t
+		}
+	}
+
+	if tier == nil {
+		return ErrFeeTierNotFound
+	}
+
+	return s.audited(actor, types.AuditActionDeleteFeeTier, feeTierTarget(qt, name), tier, nil, func() error {
+		return s.feeService.DeleteFeeTier(qt, name)
+	})
+}
+
+// SetFeeOverride creates or replaces the fee override of an account on a quote token
+func (s *AdminService) SetFeeOverride(actor common.Address, o *types.FeeOverride) error {
+	before, err := s.feeOverrideDao.GetByUserAddress(o.UserAddress, o.QuoteToken)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return s.audited(actor, types.AuditActionSetFeeOverride, feeOverrideTarget(o.UserAddress, o.QuoteToken), before, o, func() error {
+		return s.feeService.SetFeeOverride(o)
+	})
+}
+
+// DeleteFeeOverride removes the fee override of an account on a quote token
+func (s *AdminService) DeleteFeeOverride(actor, a, qt common.Address) error {
+	before, err := s.feeOverrideDao.GetByUserAddress(a, qt)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if before == nil {
+		return ErrFeeOverrideNotFound
+	}
+
+	return s.audited(actor, types.AuditActionDeleteFeeOverride, feeOverrideTarget(a, qt), before, nil, func() error {
+		return s.feeService.DeleteFeeOverride(a, qt)
+	})
+}
+
+// GetEngineState returns the order books of the pairs with their number of price levels, and
+// the state of the operator settlement queues
+func (s *AdminService) GetEngineState() (*types.EngineState, error) {
+	pairs, err := s.pairDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	loaded := map[string]bool{}
+	for _, code := range s.engine.OrderBookCodes() {
+		loaded[code] = true
+	}
+
+	state := &types.EngineState{
+		OrderBooks:     []*types.OrderBookState{},
+		PendingMatches: s.operator.PendingMatchesCount(),
+		Queues:         s.operator.GetWalletStatuses(),
+	}
+
+	for i := range pairs {
+		p := &pairs[i]
+
+		bids, asks, err := s.orderDao.GetOrderBook(p)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		state.OrderBooks = append(state.OrderBooks, &types.OrderBookState{
+			PairName:   p.Name(),
+			BaseToken:  p.BaseTokenAddress,
+			QuoteToken: p.QuoteTokenAddress,
+			Listed:     p.Listed,
+			Active:     p.Active,
+			Loaded:     loaded[p.Code()],
+			BidLevels:  len(bids),
+			AskLevels:  len(asks),
+		})
+	}
+
+	return state, nil
+}
+
+// GetAuditLogs returns the most recent audit logs, optionally restricted to a target
+func (s *AdminService) GetAuditLogs(target string, limit int) ([]*types.AuditLog, error) {
+	return s.auditLogDao.GetLatest(target, limit)
+}
+
+// audited records an admin action in the audit log and then applies it. The audit log is
+// removed if the action fails, so that every applied action is recorded even if the server
+// stops in between.
+func (s *AdminService) audited(actor common.Address, action, target string, before, after interface{}, apply func() error) error {
+	l, err := s.audit(actor, action, target, before, after)
+	if err != nil {
+		return err
+	}
+
+	err = apply()
+	if err != nil {
+		logger.Error(err)
+
+		if err := s.auditLogDao.Delete(l.ID); err != nil {
+			logger.Error(err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// audit records an admin action in the audit log
+func (s *AdminService) audit(actor common.Address, action, target string, before, after interface{}) (*types.AuditLog, error) {
+	l, err := types.NewAuditLog(actor, action, target, before, after)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = s.auditLogDao.Create(l)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// auditCancelledOrders records the orders cancelled as a consequence of an admin action. The
+// action itself is already recorded, so a failure is only logged.
+func (s *AdminService) auditCancelledOrders(actor common.Address, orders []*types.Order) {
+	for _, o := range orders {
+		cancelled := *o
+		cancelled.Status = "CANCEL"
+
+		_, err := s.audit(actor, types.AuditActionCancelOrder, "order:"+o.Hash.Hex(), o, &cancelled)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// queueLengths returns the length of each operator queue
+func queueLengths(statuses []*types.OperatorWalletStatus) map[string]int {
+	lengths := map[string]int{}
+	for _, s := range statuses {
+		lengths[s.Queue] = s.QueueLength
+	}
+
+	return lengths
+}
+
+func feeTierTarget(qt common.Address, name string) string {
+	return "feeTier:" + qt.Hex() + "::" + name
+}
+
+func feeOverrideTarget(a, qt common.Address) string {
+	return "feeOverride:" + a.Hex() + "::" + qt.Hex()
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type adminServiceMocks struct {
+	accountDao     *mocks.AccountDao
+	pairDao        *mocks.PairDao
+	tokenDao       *mocks.TokenDao
+	orderDao       *mocks.OrderDao
+	feeOverrideDao *mocks.FeeOverrideDao
+	auditLogDao    *mocks.AuditLogDao
+	orderService   *mocks.OrderService
+	feeService     *mocks.FeeService
+	compliance     *mocks.ComplianceService
+	engine         *mocks.Engine
+	operator       *mocks.Operator
+}
+
+func newTestAdminService() (*AdminService, *adminServiceMocks) {
+	m := &adminServiceMocks{
+		new(mocks.AccountDao),
+		new(mocks.PairDao),
+		new(mocks.TokenDao),
+		new(mocks.OrderDao),
+		new(mocks.FeeOverrideDao),
+		new(mocks.AuditLogDao),
+		new(mocks.OrderService),
+		new(mocks.FeeService),
+		new(mocks.ComplianceService),
+		new(mocks.Engine),
+		new(mocks.Operator),
+	}
+
+	s := NewAdminService(
+		m.accountDao,
+		m.pairDao,
+		m.tokenDao,
+		m.orderDao,
+		m.feeOverrideDao,
+		m.auditLogDao,
+		m.orderService,
+		m.feeService,
+		m.compliance,
+		m.engine,
+		m.operator,
+	)
+
+	return s, m
+}
+
+func TestAdminSetAccountBlocked(t *testing.T) {
+	s, m := newTestAdminService()
+
+	actor := common.HexToAddress("0x1")
+	addr := common.HexToAddress("0x2")
+	acc := &types.Account{Address: addr}
+	o := &types.Order{Hash: common.HexToHash("0x3"), UserAddress: addr, Status: "OPEN"}
+
+	logs := []*types.AuditLog{}
+	m.accountDao.On("FindOrCreate", addr).Return(acc, nil)
+	m.accountDao.On("UpdateIsBlocked", addr, true).Return(nil)
+	m.orderService.On("BlockAccount", addr).Return([]*types.Order{o}, nil)
+	m.auditLogDao.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		logs = append(logs, args.Get(0).(*types.AuditLog))
+	})
+
+	updated, err := s.SetAccountBlocked(actor, addr, true)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.True(t, updated.IsBlocked)
+	assert.False(t, acc.IsBlocked)
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, actor, logs[0].Actor)
+	assert.Equal(t, types.AuditActionBlockAccount, logs[0].Action)
+	assert.Equal(t, "account:"+addr.Hex(), logs[0].Target)
+	assert.Equal(t, map[string]*types.AuditChange{"isBlocked": {From: false, To: true}}, logs[0].Diff)
+	assert.Equal(t, types.AuditActionCancelOrder, logs[1].Action)
+	assert.Equal(t, "order:"+o.Hash.Hex(), logs[1].Target)
+	m.accountDao.AssertExpectations(t)
+	m.orderService.AssertExpectations(t)
+}
+
+func TestAdminUnblockAccount(t *testing.T) {
+	s, m := newTestAdminService()
+
+	actor := common.HexToAddress("0x1")
+	addr := common.HexToAddress("0x2")
+	acc := &types.Account{Address: addr, IsBlocked: true}
+
+	m.accountDao.On("FindOrCreate", addr).Return(acc, nil)
+	m.accountDao.On("UpdateIsBlocked", addr, false).Return(nil)
+	m.orderService.On("UnblockAccount", addr).Return()
+	m.auditLogDao.On("Create", mock.Anything).Return(nil).Once()
+
+	updated, err := s.SetAccountBlocked(actor, addr, false)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.False(t, updated.IsBlocked)
+	m.orderService.AssertNotCalled(t, "BlockAccount", addr)
+	m.orderService.AssertExpectations(t)
+	m.auditLogDao.AssertExpectations(t)
+}
+
+func TestAdminSetPairStatus(t *testing.T) {
+	s, m := newTestAdminService()
+
+	actor := common.HexToAddress("0x1")
+	bt := common.HexToAddress("0x2")
+	qt := common.HexToAddress("0x3")
+	p := &types.Pair{BaseTokenAddress: bt, QuoteTokenAddress: qt, Listed: true, Active: true}
+	active := false
+
+	m.pairDao.On("GetByTokenAddress", bt, qt).Return(p, nil)
+	m.pairDao.On("UpdateStatus", bt, qt, true, false).Return(nil)
+	m.auditLogDao.On("Create", mock.Anything).Return(nil)
+
+	updated, err := s.SetPairStatus(actor, bt, qt, nil, &active)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.True(t, updated.Listed)
+	assert.False(t, updated.Active)
+	m.pairDao.AssertExpectations(t)
+	m.auditLogDao.AssertExpectations(t)
+
+	m.pairDao.On("GetByTokenAddress", qt, bt).Return(nil, nil)
+
+	_, err = s.SetPairStatus(actor, qt, bt, nil, &active)
+	assert.Equal(t, ErrPairNotFound, err)
+}
+
+func TestAdminSetTokenStatusFailure(t *testing.T) {
+	s, m := newTestAdminService()
+
+	actor := common.HexToAddress("0x1")
+	a := common.HexToAddress("0x2")
+	tok := &types.Token{Address: a, Listed: true, Active: true}
+	active := false
+	failure := errors.New("db failure")
+
+	var l *types.AuditLog
+	m.tokenDao.On("GetByAddress", a).Return(tok, nil)
+	m.auditLogDao.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		l = args.Get(0).(*types.AuditLog)
+		l.ID = bson.NewObjectId()
+	})
+	m.tokenDao.On("UpdateStatus", a, true, false).Return(failure).Run(func(args mock.Arguments) {
+		// the action is recorded before it is applied
+		m.auditLogDao.AssertCalled(t, "Create", mock.Anything)
+	})
+	m.auditLogDao.On("Delete", mock.Anything).Return(nil)
+
+	_, err := s.SetTokenStatus(actor, a, nil, &active)
+	assert.Equal(t, failure, err)
+	m.auditLogDao.AssertCalled(t, "Delete", l.ID)
+}
+
+func TestAdminCancelOrder(t *testing.T) {
+	s, m := newTestAdminService()
+
+	actor := common.HexToAddress("0x1")
+	h := common.HexToHash("0x2")
+	o := &types.Order{Hash: h, Status: "OPEN"}
+
+	var l *types.AuditLog
+	m.orderService.On("GetByHash", h).Return(o, nil)
+	m.orderService.On("ForceCancelOrder", h).Return(o, nil)
+	m.auditLogDao.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		l = args.Get(0).(*types.AuditLog)
+	})
+
+	_, err := s.CancelOrder(actor, h)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, types.AuditActionCancelOrder, l.Action)
+	assert.Equal(t, &types.AuditChange{From: "OPEN", To: "CANCEL"}, l.Diff["status"])
+}
+
+func TestAdminPurgeQueues(t *testing.T) {
+	s, m := newTestAdminService()
+
+	actor := common.HexToAddress("0x1")
+
+	var l *types.AuditLog
+	m.operator.On("GetWalletStatuses").Return([]*types.OperatorWalletStatus{{Queue: "TX_QUEUES:0x4", QueueLength: 3}})
+	m.operator.On("PurgeQueues").Return(nil)
+	m.auditLogDao.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		l = args.Get(0).(*types.AuditLog)
+	})
+
+	err := s.PurgeQueues(actor)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, &types.AuditChange{From: float64(3), To: float64(0)}, l.Diff["TX_QUEUES:0x4"])
+	m.operator.AssertExpectations(t)
+}
+
+func TestAdminDeleteFeeOverride(t *testing.T) {
+	s, m := newTestAdminService()
+
+	actor := common.HexToAddress("0x1")
+	addr := common.HexToAddress("0x2")
+	qt := common.HexToAddress("0x3")
+
+	m.feeOverrideDao.On("GetByUserAddress", addr, qt).Return(nil, nil)
+
+	err := s.DeleteFeeOverride(actor, addr, qt)
+	assert.Equal(t, ErrFeeOverrideNotFound, err)
+	m.feeService.AssertNotCalled(t, "DeleteFeeOverride", addr, qt)
+	m.auditLogDao.AssertNotCalled(t, "Create", mock.Anything)
+}
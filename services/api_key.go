@@ -0,0 +1,206 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// APIKeyService creates the API keys of the accounts and authenticates the REST requests
+// signed with them. An API key is created by signing a challenge issued by the server with
+// the account private key. Pending challenges are kept in memory and can be used once.
+type APIKeyService struct {
+	apiKeyDao      interfaces.APIKeyDao
+	walletDao      interfaces.WalletDao
+	challenges     map[common.Hash]*types.APIKeyChallenge
+	challengeMutex *sync.Mutex
+}
+
+// NewAPIKeyService returns a new instance of APIKeyService
+func NewAPIKeyService(apiKeyDao interfaces.APIKeyDao, walletDao interfaces.WalletDao) *APIKeyService {
+	return &APIKeyService{
+		apiKeyDao:      apiKeyDao,
+		walletDao:      walletDao,
+		challenges:     map[common.Hash]*types.APIKeyChallenge{},
+		challengeMutex: &sync.Mutex{},
+	}
+}
+
+// CreateChallenge issues the challenge an account signs to create an API key with the given
+// scopes. The admin scope can only be requested by the admin accounts of the exchange.
+func (s *APIKeyService) CreateChallenge(a common.Address, scopes []string) (*types.APIKeyChallenge, error) {
+	scopes, err := types.NormalizeAPIKeyScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		if scope != types.APIKeyScopeAdmin {
+			continue
+		}
+
+		w, err := s.walletDao.GetByAddress(a)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if w == nil || !w.Admin {
+			return nil, ErrAPIKeyAdminScope
+		}
+	}
+
+	nonce, err := randomBytes(32)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	c := &types.APIKeyChallenge{
+		UserAddress: a,
+		Scopes:      scopes,
+		Nonce:       common.BytesToHash(nonce),
+		ExpiresAt:   time.Now().Add(time.Duration(app.Config.APIKeyChallengeTTL) * time.Second),
+	}
+
+	s.challengeMutex.Lock()
+	defer s.challengeMutex.Unlock()
+
+	for n, pending := range s.challenges {
+		if time.Now().After(pending.ExpiresAt) {
+			delete(s.challenges, n)
+		}
+	}
+
+	s.challenges[c.Nonce] = c
+	return c, nil
+}
+
+// CreateAPIKey verifies the signature of a challenge and creates the corresponding API key.
+// The returned key holds the secret, which is not returned by the other methods.
+func (s *APIKeyService) CreateAPIKey(a common.Address, nonce common.Hash, sig *types.Signature, label string) (*types.APIKey, error) {
+	s.challengeMutex.Lock()
+	c := s.challenges[nonce]
+	if c != nil && c.UserAddress == a {
+		delete(s.challenges, nonce)
+	}
+	s.challengeMutex.Unlock()
+
+	if c == nil || c.UserAddress != a || time.Now().After(c.ExpiresAt) {
+		return nil, ErrAPIKeyChallengeNotFound
+	}
+
+	_, err := c.VerifySignature(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := randomBytes(16)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	secret, err := randomBytes(32)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	k := &types.APIKey{
+		Key:         hex.EncodeToString(key),
+		Secret:      hex.EncodeToString(secret),
+		UserAddress: a,
+		Scopes:      c.Scopes,
+		Label:       label,
+	}
+
+	err = s.apiKeyDao.Create(k)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// GetAPIKeys returns the API keys of an account
+func (s *APIKeyService) GetAPIKeys(a common.Address) ([]*types.APIKey, error) {
+	keys, err := s.apiKeyDao.GetByUserAddress(a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey revokes an API key of an account
+func (s *APIKeyService) DeleteAPIKey(a common.Address, key string) error {
+	k, err := s.apiKeyDao.GetByKey(key)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if k == nil || k.UserAddress != a {
+		return ErrAPIKeyNotFound
+	}
+
+	err = s.apiKeyDao.Delete(a, key)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Authenticate returns the API key of a signed REST request. timestamp is the request time
+// in unix milliseconds and must be within the configured window around the server time.
+func (s *APIKeyService) Authenticate(key, timestamp, signature, method, uri string, body []byte) (*types.APIKey, error) {
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidRequestTimestamp
+	}
+
+	window := time.Duration(app.Config.APIKeyTimestampWindow) * time.Second
+	drift := time.Since(time.Unix(0, ms*int64(time.Millisecond)))
+	if drift > window || drift < -window {
+		return nil, ErrInvalidRequestTimestamp
+	}
+
+	k, err := s.apiKeyDao.GetByKey(key)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if k == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	if !k.VerifyRequestSignature(signature, timestamp, method, uri, body) {
+		return nil, ErrInvalidRequestSignature
+	}
+
+	return k, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
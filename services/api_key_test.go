@@ -0,0 +1,78 @@
+package services
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAndAuthenticateAPIKey(t *testing.T) {
+	app.Config.APIKeyChallengeTTL = 300
+	app.Config.APIKeyTimestampWindow = 30
+
+	apiKeyDao := new(mocks.APIKeyDao)
+	walletDao := new(mocks.WalletDao)
+	user := types.NewWallet()
+
+	apiKeyDao.On("Create", mock.Anything).Return(nil)
+	walletDao.On("GetByAddress", user.Address).Return(nil, nil)
+
+	apiKeyService := NewAPIKeyService(apiKeyDao, walletDao)
+
+	_, err := apiKeyService.CreateChallenge(user.Address, []string{"admin"})
+	assert.Equal(t, ErrAPIKeyAdminScope, err)
+
+	c, err := apiKeyService.CreateChallenge(user.Address, []string{"trade"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a signature of another account is refused
+	other := types.NewWallet()
+	sig, _ := types.SignHash(c.ComputeHash(), other.PrivateKey)
+	_, err = apiKeyService.CreateAPIKey(user.Address, c.Nonce, sig, "bot")
+	assert.Error(t, err)
+
+	// challenges can only be used once
+	sig, _ = types.SignHash(c.ComputeHash(), user.PrivateKey)
+	_, err = apiKeyService.CreateAPIKey(user.Address, c.Nonce, sig, "bot")
+	assert.Equal(t, ErrAPIKeyChallengeNotFound, err)
+
+	c, _ = apiKeyService.CreateChallenge(user.Address, []string{"trade"})
+	sig, _ = types.SignHash(c.ComputeHash(), user.PrivateKey)
+	k, err := apiKeyService.CreateAPIKey(user.Address, c.Nonce, sig, "bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, user.Address, k.UserAddress)
+	assert.Equal(t, []string{"read", "trade"}, k.Scopes)
+	assert.NotEmpty(t, k.Secret)
+
+	apiKeyDao.On("GetByKey", k.Key).Return(k, nil)
+	apiKeyDao.On("GetByKey", "unknown").Return(nil, nil)
+
+	ts := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+	reqSig := types.ComputeRequestSignature(k.Secret, ts, "GET", "/orders?address="+user.Address.Hex(), nil)
+
+	res, err := apiKeyService.Authenticate(k.Key, ts, reqSig, "GET", "/orders?address="+user.Address.Hex(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, k, res)
+
+	_, err = apiKeyService.Authenticate(k.Key, ts, reqSig, "GET", "/orders", nil)
+	assert.Equal(t, ErrInvalidRequestSignature, err)
+
+	_, err = apiKeyService.Authenticate("unknown", ts, reqSig, "GET", "/orders", nil)
+	assert.Equal(t, ErrAPIKeyNotFound, err)
+
+	old := strconv.FormatInt(time.Now().Add(-time.Minute).UnixNano()/int64(time.Millisecond), 10)
+	oldSig := types.ComputeRequestSignature(k.Secret, old, "GET", "/orders", nil)
+	_, err = apiKeyService.Authenticate(k.Key, old, oldSig, "GET", "/orders", nil)
+	assert.Equal(t, ErrInvalidRequestTimestamp, err)
+}
@@ -0,0 +1,400 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/ws"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// tokenRefreshInterval is the interval at which the tokens listed since the start are looked up
+	tokenRefreshInterval = time.Minute
+
+	minResubscribeBackoff = time.Second
+	maxResubscribeBackoff = time.Minute
+)
+
+var (
+	transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalEventTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+)
+
+// BalanceService keeps the token balances and allowances of the accounts up to date. It watches
+// the ERC20 Transfer and Approval events of the listed tokens and reads the balances of the
+// accounts involved from the chain. The balances are marked as unsynced while the events cannot
+// be received. Balance changes are pushed on the private balance channel.
+type BalanceService struct {
+	accountDao interfaces.AccountDao
+	tokenDao   interfaces.TokenDao
+	provider   interfaces.EthereumProvider
+	client     interfaces.EthereumClient
+	orders     interfaces.InvalidationService
+	tokens     map[common.Address]types.Token
+	subs       []ethereum.Subscription
+	mutex      *sync.Mutex
+}
+
+// NewBalanceService returns a new instance of BalanceService
+func NewBalanceService(
+	accountDao interfaces.AccountDao,
+	tokenDao interfaces.TokenDao,
+	provider interfaces.EthereumProvider,
+	client interfaces.EthereumClient,
+	orders interfaces.InvalidationService,
+) *BalanceService {
+	return &BalanceService{
+		accountDao: accountDao,
+		tokenDao:   tokenDao,
+		provider:   provider,
+		client:     client,
+		orders:     orders,
+		tokens:     make(map[common.Address]types.Token),
+		mutex:      &sync.Mutex{},
+	}
+}
+
+// Start subscribes to the Transfer and Approval events of the listed tokens and then reads the
+// balances of the existing accounts from the chain. The subscription is made first so that no
+// event is missed in between. The tokens listed later are watched as well, and the subscriptions
+// are made again if they fail.
+func (s *BalanceService) Start() error {
+	logs := make(chan eth.Log)
+	errs := make(chan error, 1)
+
+	_, err := s.watchNewTokens(logs, errs)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	go s.watch(logs, errs)
+
+	s.syncAccounts(s.watchedTokens())
+	return nil
+}
+
+func (s *BalanceService) watch(logs chan eth.Log, errs chan error) {
+	ticker := time.NewTicker(tokenRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case l := <-logs:
+			err := s.HandleLog(l)
+			if err != nil {
+				logger.Error(err)
+			}
+		case err := <-errs:
+			logger.Error("Token events subscription ended: ", err)
+			s.resubscribe(logs, errs)
+		case <-ticker.C:
+			tokens, err := s.watchNewTokens(logs, errs)
+			if err != nil {
+				logger.Error(err)
+				continue
+			}
+
+			s.syncAccounts(tokens)
+		}
+	}
+}
+
+// watchNewTokens subscribes to the events of the listed tokens that are not watched yet and
+// returns their addresses
+func (s *BalanceService) watchNewTokens(logs chan eth.Log, errs chan error) ([]common.Address, error) {
+	tokens, err := s.tokenDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	added := []types.Token{}
+	for _, t := range tokens {
+		if _, ok := s.tokens[t.Address]; !ok {
+			added = append(added, t)
+		}
+	}
+	s.mutex.Unlock()
+
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	err = s.subscribe(added, logs, errs)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []common.Address{}
+	for _, t := range added {
+		logger.Infof("Watching the events of token %v", t.Symbol)
+		addresses = append(addresses, t.Address)
+	}
+
+	return addresses, nil
+}
+
+// subscribe subscribes to the Transfer and Approval events of the given tokens. The events of
+// every subscription are sent on the same channel and the first error of a subscription is
+// sent on the errs channel.
+func (s *BalanceService) subscribe(tokens []types.Token, logs chan eth.Log, errs chan error) error {
+	addresses := []common.Address{}
+	for _, t := range tokens {
+		addresses = append(addresses, t.Address)
+	}
+
+	query := ethereum.FilterQuery{
+		Addresses: addresses,
+		Topics:    [][]common.Hash{{transferEventTopic, approvalEventTopic}},
+	}
+
+	sub, err := s.client.SubscribeFilterLogs(context.Background(), query, logs)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.mutex.Lock()
+	for _, t := range tokens {
+		s.tokens[t.Address] = t
+	}
+
+	s.subs = append(s.subs, sub)
+	s.mutex.Unlock()
+
+	go func() {
+		// the error channel is closed without an error when the subscription is cancelled
+		err, ok := <-sub.Err()
+		if !ok {
+			return
+		}
+
+		select {
+		case errs <- err:
+		default:
+		}
+	}()
+
+	return nil
+}
+
+// resubscribe is called once a subscription failed. The events received in the meantime are
+// lost, so the cached balances of the watched tokens are marked as unsynced until they are read
+// from the chain again. The token events are then subscribed to again, backing off while the
+// node cannot be reached.
+func (s *BalanceService) resubscribe(logs chan eth.Log, errs chan error) {
+	s.mutex.Lock()
+	for _, sub := range s.subs {
+		sub.Unsubscribe()
+	}
+
+	s.subs = nil
+	tokens := []types.Token{}
+	for _, t := range s.tokens {
+		tokens = append(tokens, t)
+	}
+	s.mutex.Unlock()
+
+	// drop an error sent by another subscription that failed at the same time
+	select {
+	case <-errs:
+	default:
+	}
+
+	if len(tokens) == 0 {
+		return
+	}
+
+	for _, t := range tokens {
+		err := s.accountDao.UnsyncTokenBalances(t.Address)
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+
+	backoff := minResubscribeBackoff
+	for {
+		err := s.subscribe(tokens, logs, errs)
+		if err == nil {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxResubscribeBackoff {
+			backoff = maxResubscribeBackoff
+		}
+	}
+
+	s.syncAccounts(s.watchedTokens())
+}
+
+// syncAccounts reads the balances and allowances of every account for the given tokens from the chain
+func (s *BalanceService) syncAccounts(tokens []common.Address) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	accounts, err := s.accountDao.GetAll()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	for _, a := range accounts {
+		for _, token := range tokens {
+			err := s.SyncTokenBalance(a.Address, token)
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// watchedTokens returns the addresses of the tokens whose events are watched
+func (s *BalanceService) watchedTokens() []common.Address {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tokens := []common.Address{}
+	for token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+// HandleLog reads the balances and allowances of the accounts involved in a Transfer or Approval
+// event from the chain. The cache is not updated with the event amounts: an event may be received
+// after the balances were read from the chain at a later block, and applying it again would count
+// it twice. Removed events (chain reorganisations) are handled the same way.
+func (s *BalanceService) HandleLog(l eth.Log) error {
+	if len(l.Topics) != 3 {
+		logger.Warningf("Unexpected token event in tx %v", l.TxHash.Hex())
+		return nil
+	}
+
+	token := l.Address
+	from := common.BytesToAddress(l.Topics[1].Bytes())
+	to := common.BytesToAddress(l.Topics[2].Bytes())
+
+	switch l.Topics[0] {
+	case transferEventTopic:
+		// tokens sent by the exchange (transferFrom) also consume the allowance of the sender,
+		// which is read again with the balance
+		err := s.resyncTokenBalance(from, token)
+		if err != nil {
+			return err
+		}
+
+		return s.resyncTokenBalance(to, token)
+
+	case approvalEventTopic:
+		if !types.IsExchangeAddress(to) {
+			return nil
+		}
+
+		// only the allowance to the default exchange contract is cached, the orders settled on
+		// the other exchange contracts are validated again with the allowance read from the chain
+		if to != types.DefaultExchangeAddress() {
+			if s.orders != nil {
+				s.orders.NotifyBalanceChange(from, token)
+			}
+
+			return nil
+		}
+
+		return s.resyncTokenBalance(from, token)
+	}
+
+	return nil
+}
+
+// resyncTokenBalance reads the token balance of the account from the chain if the account is known to the exchange
+func (s *BalanceService) resyncTokenBalance(owner, token common.Address) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tb, err := s.accountDao.GetTokenBalance(owner, token)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if tb == nil {
+		return nil
+	}
+
+	return s.syncTokenBalance(owner, token)
+}
+
+// SyncAccount reads the balances and allowances of the account for every listed token from the chain
+func (s *BalanceService) SyncAccount(owner common.Address) error {
+	for _, token := range s.watchedTokens() {
+		err := s.SyncTokenBalance(owner, token)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SyncTokenBalance reads the balance and allowance of the account for the given token from the chain
+func (s *BalanceService) SyncTokenBalance(owner, token common.Address) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.syncTokenBalance(owner, token)
+}
+
+func (s *BalanceService) syncTokenBalance(owner, token common.Address) error {
+	balance, err := s.provider.BalanceOf(owner, token)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	allowance, err := s.provider.ExchangeAllowance(owner, token)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	tb := &types.TokenBalance{
+		Address:   token,
+		Symbol:    s.tokens[token].Symbol,
+		Balance:   balance,
+		Allowance: allowance,
+		Synced:    true,
+	}
+
+	err = s.accountDao.SyncTokenBalance(owner, token, tb)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.notify(owner, tb)
+	return nil
+}
+
+// notify pushes the new token balance to the account owner and schedules the re-validation
+// of the resting orders that depend on it
+func (s *BalanceService) notify(owner common.Address, tb *types.TokenBalance) {
+	ws.SendBalanceMessage("UPDATE", owner, &types.BalanceUpdate{Address: owner, TokenBalance: tb})
+
+	if s.orders != nil {
+		s.orders.NotifyBalanceChange(owner, tb.Address)
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleTransferLog(t *testing.T) {
+	accountDao := new(mocks.AccountDao)
+	tokenDao := new(mocks.TokenDao)
+	provider := new(mocks.EthereumProvider)
+	client := new(mocks.EthereumClient)
+
+	token := common.HexToAddress("0x1")
+	sender := common.HexToAddress("0x2")
+	receiver := common.HexToAddress("0x3")
+
+	senderBalance := &types.TokenBalance{
+		Address:   token,
+		Balance:   big.NewInt(1000),
+		Allowance: big.NewInt(1000),
+		Synced:    true,
+	}
+
+	accountDao.On("GetTokenBalance", sender, token).Return(senderBalance, nil)
+	accountDao.On("GetTokenBalance", receiver, token).Return(nil, nil)
+	provider.On("BalanceOf", sender, token).Return(big.NewInt(600), nil)
+	provider.On("ExchangeAllowance", sender, token).Return(big.NewInt(600), nil)
+	accountDao.On("SyncTokenBalance", sender, token, &types.TokenBalance{
+		Address:   token,
+		Balance:   big.NewInt(600),
+		Allowance: big.NewInt(600),
+		Synced:    true,
+	}).Return(nil)
+
+	balanceService := NewBalanceService(accountDao, tokenDao, provider, client, nil)
+	err := balanceService.HandleLog(eth.Log{
+		Address: token,
+		Topics:  []common.Hash{transferEventTopic, sender.Hash(), receiver.Hash()},
+		Data:    common.BigToHash(big.NewInt(400)).Bytes(),
+	})
+
+	if err != nil {
+		t.Errorf("Could not handle transfer: %v", err)
+	}
+
+	// the balance of an account unknown to the exchange is not read
+	provider.AssertNotCalled(t, "BalanceOf", receiver, token)
+	accountDao.AssertExpectations(t)
+	provider.AssertExpectations(t)
+}
+
+func TestHandleApprovalLog(t *testing.T) {
+	accountDao := new(mocks.AccountDao)
+	tokenDao := new(mocks.TokenDao)
+	provider := new(mocks.EthereumProvider)
+	client := new(mocks.EthereumClient)
+
+	exchange := common.HexToAddress("0x4")
+	app.Config.Ethereum = map[string]string{"exchange_address": exchange.Hex()}
+
+	token := common.HexToAddress("0x1")
+	owner := common.HexToAddress("0x2")
+
+	accountDao.On("GetTokenBalance", owner, token).Return(&types.TokenBalance{Address: token}, nil)
+	provider.On("BalanceOf", owner, token).Return(big.NewInt(1000), nil)
+	provider.On("ExchangeAllowance", owner, token).Return(big.NewInt(500), nil)
+	accountDao.On("SyncTokenBalance", owner, token, &types.TokenBalance{
+		Address:   token,
+		Balance:   big.NewInt(1000),
+		Allowance: big.NewInt(500),
+		Synced:    true,
+	}).Return(nil)
+
+	balanceService := NewBalanceService(accountDao, tokenDao, provider, client, nil)
+
+	// approvals to other spenders are ignored
+	err := balanceService.HandleLog(eth.Log{
+		Address: token,
+		Topics:  []common.Hash{approvalEventTopic, owner.Hash(), common.HexToHash("0x5")},
+		Data:    common.BigToHash(big.NewInt(500)).Bytes(),
+	})
+
+	if err != nil {
+		t.Errorf("Could not handle approval: %v", err)
+	}
+
+	accountDao.AssertNotCalled(t, "GetTokenBalance", owner, token)
+
+	// the balance was never synced so it is read from the chain
+	err = balanceService.HandleLog(eth.Log{
+		Address: token,
+		Topics:  []common.Hash{approvalEventTopic, owner.Hash(), exchange.Hash()},
+		Data:    common.BigToHash(big.NewInt(500)).Bytes(),
+	})
+
+	if err != nil {
+		t.Errorf("Could not handle approval: %v", err)
+	}
+
+	accountDao.AssertExpectations(t)
+	provider.AssertExpectations(t)
+}
+
+type testSubscription struct {
+	err chan error
+}
+
+func (s *testSubscription) Unsubscribe() {
+	close(s.err)
+}
+
+func (s *testSubscription) Err() <-chan error {
+	return s.err
+}
+
+func TestBalanceResubscribe(t *testing.T) {
+	accountDao := new(mocks.AccountDao)
+	tokenDao := new(mocks.TokenDao)
+	provider := new(mocks.EthereumProvider)
+	client := new(mocks.EthereumClient)
+
+	token := common.HexToAddress("0x1")
+	first := &testSubscription{err: make(chan error, 1)}
+	second := &testSubscription{err: make(chan error, 1)}
+	synced := make(chan bool, 2)
+
+	tokenDao.On("GetAll").Return([]types.Token{{Address: token, Symbol: "AMP"}}, nil)
+	client.On("SubscribeFilterLogs", mock.Anything, mock.Anything, mock.Anything).Return(first, nil).Once()
+	client.On("SubscribeFilterLogs", mock.Anything, mock.Anything, mock.Anything).Return(second, nil).Once()
+	accountDao.On("UnsyncTokenBalances", token).Return(nil)
+	accountDao.On("GetAll").Return([]types.Account{}, nil).Run(func(args mock.Arguments) {
+		synced <- true
+	})
+
+	balanceService := NewBalanceService(accountDao, tokenDao, provider, client, nil)
+	err := balanceService.Start()
+	if err != nil {
+		t.Error(err)
+	}
+
+	<-synced
+	first.err <- errors.New("connection lost")
+
+	// the balances are read from the chain again once the events are subscribed to again
+	select {
+	case <-synced:
+	case <-time.After(time.Second):
+		t.Fatal("The token events were not subscribed to again")
+	}
+
+	accountDao.AssertCalled(t, "UnsyncTokenBalances", token)
+	client.AssertNumberOfCalls(t, "SubscribeFilterLogs", 2)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ComplianceService holds the addresses that are not allowed to trade: the accounts blocked by
+// an admin and the addresses of the deny list file. It is kept in memory so that the order
+// service and the matching engine can check every order and every maker order against it.
+type ComplianceService struct {
+	accountDao   interfaces.AccountDao
+	denyListFile string
+	blocked      map[common.Address]bool
+	denied       map[common.Address]bool
+	// uncancelled holds the denied addresses whose open orders are not cancelled yet
+	uncancelled map[common.Address]bool
+	mutex       *sync.RWMutex
+}
+
+// NewComplianceService returns a new instance of ComplianceService. The deny list is read from
+// denyListFile, no address is denied if it is empty.
+func NewComplianceService(accountDao interfaces.AccountDao, denyListFile string) *ComplianceService {
+	return &ComplianceService{
+		accountDao:   accountDao,
+		denyListFile: denyListFile,
+		blocked:      map[common.Address]bool{},
+		denied:       map[common.Address]bool{},
+		uncancelled:  map[common.Address]bool{},
+		mutex:        &sync.RWMutex{},
+	}
+}
+
+// LoadBlockedAccounts loads the blocked accounts from the db
+func (s *ComplianceService) LoadBlockedAccounts() error {
+	addresses, err := s.accountDao.GetBlockedAddresses()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	blocked := map[common.Address]bool{}
+	for _, a := range addresses {
+		blocked[a] = true
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.blocked = blocked
+	return nil
+}
+
+// LoadDenyList reads the deny list file and applies it right away, so that the denied addresses
+// cannot place orders anymore. It returns the denied addresses whose open orders still have to
+// be cancelled: the addresses added to the deny list, and the addresses whose orders could not
+// be cancelled after a previous reload until SetOrdersCancelled is called for them. The current
+// deny list is kept if the file cannot be read.
+func (s *ComplianceService) LoadDenyList() ([]common.Address, error) {
+	addresses, err := s.ReadDenyList()
+	if err != nil {
+		return nil, err
+	}
+
+	denied := map[common.Address]bool{}
+	for _, a := range addresses {
+		denied[a] = true
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for a := range denied {
+		if !s.denied[a] {
+			s.uncancelled[a] = true
+		}
+	}
+
+	pending := []common.Address{}
+	for a := range s.uncancelled {
+		if !denied[a] {
+			delete(s.uncancelled, a)
+			continue
+		}
+
+		pending = append(pending, a)
+	}
+
+	sortAddresses(pending)
+	s.denied = denied
+	return pending, nil
+}
+
+// SetOrdersCancelled records that the open orders of a denied address have been cancelled
+func (s *ComplianceService) SetOrdersCancelled(a common.Address) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.uncancelled, a)
+}
+
+// ReadDenyList reads the addresses of the deny list file without applying them
+func (s *ComplianceService) ReadDenyList() ([]common.Address, error) {
+	addresses := []common.Address{}
+	if s.denyListFile == "" {
+		return addresses, nil
+	}
+
+	f, err := os.Open(s.denyListFile)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	defer f.Close()
+
+	addresses, err = parseDenyList(f)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	sortAddresses(addresses)
+	return addresses, nil
+}
+
+// IsBlocked returns true if the account is blocked or its address is on the deny list
+func (s *ComplianceService) IsBlocked(a common.Address) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.blocked[a] || s.denied[a]
+}
+
+// SetBlocked blocks or unblocks an account. An unblocked account is still not allowed to trade
+// if its address is on the deny list.
+func (s *ComplianceService) SetBlocked(a common.Address, blocked bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if blocked {
+		s.blocked[a] = true
+	} else {
+		delete(s.blocked, a)
+	}
+}
+
+// GetDenyList returns the addresses of the deny list
+func (s *ComplianceService) GetDenyList() []common.Address {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	addresses := []common.Address{}
+	for a := range s.denied {
+		addresses = append(addresses, a)
+	}
+
+	sortAddresses(addresses)
+	return addresses
+}
+
+func sortAddresses(addresses []common.Address) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+}
+
+// parseDenyList reads one address per line. Empty lines and lines starting with # are ignored.
+func parseDenyList(r io.Reader) ([]common.Address, error) {
+	addresses := []common.Address{}
+	scanner := bufio.NewScanner(r)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		l := strings.TrimSpace(scanner.Text())
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+
+		if !common.IsHexAddress(l) {
+			return nil, fmt.Errorf("Invalid address on line %v of the deny list", line)
+		}
+
+		addresses = append(addresses, common.HexToAddress(l))
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDenyList(t *testing.T) {
+	addresses, err := parseDenyList(strings.NewReader(`
+# sanctioned addresses
+0x0000000000000000000000000000000000000001
+
+  0x0000000000000000000000000000000000000002
+`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}, addresses)
+
+	_, err = parseDenyList(strings.NewReader("0x1234\n"))
+	assert.Error(t, err)
+}
+
+func TestComplianceService(t *testing.T) {
+	accountDao := new(mocks.AccountDao)
+
+	f, err := ioutil.TempFile("", "deny_list")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(f.Name())
+
+	blocked := common.HexToAddress("0x1")
+	denied := common.HexToAddress("0x2")
+	other := common.HexToAddress("0x3")
+
+	f.WriteString(denied.Hex() + "\n")
+	f.Close()
+
+	accountDao.On("GetBlockedAddresses").Return([]common.Address{blocked}, nil)
+
+	s := NewComplianceService(accountDao, f.Name())
+
+	err = s.LoadBlockedAccounts()
+	if err != nil {
+		t.Error(err)
+	}
+
+	added, err := s.LoadDenyList()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, []common.Address{denied}, added)
+	assert.True(t, s.IsBlocked(blocked))
+	assert.True(t, s.IsBlocked(denied))
+	assert.False(t, s.IsBlocked(other))
+
+	// the address is returned again until its orders are cancelled
+	added, err = s.LoadDenyList()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, []common.Address{denied}, added)
+
+	// reloading an unchanged deny list does not add any address
+	s.SetOrdersCancelled(denied)
+	added, err = s.LoadDenyList()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 0, len(added))
+
+	s.SetBlocked(other, true)
+	assert.True(t, s.IsBlocked(other))
+
+	// unblocked accounts on the deny list are still blocked
+	s.SetBlocked(blocked, false)
+	s.SetBlocked(denied, false)
+	assert.False(t, s.IsBlocked(blocked))
+	assert.True(t, s.IsBlocked(denied))
+	assert.Equal(t, []common.Address{denied}, s.GetDenyList())
+}
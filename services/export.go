@@ -0,0 +1,144 @@
+package services
+
+import (
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ExportService streams the order and trade history of an account in a format
+// suitable for accounting (decimal amounts, fees and settlement transactions)
+type ExportService struct {
+	orderDao interfaces.OrderDao
+	tradeDao interfaces.TradeDao
+	pairDao  interfaces.PairDao
+}
+
+// NewExportService returns a new instance of ExportService
+func NewExportService(
+	orderDao interfaces.OrderDao,
+	tradeDao interfaces.TradeDao,
+	pairDao interfaces.PairDao,
+) *ExportService {
+	return &ExportService{orderDao, tradeDao, pairDao}
+}
+
+// ExportOrders calls fn for every order of the account, oldest orders first. The orders
+// of a pair that does not exist anymore are skipped.
+func (s *ExportService) ExportOrders(a common.Address, fn func(e *types.OrderExport) error) error {
+	pairs := s.pairCache()
+
+	return s.orderDao.StreamByUserAddress(a, func(o *types.Order) error {
+		p, err := pairs(o.BaseToken, o.QuoteToken)
+		if err == ErrPairNotFound {
+			logger.Warningf("Skipping order %v of an unknown pair", o.Hash.Hex())
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return fn(types.NewOrderExport(o, p))
+	})
+}
+
+// ExportTrades calls fn for every trade of the account, oldest trades first. A trade in
+// which the account is both the maker and the taker is exported once for each role. The
+// trades of an order that cannot be found are exported without the order fields, and the
+// trades of a pair that does not exist anymore are skipped.
+func (s *ExportService) ExportTrades(a common.Address, fn func(e *types.TradeExport) error) error {
+	pairs := s.pairCache()
+
+	// the trades of a taker order are consecutive, the last order is kept to avoid
+	// fetching it again for each of its trades
+	var last *types.Order
+	getOrder := func(h common.Hash) (*types.Order, error) {
+		if last != nil && last.Hash == h {
+			return last, nil
+		}
+
+		o, err := s.orderDao.GetByHash(h)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if o == nil {
+			logger.Warningf("Exporting the trades of order %v without the order fields: order not found", h.Hex())
+			return nil, nil
+		}
+
+		last = o
+		return o, nil
+	}
+
+	return s.tradeDao.StreamByUserAddress(a, func(t *types.Trade) error {
+		p, err := pairs(t.BaseToken, t.QuoteToken)
+		if err == ErrPairNotFound {
+			logger.Warningf("Skipping trade %v of an unknown pair", t.Hash.Hex())
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if t.Maker == a {
+			o, err := getOrder(t.MakerOrderHash)
+			if err != nil {
+				return err
+			}
+
+			err = fn(types.NewTradeExport(t, p, o, types.TradeRoleMaker))
+			if err != nil {
+				return err
+			}
+		}
+
+		if t.Taker == a {
+			o, err := getOrder(t.TakerOrderHash)
+			if err != nil {
+				return err
+			}
+
+			err = fn(types.NewTradeExport(t, p, o, types.TradeRoleTaker))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// pairCache returns a function fetching pairs by token addresses, each pair being
+// queried only once during an export. ErrPairNotFound is returned for an unknown pair.
+func (s *ExportService) pairCache() func(bt, qt common.Address) (*types.Pair, error) {
+	pairs := map[string]*types.Pair{}
+
+	return func(bt, qt common.Address) (*types.Pair, error) {
+		key := bt.Hex() + "::" + qt.Hex()
+		if p, ok := pairs[key]; ok {
+			if p == nil {
+				return nil, ErrPairNotFound
+			}
+
+			return p, nil
+		}
+
+		p, err := s.pairDao.GetByTokenAddress(bt, qt)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		pairs[key] = p
+		if p == nil {
+			return nil, ErrPairNotFound
+		}
+
+		pairs[key] = p
+		return p, nil
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/math"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportTrades(t *testing.T) {
+	orderDao := new(mocks.OrderDao)
+	tradeDao := new(mocks.TradeDao)
+	pairDao := new(mocks.PairDao)
+
+	user := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	bt := common.HexToAddress("0x3")
+	qt := common.HexToAddress("0x4")
+
+	pair := &types.Pair{
+		BaseTokenSymbol:    "ZRX",
+		BaseTokenAddress:   bt,
+		BaseTokenDecimals:  2,
+		QuoteTokenSymbol:   "WETH",
+		QuoteTokenAddress:  qt,
+		QuoteTokenDecimals: 2,
+	}
+
+	takerOrder := &types.Order{Hash: common.HexToHash("0x10"), Side: "BUY", MakeFee: big.NewInt(5), TakeFee: big.NewInt(10)}
+	makerOrder := &types.Order{Hash: common.HexToHash("0x11"), Side: "SELL", MakeFee: big.NewInt(5), TakeFee: big.NewInt(10)}
+
+	pricepoint := math.Mul(big.NewInt(2), math.Exp(big.NewInt(10), big.NewInt(20)))
+	trades := []*types.Trade{
+		{Hash: common.HexToHash("0x20"), Maker: other, Taker: user, BaseToken: bt, QuoteToken: qt, TakerOrderHash: takerOrder.Hash, PricePoint: pricepoint, Amount: big.NewInt(150), Status: "SUCCESS"},
+		{Hash: common.HexToHash("0x21"), Maker: other, Taker: user, BaseToken: bt, QuoteToken: qt, TakerOrderHash: takerOrder.Hash, PricePoint: pricepoint, Amount: big.NewInt(50), Status: "SUCCESS"},
+		{Hash: common.HexToHash("0x22"), Maker: user, Taker: user, BaseToken: bt, QuoteToken: qt, MakerOrderHash: makerOrder.Hash, TakerOrderHash: takerOrder.Hash, PricePoint: pricepoint, Amount: big.NewInt(100), Status: "PENDING"},
+	}
+
+	pairDao.On("GetByTokenAddress", bt, qt).Return(pair, nil).Once()
+	orderDao.On("GetByHash", takerOrder.Hash).Return(takerOrder, nil)
+	orderDao.On("GetByHash", makerOrder.Hash).Return(makerOrder, nil)
+	tradeDao.On("StreamByUserAddress", user, mock.Anything).Return(func(a common.Address, fn func(*types.Trade) error) error {
+		for _, tr := range trades {
+			err := fn(tr)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	exportService := NewExportService(orderDao, tradeDao, pairDao)
+
+	res := []*types.TradeExport{}
+	err := exportService.ExportTrades(user, func(e *types.TradeExport) error {
+		res = append(res, e)
+		return nil
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, 4, len(res))
+	assert.Equal(t, "ZRX/WETH", res[0].PairName)
+	assert.Equal(t, types.TradeRoleTaker, res[0].Role)
+	assert.Equal(t, "BUY", res[0].Side)
+	assert.Equal(t, 2.0, res[0].Price)
+	assert.Equal(t, 1.5, res[0].Amount)
+	assert.Equal(t, 0.1, res[0].Fee)
+
+	assert.Equal(t, types.TradeRoleMaker, res[2].Role)
+	assert.Equal(t, "SELL", res[2].Side)
+	assert.Equal(t, 0.05, res[2].Fee)
+	assert.Equal(t, types.TradeRoleTaker, res[3].Role)
+	assert.Equal(t, common.HexToHash("0x22"), res[3].Hash)
+
+	pairDao.AssertNumberOfCalls(t, "GetByTokenAddress", 1)
+	orderDao.AssertNumberOfCalls(t, "GetByHash", 3)
+}
+
+func TestExportTradesMissingOrderAndPair(t *testing.T) {
+	orderDao := new(mocks.OrderDao)
+	tradeDao := new(mocks.TradeDao)
+	pairDao := new(mocks.PairDao)
+
+	user := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	bt := common.HexToAddress("0x3")
+	qt := common.HexToAddress("0x4")
+	unknown := common.HexToAddress("0x5")
+
+	pair := &types.Pair{
+		BaseTokenSymbol:    "ZRX",
+		BaseTokenAddress:   bt,
+		BaseTokenDecimals:  2,
+		QuoteTokenSymbol:   "WETH",
+		QuoteTokenAddress:  qt,
+		QuoteTokenDecimals: 2,
+	}
+
+	pricepoint := math.Mul(big.NewInt(2), math.Exp(big.NewInt(10), big.NewInt(20)))
+	trades := []*types.Trade{
+		{Hash: common.HexToHash("0x20"), Maker: other, Taker: user, BaseToken: unknown, QuoteToken: qt, TakerOrderHash: common.HexToHash("0x10"), PricePoint: pricepoint, Amount: big.NewInt(150), Status: "SUCCESS"},
+		{Hash: common.HexToHash("0x21"), Maker: other, Taker: user, BaseToken: bt, QuoteToken: qt, TakerOrderHash: common.HexToHash("0x11"), PricePoint: pricepoint, Amount: big.NewInt(50), Status: "SUCCESS"},
+	}
+
+	pairDao.On("GetByTokenAddress", unknown, qt).Return(nil, nil)
+	pairDao.On("GetByTokenAddress", bt, qt).Return(pair, nil)
+	orderDao.On("GetByHash", common.HexToHash("0x11")).Return(nil, nil)
+	tradeDao.On("StreamByUserAddress", user, mock.Anything).Return(func(a common.Address, fn func(*types.Trade) error) error {
+		for _, tr := range trades {
+			err := fn(tr)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	exportService := NewExportService(orderDao, tradeDao, pairDao)
+
+	res := []*types.TradeExport{}
+	err := exportService.ExportTrades(user, func(e *types.TradeExport) error {
+		res = append(res, e)
+		return nil
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	// the trade of the unknown pair is skipped and the trade of the missing order has no order fields
+	assert.Equal(t, 1, len(res))
+	assert.Equal(t, common.HexToHash("0x21"), res[0].Hash)
+	assert.Equal(t, common.HexToHash("0x11"), res[0].OrderHash)
+	assert.Equal(t, "", res[0].Side)
+	assert.Equal(t, 0.0, res[0].Fee)
+	assert.Equal(t, 0.5, res[0].Amount)
+}
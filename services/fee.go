@@ -0,0 +1,255 @@
+package services
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/math"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// feeCacheDuration is the time during which the fee tier of an account is cached
+const feeCacheDuration = 5 * time.Minute
+
+// FeeService computes the fees applicable to an account from its trailing 30 days volume,
+// the fee tiers of the quote token and the account fee overrides. Accounts that have not
+// reached any tier pay the pair fees.
+type FeeService struct {
+	feeTierDao     interfaces.FeeTierDao
+	feeOverrideDao interfaces.FeeOverrideDao
+	tradeDao       interfaces.TradeDao
+	pairDao        interfaces.PairDao
+	cache          map[string]*accountFeeSchedule
+	cacheMutex     *sync.Mutex
+}
+
+// accountFeeSchedule is the cached fee schedule of an account on a quote token
+type accountFeeSchedule struct {
+	volume    *big.Int
+	tier      *types.FeeTier
+	override  *types.FeeOverride
+	expiresAt time.Time
+}
+
+// NewFeeService returns a new instance of FeeService
+func NewFeeService(
+	feeTierDao interfaces.FeeTierDao,
+	feeOverrideDao interfaces.FeeOverrideDao,
+	tradeDao interfaces.TradeDao,
+	pairDao interfaces.PairDao,
+) *FeeService {
+	return &FeeService{
+		feeTierDao:     feeTierDao,
+		feeOverrideDao: feeOverrideDao,
+		tradeDao:       tradeDao,
+		pairDao:        pairDao,
+		cache:          map[string]*accountFeeSchedule{},
+		cacheMutex:     &sync.Mutex{},
+	}
+}
+
+// GetAccountFees returns the fees an account has to sign in its orders on the pair p
+func (s *FeeService) GetAccountFees(a common.Address, p *types.Pair) (*types.AccountFees, error) {
+	schedule, err := s.getSchedule(a, p.QuoteTokenAddress)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	fees := &types.AccountFees{
+		UserAddress: a,
+		BaseToken:   p.BaseTokenAddress,
+		QuoteToken:  p.QuoteTokenAddress,
+		Volume:      schedule.volume,
+		MakeFee:     p.MakeFee,
+		TakeFee:     p.TakeFee,
+	}
+
+	if schedule.tier != nil {
+		fees.Tier = schedule.tier.Name
+		fees.MakeFee = schedule.tier.MakeFee
+		fees.TakeFee = schedule.tier.TakeFee
+	}
+
+	if schedule.override != nil {
+		fees.Override = true
+		fees.MakeFee = schedule.override.MakeFee
+		fees.TakeFee = schedule.override.TakeFee
+	}
+
+	return fees, nil
+}
+
+// GetAccountFeesByPair returns the fees an account has to sign in its orders on the pair
+// of the given base and quote tokens
+func (s *FeeService) GetAccountFeesByPair(a, bt, qt common.Address) (*types.AccountFees, error) {
+	p, err := s.pairDao.GetByTokenAddress(bt, qt)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, ErrPairNotFound
+	}
+
+	return s.GetAccountFees(a, p)
+}
+
+// GetFeeTiers returns the fee tiers of a quote token sorted by increasing minimum volume
+func (s *FeeService) GetFeeTiers(qt common.Address) ([]*types.FeeTier, error) {
+	tiers, err := s.feeTierDao.GetByQuoteToken(qt)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	sort.Slice(tiers, func(i, j int) bool {
+		return math.IsStrictlySmallerThan(tiers[i].MinVolume, tiers[j].MinVolume)
+	})
+
+	return tiers, nil
+}
+
+// CreateFeeTier adds a fee tier. The cached account fee schedules are discarded.
+func (s *FeeService) CreateFeeTier(t *types.FeeTier) error {
+	err := t.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = s.feeTierDao.Create(t)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.flushCache()
+	return nil
+}
+
+// DeleteFeeTier removes a fee tier of a quote token. The cached account fee schedules are discarded.
+func (s *FeeService) DeleteFeeTier(qt common.Address, name string) error {
+	err := s.feeTierDao.DeleteByName(qt, name)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.flushCache()
+	return nil
+}
+
+// SetFeeOverride creates or replaces the fee override of an account on a quote token
+func (s *FeeService) SetFeeOverride(o *types.FeeOverride) error {
+	err := o.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = s.feeOverrideDao.Upsert(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.invalidate(o.UserAddress, o.QuoteToken)
+	return nil
+}
+
+// DeleteFeeOverride removes the fee override of an account on a quote token
+func (s *FeeService) DeleteFeeOverride(a, qt common.Address) error {
+	err := s.feeOverrideDao.Delete(a, qt)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	s.invalidate(a, qt)
+	return nil
+}
+
+// getSchedule returns the cached fee schedule of an account on a quote token, or computes it
+// if it is missing or has expired
+func (s *FeeService) getSchedule(a, qt common.Address) (*accountFeeSchedule, error) {
+	key := a.Hex() + "::" + qt.Hex()
+
+	s.cacheMutex.Lock()
+	schedule := s.cache[key]
+	s.cacheMutex.Unlock()
+
+	if schedule != nil && time.Now().Before(schedule.expiresAt) {
+		return schedule, nil
+	}
+
+	override, err := s.feeOverrideDao.GetByUserAddress(a, qt)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := s.getQuoteVolume(a, qt)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers, err := s.feeTierDao.GetByQuoteToken(qt)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule = &accountFeeSchedule{
+		volume:    volume,
+		tier:      types.SelectFeeTier(tiers, volume),
+		override:  override,
+		expiresAt: time.Now().Add(feeCacheDuration),
+	}
+
+	s.cacheMutex.Lock()
+	s.cache[key] = schedule
+	s.cacheMutex.Unlock()
+
+	return schedule, nil
+}
+
+// getQuoteVolume returns the trailing volume of an account on the pairs of a quote token,
+// in quote token units
+func (s *FeeService) getQuoteVolume(a, qt common.Address) (*big.Int, error) {
+	volumes, err := s.tradeDao.GetAccountVolumes(a, qt, time.Now().Add(-types.FeeVolumePeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	total := big.NewInt(0)
+	for bt, v := range volumes {
+		p, err := s.pairDao.GetByTokenAddress(bt, qt)
+		if err != nil {
+			return nil, err
+		}
+
+		if p == nil {
+			continue
+		}
+
+		total = math.Add(total, math.Div(v, p.PairMultiplier()))
+	}
+
+	return total, nil
+}
+
+func (s *FeeService) invalidate(a, qt common.Address) {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	delete(s.cache, a.Hex()+"::"+qt.Hex())
+}
+
+func (s *FeeService) flushCache() {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	s.cache = map[string]*accountFeeSchedule{}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/math"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAccountFees(t *testing.T) {
+	feeTierDao := new(mocks.FeeTierDao)
+	feeOverrideDao := new(mocks.FeeOverrideDao)
+	tradeDao := new(mocks.TradeDao)
+	pairDao := new(mocks.PairDao)
+
+	user := common.HexToAddress("0x1")
+	maker := common.HexToAddress("0x2")
+	bt := common.HexToAddress("0x3")
+	qt := common.HexToAddress("0x4")
+
+	pair := &types.Pair{
+		BaseTokenAddress:  bt,
+		BaseTokenDecimals: 18,
+		QuoteTokenAddress: qt,
+		MakeFee:           big.NewInt(10),
+		TakeFee:           big.NewInt(20),
+	}
+
+	tiers := []*types.FeeTier{
+		{Name: "silver", QuoteToken: qt, MinVolume: big.NewInt(1000), MakeFee: big.NewInt(5), TakeFee: big.NewInt(15)},
+		{Name: "gold", QuoteToken: qt, MinVolume: big.NewInt(5000), MakeFee: big.NewInt(0), TakeFee: big.NewInt(10)},
+	}
+
+	// amount * pricepoint of a 2000 quote tokens volume
+	volume := math.Mul(big.NewInt(2000), pair.PairMultiplier())
+
+	override := &types.FeeOverride{UserAddress: maker, QuoteToken: qt, MakeFee: big.NewInt(0), TakeFee: big.NewInt(1)}
+
+	feeTierDao.On("GetByQuoteToken", qt).Return(tiers, nil)
+	feeOverrideDao.On("GetByUserAddress", user, qt).Return(nil, nil)
+	feeOverrideDao.On("GetByUserAddress", maker, qt).Return(override, nil)
+	tradeDao.On("GetAccountVolumes", user, qt, mock.Anything).Return(map[common.Address]*big.Int{bt: volume}, nil).Once()
+	tradeDao.On("GetAccountVolumes", maker, qt, mock.Anything).Return(map[common.Address]*big.Int{}, nil)
+	pairDao.On("GetByTokenAddress", bt, qt).Return(pair, nil)
+
+	feeService := NewFeeService(feeTierDao, feeOverrideDao, tradeDao, pairDao)
+
+	fees, err := feeService.GetAccountFees(user, pair)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "silver", fees.Tier)
+	assert.Equal(t, big.NewInt(2000), fees.Volume)
+	assert.Equal(t, big.NewInt(5), fees.MakeFee)
+	assert.Equal(t, big.NewInt(15), fees.TakeFee)
+
+	// the schedule is cached
+	_, err = feeService.GetAccountFees(user, pair)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tradeDao.AssertNumberOfCalls(t, "GetAccountVolumes", 1)
+
+	fees, err = feeService.GetAccountFees(maker, pair)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.True(t, fees.Override)
+	assert.Equal(t, "", fees.Tier)
+	assert.Equal(t, big.NewInt(0), fees.MakeFee)
+	assert.Equal(t, big.NewInt(1), fees.TakeFee)
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/contracts/contractsinterfaces"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+)
+
+// backfillMargin is the number of blocks before the last processed block that are indexed again on
+// startup. Events are only stored once, this margin covers chain reorganisations and events received
+// out of order before the process stopped.
+const backfillMargin = 12
+
+// backfillChunkSize is the number of blocks whose events are requested at once. The node limits the
+// number of logs returned by a single request, and the last processed block advances after each chunk
+// so that an interrupted backfill resumes where it stopped.
+const backfillChunkSize = 5000
+
+// checkpointInterval is the interval between two backfills of the blocks mined since the last
+// processed block. The last processed block only advances once all the events of a range of
+// blocks are stored, the events received from the subscriptions are stored but do not move it.
+const checkpointInterval = time.Minute
+
+// IndexerService indexes the LogTrade, LogBatchTrades and LogError events emitted by the exchange
+// smart contracts. On startup, the events emitted since the last processed block of each contract
+// are backfilled.
+// New events are then stored as they are emitted, and the new blocks are backfilled periodically
+// to advance the last processed block.
+type IndexerService struct {
+	eventDao  interfaces.EventDao
+	exchanges []interfaces.Exchange
+	provider  interfaces.EthereumProvider
+}
+
+// NewIndexerService returns a new instance of IndexerService indexing the events of the given
+// exchange contracts
+func NewIndexerService(eventDao interfaces.EventDao, exchanges []interfaces.Exchange, provider interfaces.EthereumProvider) *IndexerService {
+	return &IndexerService{eventDao, exchanges, provider}
+}
+
+// Start subscribes to the exchange events, backfills the events emitted while the indexer was not
+// running and then follows new events. The subscriptions are made before backfilling so that no
+// event is missed in between.
+func (s *IndexerService) Start() error {
+	for _, ex := range s.exchanges {
+		trades, err := ex.ListenToTrades()
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		batchTrades, err := ex.ListenToBatchTrades()
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		errorEvents, err := ex.ListenToErrors()
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		go s.follow(trades, batchTrades, errorEvents)
+	}
+
+	err := s.Backfill()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	go s.checkpoint()
+	return nil
+}
+
+// Backfill indexes the events emitted by every exchange contract since its last processed block up to
+// the latest block.
+func (s *IndexerService) Backfill() error {
+	end, err := s.provider.BlockNumber()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for _, ex := range s.exchanges {
+		err := s.backfillExchange(ex, end)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillExchange indexes the events emitted by an exchange contract since its last processed block
+// up to the end block, by chunks of blocks. The last processed block of the contract is set to the end
+// of a chunk once all the events of the chunk are stored.
+func (s *IndexerService) backfillExchange(ex interfaces.Exchange, end uint64) error {
+	last, err := s.eventDao.GetLastProcessedBlock(ex.GetAddress())
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	start := uint64(0)
+	if last > backfillMargin {
+		start = last - backfillMargin
+	}
+
+	if start > end {
+		return nil
+	}
+
+	logger.Debugf("Backfilling the events of exchange %v from block %v to block %v", ex.GetAddress().Hex(), start, end)
+
+	for from := start; from <= end; from += backfillChunkSize {
+		to := from + backfillChunkSize - 1
+		if to > end {
+			to = end
+		}
+
+		err := s.backfillRange(ex, from, to)
+		if err != nil {
+			return err
+		}
+
+		err = s.eventDao.SetLastProcessedBlock(ex.GetAddress(), to)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillRange indexes the events emitted by an exchange contract between the start and end blocks
+// (included). The events stored for that range in blocks that are not part of the canonical chain
+// anymore (chain reorganisations) are removed.
+func (s *IndexerService) backfillRange(ex interfaces.Exchange, start, end uint64) error {
+	blockHashes := []common.Hash{}
+
+	trades, err := ex.FilterTrades(start, &end)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for _, ev := range trades {
+		err := s.IndexTrade(ev)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		blockHashes = append(blockHashes, ev.Raw.BlockHash)
+	}
+
+	batchTrades, err := ex.FilterBatchTrades(start, &end)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for _, ev := range batchTrades {
+		err := s.IndexBatchTrades(ev)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		blockHashes = append(blockHashes, ev.Raw.BlockHash)
+	}
+
+	errorEvents, err := ex.FilterErrors(start, &end)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	for _, ev := range errorEvents {
+		err := s.IndexError(ev)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		blockHashes = append(blockHashes, ev.Raw.BlockHash)
+	}
+
+	err = s.eventDao.RemoveReorged(ex.GetAddress(), start, end, blockHashes)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if len(trades)+len(batchTrades)+len(errorEvents) > 0 {
+		logger.Infof("Backfilled %v trade, %v batch trade and %v error events of exchange %v", len(trades), len(batchTrades), len(errorEvents), ex.GetAddress().Hex())
+	}
+
+	return nil
+}
+
+// checkpoint backfills the new blocks periodically. A failed backfill is retried from the same
+// last processed block on the next tick.
+func (s *IndexerService) checkpoint() {
+	ticker := time.NewTicker(checkpointInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := s.Backfill()
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+func (s *IndexerService) follow(
+	trades chan *contractsinterfaces.ExchangeLogTrade,
+	batchTrades chan *contractsinterfaces.ExchangeLogBatchTrades,
+	errorEvents chan *contractsinterfaces.ExchangeLogError,
+) {
+	for {
+		select {
+		case ev := <-trades:
+			err := s.IndexTrade(ev)
+			if err != nil {
+				logger.Error(err)
+			}
+		case ev := <-batchTrades:
+			err := s.IndexBatchTrades(ev)
+			if err != nil {
+				logger.Error(err)
+			}
+		case ev := <-errorEvents:
+			err := s.IndexError(ev)
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// IndexTrade stores a LogTrade event
+func (s *IndexerService) IndexTrade(ev *contractsinterfaces.ExchangeLogTrade) error {
+	e := newContractEvent("LogTrade", ev.Raw)
+	e.Maker = ev.Maker
+	e.Taker = ev.Taker
+	e.TokenSell = ev.TokenSell
+	e.TokenBuy = ev.TokenBuy
+	e.FilledAmountSell = ev.FilledAmountSell
+	e.FilledAmountBuy = ev.FilledAmountBuy
+	e.PaidFeeMake = ev.PaidFeeMake
+	e.PaidFeeTake = ev.PaidFeeTake
+	e.OrderHash = common.BytesToHash(ev.OrderHash[:])
+	e.TradeHash = common.BytesToHash(ev.TradeHash[:])
+	e.TokenPairHash = common.BytesToHash(ev.TokenPairHash[:])
+
+	return s.store(e)
+}
+
+// IndexBatchTrades stores a LogBatchTrades event
+func (s *IndexerService) IndexBatchTrades(ev *contractsinterfaces.ExchangeLogBatchTrades) error {
+	e := newContractEvent("LogBatchTrades", ev.Raw)
+	e.TokenPairHash = common.BytesToHash(ev.TokenPairHash[:])
+
+	for _, h := range ev.MakerOrderHashes {
+		e.MakerOrderHashes = append(e.MakerOrderHashes, common.BytesToHash(h[:]))
+	}
+
+	for _, h := range ev.TakerOrderHashes {
+		e.TakerOrderHashes = append(e.TakerOrderHashes, common.BytesToHash(h[:]))
+	}
+
+	return s.store(e)
+}
+
+// IndexError stores a LogError event
+func (s *IndexerService) IndexError(ev *contractsinterfaces.ExchangeLogError) error {
+	e := newContractEvent("LogError", ev.Raw)
+	e.ErrorID = ev.ErrorId
+	e.MakerOrderHash = common.BytesToHash(ev.MakerOrderHash[:])
+	e.TakerOrderHash = common.BytesToHash(ev.TakerOrderHash[:])
+
+	return s.store(e)
+}
+
+func (s *IndexerService) store(e *types.ContractEvent) error {
+	err := s.eventDao.Upsert(e)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func newContractEvent(eventType string, log eth.Log) *types.ContractEvent {
+	return &types.ContractEvent{
+		Type:            eventType,
+		ContractAddress: log.Address,
+		BlockNumber:     log.BlockNumber,
+		BlockHash:       log.BlockHash,
+		TxHash:          log.TxHash,
+		LogIndex:        log.Index,
+		Removed:         log.Removed,
+	}
+}
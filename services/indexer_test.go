@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/contracts/contractsinterfaces"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIndexerBackfill(t *testing.T) {
+	eventDao := new(mocks.EventDao)
+	exchange := new(mocks.Exchange)
+	other := new(mocks.Exchange)
+	provider := new(mocks.EthereumProvider)
+
+	exchangeAddress := common.HexToAddress("0x10")
+	otherAddress := common.HexToAddress("0x11")
+
+	trade := &contractsinterfaces.ExchangeLogTrade{
+		Raw: eth.Log{TxHash: common.HexToHash("0x1"), BlockNumber: 120, BlockHash: common.HexToHash("0x20")},
+	}
+
+	batch := &contractsinterfaces.ExchangeLogBatchTrades{
+		Raw: eth.Log{TxHash: common.HexToHash("0x2"), BlockNumber: 140, BlockHash: common.HexToHash("0x21")},
+	}
+
+	end := uint64(150)
+	stored := []*types.ContractEvent{}
+
+	// the checkpoints are kept for each exchange contract
+	eventDao.On("GetLastProcessedBlock", exchangeAddress).Return(uint64(100), nil)
+	eventDao.On("GetLastProcessedBlock", otherAddress).Return(uint64(130), nil)
+	provider.On("BlockNumber").Return(end, nil)
+	exchange.On("FilterTrades", uint64(100-backfillMargin), &end).Return([]*contractsinterfaces.ExchangeLogTrade{trade}, nil)
+	exchange.On("FilterBatchTrades", uint64(100-backfillMargin), &end).Return([]*contractsinterfaces.ExchangeLogBatchTrades{}, nil)
+	exchange.On("FilterErrors", uint64(100-backfillMargin), &end).Return([]*contractsinterfaces.ExchangeLogError{}, nil)
+	exchange.On("GetAddress").Return(exchangeAddress)
+	other.On("FilterTrades", uint64(130-backfillMargin), &end).Return([]*contractsinterfaces.ExchangeLogTrade{}, nil)
+	other.On("FilterBatchTrades", uint64(130-backfillMargin), &end).Return([]*contractsinterfaces.ExchangeLogBatchTrades{batch}, nil)
+	other.On("FilterErrors", uint64(130-backfillMargin), &end).Return([]*contractsinterfaces.ExchangeLogError{}, nil)
+	other.On("GetAddress").Return(otherAddress)
+	eventDao.On("Upsert", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		stored = append(stored, args.Get(0).(*types.ContractEvent))
+	})
+
+	// the events of the blocks that are not part of the canonical chain anymore are removed
+	eventDao.On("RemoveReorged", exchangeAddress, uint64(100-backfillMargin), end, []common.Hash{trade.Raw.BlockHash}).Return(nil)
+	eventDao.On("RemoveReorged", otherAddress, uint64(130-backfillMargin), end, []common.Hash{batch.Raw.BlockHash}).Return(nil)
+	eventDao.On("SetLastProcessedBlock", exchangeAddress, end).Return(nil).Run(func(args mock.Arguments) {
+		// the checkpoint only advances once the whole range of the contract is stored
+		assert.Equal(t, 1, len(stored))
+	})
+	eventDao.On("SetLastProcessedBlock", otherAddress, end).Return(nil)
+
+	s := NewIndexerService(eventDao, []interfaces.Exchange{exchange, other}, provider)
+	err := s.Backfill()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "LogTrade", stored[0].Type)
+	assert.Equal(t, "LogBatchTrades", stored[1].Type)
+	eventDao.AssertExpectations(t)
+	eventDao.AssertNumberOfCalls(t, "SetLastProcessedBlock", 2)
+}
+
+func TestIndexerBackfillChunks(t *testing.T) {
+	eventDao := new(mocks.EventDao)
+	exchange := new(mocks.Exchange)
+	provider := new(mocks.EthereumProvider)
+
+	address := common.HexToAddress("0x10")
+	end := uint64(2*backfillChunkSize + 10)
+	ranges := [][2]uint64{}
+
+	eventDao.On("GetLastProcessedBlock", address).Return(uint64(0), nil)
+	provider.On("BlockNumber").Return(end, nil)
+	exchange.On("GetAddress").Return(address)
+	exchange.On("FilterTrades", mock.Anything, mock.Anything).Return([]*contractsinterfaces.ExchangeLogTrade{}, nil).Run(func(args mock.Arguments) {
+		ranges = append(ranges, [2]uint64{args.Get(0).(uint64), *args.Get(1).(*uint64)})
+	})
+	exchange.On("FilterBatchTrades", mock.Anything, mock.Anything).Return([]*contractsinterfaces.ExchangeLogBatchTrades{}, nil)
+	exchange.On("FilterErrors", mock.Anything, mock.Anything).Return([]*contractsinterfaces.ExchangeLogError{}, nil)
+	eventDao.On("RemoveReorged", address, mock.Anything, mock.Anything, []common.Hash{}).Return(nil)
+	eventDao.On("SetLastProcessedBlock", address, mock.Anything).Return(nil)
+
+	s := NewIndexerService(eventDao, []interfaces.Exchange{exchange}, provider)
+	err := s.Backfill()
+	if err != nil {
+		t.Error(err)
+	}
+
+	// a backfill from the first block is split in chunks and the checkpoint advances after each chunk
+	expected := [][2]uint64{
+		{0, backfillChunkSize - 1},
+		{backfillChunkSize, 2*backfillChunkSize - 1},
+		{2 * backfillChunkSize, end},
+	}
+
+	assert.Equal(t, expected, ranges)
+	eventDao.AssertCalled(t, "SetLastProcessedBlock", address, uint64(backfillChunkSize-1))
+	eventDao.AssertCalled(t, "SetLastProcessedBlock", address, uint64(2*backfillChunkSize-1))
+	eventDao.AssertCalled(t, "SetLastProcessedBlock", address, end)
+}
+
+func TestIndexerBackfillFailure(t *testing.T) {
+	eventDao := new(mocks.EventDao)
+	exchange := new(mocks.Exchange)
+	provider := new(mocks.EthereumProvider)
+
+	address := common.HexToAddress("0x10")
+	trade := &contractsinterfaces.ExchangeLogTrade{
+		Raw: eth.Log{TxHash: common.HexToHash("0x1"), BlockNumber: 5},
+	}
+
+	eventDao.On("GetLastProcessedBlock", address).Return(uint64(0), nil)
+	provider.On("BlockNumber").Return(uint64(10), nil)
+	exchange.On("GetAddress").Return(address)
+	exchange.On("FilterTrades", uint64(0), mock.Anything).Return([]*contractsinterfaces.ExchangeLogTrade{trade}, nil)
+	eventDao.On("Upsert", mock.Anything).Return(errors.New("db failure"))
+
+	s := NewIndexerService(eventDao, []interfaces.Exchange{exchange}, provider)
+	err := s.Backfill()
+	assert.Error(t, err)
+
+	eventDao.AssertNotCalled(t, "SetLastProcessedBlock", mock.Anything, mock.Anything)
+	eventDao.AssertNotCalled(t, "RemoveReorged", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	exchange.AssertNotCalled(t, "FilterBatchTrades", mock.Anything, mock.Anything)
+}
+
+func TestIndexerLiveEvent(t *testing.T) {
+	eventDao := new(mocks.EventDao)
+	s := NewIndexerService(eventDao, []interfaces.Exchange{new(mocks.Exchange)}, new(mocks.EthereumProvider))
+
+	ev := &contractsinterfaces.ExchangeLogError{
+		ErrorId: 1,
+		Raw:     eth.Log{TxHash: common.HexToHash("0x1"), BlockNumber: 200},
+	}
+
+	eventDao.On("Upsert", mock.Anything).Return(nil)
+
+	err := s.IndexError(ev)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// the events received from the subscriptions do not move the last processed block
+	eventDao.AssertNotCalled(t, "SetLastProcessedBlock", mock.Anything, mock.Anything)
+}
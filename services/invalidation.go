@@ -0,0 +1,292 @@
+package services
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/rabbitmq"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/math"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// orderSweepInterval is the interval at which all the resting orders are re-validated
+	orderSweepInterval = 5 * time.Minute
+	// balanceChangeQueueSize is the number of balance changes that can wait to be processed
+	balanceChangeQueueSize = 1000
+)
+
+type balanceChange struct {
+	owner common.Address
+	token common.Address
+}
+
+// InvalidationService re-validates resting orders when the balance or allowance of their owner
+// changes, and periodically sweeps the whole orderbook. Orders that are not backed by a sufficient
+// balance and allowance anymore are removed from the orderbook by the engine before they can be matched.
+type InvalidationService struct {
+	orderDao  interfaces.OrderDao
+	pairDao   interfaces.PairDao
+	validator interfaces.ValidatorService
+	broker    *rabbitmq.Connection
+	changes   chan balanceChange
+}
+
+// NewInvalidationService returns a new instance of InvalidationService
+func NewInvalidationService(
+	orderDao interfaces.OrderDao,
+	pairDao interfaces.PairDao,
+	validator interfaces.ValidatorService,
+	broker *rabbitmq.Connection,
+) *InvalidationService {
+	return &InvalidationService{
+		orderDao:  orderDao,
+		pairDao:   pairDao,
+		validator: validator,
+		broker:    broker,
+		changes:   make(chan balanceChange, balanceChangeQueueSize),
+	}
+}
+
+// Start processes the balance changes and sweeps the resting orders at regular intervals
+func (s *InvalidationService) Start() {
+	ticker := time.NewTicker(orderSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case c := <-s.changes:
+			err := s.CheckOrders(c.owner, c.token)
+			if err != nil {
+				logger.Error(err)
+			}
+		case <-ticker.C:
+			err := s.SweepOrders()
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// NotifyBalanceChange schedules the re-validation of the resting orders selling the given token.
+// If too many changes are waiting to be processed, the orders will be re-validated by the next sweep.
+func (s *InvalidationService) NotifyBalanceChange(owner, token common.Address) {
+	select {
+	case s.changes <- balanceChange{owner, token}:
+	default:
+		logger.Warningf("Balance change queue is full, skipping %v (%v)", owner.Hex(), token.Hex())
+	}
+}
+
+// CheckOrders re-validates the resting orders of the owner selling the given token
+func (s *InvalidationService) CheckOrders(owner, token common.Address) error {
+	orders, err := s.orderDao.GetCurrentByUserAddress(owner)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	selling := []*types.Order{}
+	for _, o := range orders {
+		if o.SellToken() == token {
+			selling = append(selling, o)
+		}
+	}
+
+	sort.Slice(selling, func(i, j int) bool {
+		return selling[i].CreatedAt.Before(selling[j].CreatedAt)
+	})
+
+	return s.checkOrders(owner, token, selling)
+}
+
+// SweepOrders re-validates all the resting orders
+func (s *InvalidationService) SweepOrders() error {
+	orders, err := s.orderDao.GetCurrentOrders()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	keys := []balanceChange{}
+	ordersByKey := map[balanceChange][]*types.Order{}
+	for _, o := range orders {
+		k := balanceChange{o.UserAddress, o.SellToken()}
+		if ordersByKey[k] == nil {
+			keys = append(keys, k)
+		}
+
+		ordersByKey[k] = append(ordersByKey[k], o)
+	}
+
+	for _, k := range keys {
+		err := s.checkOrders(k.owner, k.token, ordersByKey[k])
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+
+	return nil
+}
+
+// checkOrders requests the invalidation of the orders that are not covered by the balance and allowance
+// of the owner. The orders are expected to be sorted from the oldest to the most recent.
+func (s *InvalidationService) checkOrders(owner, token common.Address, orders []*types.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	pairs := map[string]*types.Pair{}
+	checked := []*types.Order{}
+	amounts := []*big.Int{}
+	exchanges := []common.Address{}
+	for _, o := range orders {
+		code, err := o.PairCode()
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		if pairs[code] == nil {
+			p, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+
+			if p == nil {
+				logger.Warningf("Unknown pair for order %v", o.Hash.Hex())
+				continue
+			}
+
+			pairs[code] = p
+		}
+
+		checked = append(checked, o)
+		amounts = append(amounts, o.RemainingSellAmount(pairs[code]))
+		exchanges = append(exchanges, pairs[code].Exchange())
+	}
+
+	var balance *big.Int
+	allowances := map[common.Address]*big.Int{}
+	for _, ex := range exchanges {
+		if allowances[ex] != nil {
+			continue
+		}
+
+		b, a, err := s.validator.GetBalanceAndAllowance(owner, token, ex)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		balance = b
+		allowances[ex] = a
+	}
+
+	invalid := underfundedExchangeOrders(checked, amounts, exchanges, allowances, balance)
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	logger.Infof("Invalidating %v orders of %v selling %v", len(invalid), owner.Hex(), token.Hex())
+
+	for _, m := range invalidMatches(invalid) {
+		err := s.broker.PublishInvalidateMakerOrdersMessage(*m)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidMatches groups the orders by pair into matches without taker order nor trades, which are
+// invalidated by the engine like the maker orders of failed matches
+func invalidMatches(orders []*types.Order) []*types.Matches {
+	matches := []*types.Matches{}
+	index := map[string]*types.Matches{}
+	for _, o := range orders {
+		code, err := o.PairCode()
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		if index[code] == nil {
+			index[code] = types.NewMatches([]*types.Order{}, nil, []*types.Trade{})
+			matches = append(matches, index[code])
+		}
+
+		index[code].MakerOrders = append(index[code].MakerOrders, o)
+	}
+
+	return matches
+}
+
+// underfundedExchangeOrders returns the orders that can not be covered by the allowance to their
+// exchange contract, and then the remaining orders that can not be covered by the balance shared
+// by all the exchange contracts. The orders are returned in the given order.
+func underfundedExchangeOrders(orders []*types.Order, amounts []*big.Int, exchanges []common.Address, allowances map[common.Address]*big.Int, balance *big.Int) []*types.Order {
+	underfunded := map[common.Hash]bool{}
+	for ex, allowance := range allowances {
+		exOrders := []*types.Order{}
+		exAmounts := []*big.Int{}
+		for i, o := range orders {
+			if exchanges[i] == ex {
+				exOrders = append(exOrders, o)
+				exAmounts = append(exAmounts, amounts[i])
+			}
+		}
+
+		for _, o := range underfundedOrders(exOrders, exAmounts, allowance) {
+			underfunded[o.Hash] = true
+		}
+	}
+
+	funded := []*types.Order{}
+	fundedAmounts := []*big.Int{}
+	for i, o := range orders {
+		if !underfunded[o.Hash] {
+			funded = append(funded, o)
+			fundedAmounts = append(fundedAmounts, amounts[i])
+		}
+	}
+
+	for _, o := range underfundedOrders(funded, fundedAmounts, balance) {
+		underfunded[o.Hash] = true
+	}
+
+	invalid := []*types.Order{}
+	for _, o := range orders {
+		if underfunded[o.Hash] {
+			invalid = append(invalid, o)
+		}
+	}
+
+	return invalid
+}
+
+// underfundedOrders returns the orders that can not be covered by the available amount. The orders
+// are considered from the oldest to the most recent so that the oldest orders keep their priority.
+func underfundedOrders(orders []*types.Order, amounts []*big.Int, available *big.Int) []*types.Order {
+	invalid := []*types.Order{}
+	locked := big.NewInt(0)
+
+	for i, o := range orders {
+		required := math.Add(locked, amounts[i])
+		if math.IsStrictlyGreaterThan(required, available) {
+			invalid = append(invalid, o)
+			continue
+		}
+
+		locked = required
+	}
+
+	return invalid
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnderfundedOrders(t *testing.T) {
+	o1 := &types.Order{Hash: common.HexToHash("0x1")}
+	o2 := &types.Order{Hash: common.HexToHash("0x2")}
+	o3 := &types.Order{Hash: common.HexToHash("0x3")}
+
+	orders := []*types.Order{o1, o2, o3}
+	amounts := []*big.Int{big.NewInt(500), big.NewInt(400), big.NewInt(100)}
+
+	invalid := underfundedOrders(orders, amounts, big.NewInt(1000))
+	assert.Equal(t, []*types.Order{}, invalid)
+
+	// the most recent orders are invalidated first
+	invalid = underfundedOrders(orders, amounts, big.NewInt(900))
+	assert.Equal(t, []*types.Order{o3}, invalid)
+
+	// smaller orders that still fit keep their place in the orderbook
+	invalid = underfundedOrders(orders, amounts, big.NewInt(600))
+	assert.Equal(t, []*types.Order{o2}, invalid)
+
+	invalid = underfundedOrders(orders, amounts, big.NewInt(0))
+	assert.Equal(t, []*types.Order{o1, o2, o3}, invalid)
+}
+
+func TestUnderfundedExchangeOrders(t *testing.T) {
+	ex1 := common.HexToAddress("0x10")
+	ex2 := common.HexToAddress("0x11")
+
+	o1 := &types.Order{Hash: common.HexToHash("0x1")}
+	o2 := &types.Order{Hash: common.HexToHash("0x2")}
+	o3 := &types.Order{Hash: common.HexToHash("0x3")}
+
+	orders := []*types.Order{o1, o2, o3}
+	amounts := []*big.Int{big.NewInt(500), big.NewInt(400), big.NewInt(100)}
+	exchanges := []common.Address{ex1, ex2, ex1}
+
+	allowances := map[common.Address]*big.Int{ex1: big.NewInt(1000), ex2: big.NewInt(1000)}
+	invalid := underfundedExchangeOrders(orders, amounts, exchanges, allowances, big.NewInt(1000))
+	assert.Equal(t, []*types.Order{}, invalid)
+
+	// each order is covered by the allowance to its own exchange contract
+	allowances = map[common.Address]*big.Int{ex1: big.NewInt(500), ex2: big.NewInt(1000)}
+	invalid = underfundedExchangeOrders(orders, amounts, exchanges, allowances, big.NewInt(1000))
+	assert.Equal(t, []*types.Order{o3}, invalid)
+
+	// the balance is shared by the orders of all the exchange contracts
+	allowances = map[common.Address]*big.Int{ex1: big.NewInt(1000), ex2: big.NewInt(1000)}
+	invalid = underfundedExchangeOrders(orders, amounts, exchanges, allowances, big.NewInt(600))
+	assert.Equal(t, []*types.Order{o2}, invalid)
+}
+
+func TestInvalidMatches(t *testing.T) {
+	zrx := common.HexToAddress("0x20")
+	dai := common.HexToAddress("0x21")
+	weth := common.HexToAddress("0x22")
+
+	o1 := &types.Order{Hash: common.HexToHash("0x1"), PairName: "ZRX/WETH", BaseToken: zrx, QuoteToken: weth}
+	o2 := &types.Order{Hash: common.HexToHash("0x2"), PairName: "DAI/WETH", BaseToken: dai, QuoteToken: weth}
+	o3 := &types.Order{Hash: common.HexToHash("0x3"), PairName: "ZRX/WETH", BaseToken: zrx, QuoteToken: weth}
+
+	// the orders are invalidated by the orderbook of their pair, as the maker orders of matches without trade
+	matches := invalidMatches([]*types.Order{o1, o2, o3})
+	assert.Equal(t, 2, len(matches))
+	assert.Equal(t, []*types.Order{o1, o3}, matches[0].MakerOrders)
+	assert.Equal(t, []*types.Order{o2}, matches[1].MakerOrders)
+	assert.Nil(t, matches[0].TakerOrder)
+	assert.Equal(t, 0, matches[0].Length())
+
+	code, err := matches[0].PairCode()
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "ZRX/WETH::"+zrx.Hex()+"::"+weth.Hex(), code)
+}
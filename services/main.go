@@ -11,6 +11,8 @@ var engineLogger = utils.EngineLogger
 
 var ErrPairExists = errors.New("Pairs already exists")
 var ErrPairNotFound = errors.New("Pair not found")
+var ErrPairInactive = errors.New("Pair is not active")
+var ErrOrderNotFound = errors.New("Order not found")
 var ErrBaseTokenNotFound = errors.New("BaseToken not found")
 var ErrQuoteTokenNotFound = errors.New("QuoteToken not found")
 var ErrQuoteTokenInvalid = errors.New("Quote Token Invalid (not a quote)")
@@ -18,3 +20,19 @@ var ErrTokenExists = errors.New("Token already exists")
 var ErrAccountNotFound = errors.New("Account not found")
 var ErrAccountExists = errors.New("Account already Exists")
 var ErrNoContractCode = errors.New("Contract not found at given address")
+var ErrTokenMetadataMismatch = errors.New("Token metadata does not match the token contract")
+var ErrTokenNoSupply = errors.New("Token has no supply")
+var ErrEngineTimeout = errors.New("Timed out waiting for the matching engine")
+var ErrEngineRejected = errors.New("Order rejected by the matching engine")
+var ErrTokenTransferFailed = errors.New("Token transfer simulation failed")
+var ErrAPIKeyNotFound = errors.New("API key not found")
+var ErrAPIKeyChallengeNotFound = errors.New("API key challenge not found or expired")
+var ErrAPIKeyAdminScope = errors.New("The admin scope is restricted to admin accounts")
+var ErrInvalidRequestTimestamp = errors.New("Request timestamp is missing or outside of the accepted window")
+var ErrInvalidRequestSignature = errors.New("Invalid request signature")
+var ErrTokenNotFound = errors.New("Token not found")
+var ErrTokenInactive = errors.New("Token is not active")
+var ErrFeeTierNotFound = errors.New("Fee tier not found")
+var ErrFeeOverrideNotFound = errors.New("Fee override not found")
+var ErrAccountBlocked = errors.New("Account is blocked")
+var ErrBlockRangeNotIndexed = errors.New("Block range is not indexed yet")
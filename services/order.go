@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/utils"
@@ -22,22 +24,31 @@ import (
 type OrderService struct {
 	orderDao      interfaces.OrderDao
 	pairDao       interfaces.PairDao
+	tokenDao      interfaces.TokenDao
 	accountDao    interfaces.AccountDao
 	tradeDao      interfaces.TradeDao
 	engine        interfaces.Engine
 	validator     interfaces.ValidatorService
+	feeService    interfaces.FeeService
+	compliance    interfaces.ComplianceService
 	broker        *rabbitmq.Connection
 	orderChannels map[string]chan *types.WebsocketEvent
+	// the channels of the requests waiting for the engine response of an order
+	engineWaiters map[common.Hash]chan *types.EngineResponse
+	waitersMutex  *sync.Mutex
 }
 
 // NewOrderService returns a new instance of orderservice
 func NewOrderService(
 	orderDao interfaces.OrderDao,
 	pairDao interfaces.PairDao,
+	tokenDao interfaces.TokenDao,
 	accountDao interfaces.AccountDao,
 	tradeDao interfaces.TradeDao,
 	engine interfaces.Engine,
 	validator interfaces.ValidatorService,
+	feeService interfaces.FeeService,
+	compliance interfaces.ComplianceService,
 	broker *rabbitmq.Connection,
 ) *OrderService {
 
@@ -46,12 +57,17 @@ func NewOrderService(
 	return &OrderService{
 		orderDao,
 		pairDao,
+		tokenDao,
 		accountDao,
 		tradeDao,
 		engine,
 		validator,
+		feeService,
+		compliance,
 		broker,
 		orderChannels,
+		make(map[common.Hash]chan *types.EngineResponse),
+		&sync.Mutex{},
 	}
 }
 
@@ -70,6 +86,11 @@ func (s *OrderService) GetByHash(hash common.Hash) (*types.Order, error) {
 	return s.orderDao.GetByHash(hash)
 }
 
+// GetByClientOrderID fetches the most recent order of a user with the given client order ID
+func (s *OrderService) GetByClientOrderID(addr common.Address, id string) (*types.Order, error) {
+	return s.orderDao.GetByClientOrderID(addr, id)
+}
+
 func (s *OrderService) GetByHashes(hashes []common.Hash) ([]*types.Order, error) {
 	return s.orderDao.GetByHashes(hashes)
 }
@@ -87,6 +108,17 @@ func (s *OrderService) GetHistoryByUserAddress(addr common.Address, limit ...int
 	return s.orderDao.GetHistoryByUserAddress(addr, limit...)
 }
 
+// GetPage fetches a page of the orders matching the query, most recent orders first
+func (s *OrderService) GetPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	return s.orderDao.GetPage(q)
+}
+
+// GetHistoryPage fetches a page of the orders matching the query which are not in
+// open/partial order status, most recent orders first
+func (s *OrderService) GetHistoryPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	return s.orderDao.GetHistoryPage(q)
+}
+
 // NewOrder validates if the passed order is valid or not based on user's available
 // funds and order data.
 // If valid: Order is inserted in DB with order status as new and order is publiched
@@ -97,6 +129,10 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 		return err
 	}
 
+	if s.compliance.IsBlocked(o.UserAddress) {
+		return ErrAccountBlocked
+	}
+
 	ok, err := o.VerifySignature()
 	if err != nil {
 		logger.Error(err)
@@ -116,10 +152,42 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 		return errors.New("Pair not found")
 	}
 
+	if !p.Active {
+		return ErrPairInactive
+	}
+
+	for _, a := range []common.Address{p.BaseTokenAddress, p.QuoteTokenAddress} {
+		t, err := s.tokenDao.GetByAddress(a)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if t != nil && !t.Active {
+			return ErrTokenInactive
+		}
+	}
+
+	if o.ExchangeAddress != p.Exchange() {
+		return errors.New("Order 'exchangeAddress' does not match the exchange contract of the pair")
+	}
+
 	if math.IsStrictlySmallerThan(o.QuoteAmount(p), p.MinQuoteAmount()) {
 		return errors.New("Order amount too low")
 	}
 
+	fees, err := s.feeService.GetAccountFees(o.UserAddress, p)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	err = o.ValidateFees(fees.MakeFee, fees.TakeFee)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
 	// Fill token and pair data
 	err = o.Process(p)
 	if err != nil {
@@ -133,9 +201,21 @@ func (s *OrderService) NewOrder(o *types.Order) error {
 		return err
 	}
 
+	err = s.orderDao.Reserve(o)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
 	err = s.broker.PublishNewOrderMessage(o)
 	if err != nil {
 		logger.Error(err)
+
+		// the reserved order is removed, which releases its nonce
+		if delErr := s.orderDao.Delete(o); delErr != nil {
+			logger.Error("Could not remove the unpublished order ", o.Hash.Hex(), ": ", delErr)
+		}
+
 		return err
 	}
 
@@ -152,6 +232,10 @@ func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 		return err
 	}
 
+	if o == nil {
+		return errors.New("No order with corresponding hash")
+	}
+
 	ok, err := oc.VerifySignature(o)
 	if err != nil {
 		logger.Error(err)
@@ -161,15 +245,101 @@ func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 		return errors.New("Invalid signature")
 	}
 
+	if s.compliance.IsBlocked(o.UserAddress) {
+		return ErrAccountBlocked
+	}
+
+	return s.cancelOrder(o)
+}
+
+// ForceCancelOrder sends the cancel message of an order to the matching engine without
+// requiring a cancel signed by the order owner. It returns the order as it was before the
+// cancel.
+func (s *OrderService) ForceCancelOrder(h common.Hash) (*types.Order, error) {
+	o, err := s.orderDao.GetByHash(h)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
 	if o == nil {
-		return errors.New("No order with corresponding hash")
+		return nil, ErrOrderNotFound
+	}
+
+	err = s.cancelOrder(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// BlockAccount prevents an account from placing and cancelling orders, and cancels its open
+// orders. The matching engine stops matching the account orders immediately, before the cancel
+// messages are processed. The cancelled orders are returned.
+func (s *OrderService) BlockAccount(a common.Address) ([]*types.Order, error) {
+	s.compliance.SetBlocked(a, true)
+	return s.cancelAccountOrders(a)
+}
+
+// UnblockAccount allows a blocked account to trade again, unless its address is on the deny list
+func (s *OrderService) UnblockAccount(a common.Address) {
+	s.compliance.SetBlocked(a, false)
+}
+
+// ReloadDenyList reloads the deny list and cancels the open orders of the addresses added to it.
+// The cancelled orders are returned. The orders of an address that could not be cancelled are
+// cancelled again on the next reload.
+func (s *OrderService) ReloadDenyList() ([]*types.Order, error) {
+	pending, err := s.compliance.LoadDenyList()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cancelled := []*types.Order{}
+	for _, a := range pending {
+		orders, err := s.cancelAccountOrders(a)
+		if err != nil {
+			return nil, err
+		}
+
+		s.compliance.SetOrdersCancelled(a)
+		cancelled = append(cancelled, orders...)
+	}
+
+	return cancelled, nil
+}
+
+// cancelAccountOrders publishes the cancel messages of the open orders of an account
+func (s *OrderService) cancelAccountOrders(a common.Address) ([]*types.Order, error) {
+	orders, err := s.orderDao.GetCurrentByUserAddress(a)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
 	}
 
+	cancelled := []*types.Order{}
+	for _, o := range orders {
+		err := s.cancelOrder(o)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		cancelled = append(cancelled, o)
+	}
+
+	return cancelled, nil
+}
+
+// cancelOrder publishes the cancel message of an open order to the matching engine
+func (s *OrderService) cancelOrder(o *types.Order) error {
 	if o.Status == "FILLED" || o.Status == "ERROR" || o.Status == "CANCEL" {
 		return fmt.Errorf("Cannot cancel order. Status is %v", o.Status)
 	}
 
-	err = s.broker.PublishCancelOrderMessage(o)
+	err := s.broker.PublishCancelOrderMessage(o)
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -178,6 +348,85 @@ func (s *OrderService) CancelOrder(oc *types.OrderCancel) error {
 	return nil
 }
 
+// NewOrderSync validates and sends a new order to the matching engine like NewOrder, and then waits
+// for the engine to accept or reject the order. ErrEngineTimeout is returned if the engine does
+// not respond within the given timeout.
+func (s *OrderService) NewOrderSync(o *types.Order, timeout time.Duration) (*types.EngineResponse, error) {
+	ch := s.addEngineWaiter(o.Hash)
+	defer s.removeEngineWaiter(o.Hash)
+
+	err := s.NewOrder(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return waitEngineResponse(ch, timeout)
+}
+
+// CancelOrderSync sends an order cancel to the matching engine like CancelOrder, and then waits
+// for the engine to cancel the order. ErrEngineTimeout is returned if the engine does not respond
+// within the given timeout.
+func (s *OrderService) CancelOrderSync(oc *types.OrderCancel, timeout time.Duration) (*types.EngineResponse, error) {
+	ch := s.addEngineWaiter(oc.OrderHash)
+	defer s.removeEngineWaiter(oc.OrderHash)
+
+	err := s.CancelOrder(oc)
+	if err != nil {
+		return nil, err
+	}
+
+	return waitEngineResponse(ch, timeout)
+}
+
+func waitEngineResponse(ch chan *types.EngineResponse, timeout time.Duration) (*types.EngineResponse, error) {
+	select {
+	case res := <-ch:
+		if res.Status == "ERROR" {
+			return res, ErrEngineRejected
+		}
+
+		return res, nil
+	case <-time.After(timeout):
+		return nil, ErrEngineTimeout
+	}
+}
+
+func (s *OrderService) addEngineWaiter(h common.Hash) chan *types.EngineResponse {
+	s.waitersMutex.Lock()
+	defer s.waitersMutex.Unlock()
+
+	ch := make(chan *types.EngineResponse, 1)
+	s.engineWaiters[h] = ch
+	return ch
+}
+
+func (s *OrderService) removeEngineWaiter(h common.Hash) {
+	s.waitersMutex.Lock()
+	defer s.waitersMutex.Unlock()
+
+	delete(s.engineWaiters, h)
+}
+
+// notifyEngineWaiter hands the engine response of an order over to the request waiting for it, if any
+func (s *OrderService) notifyEngineWaiter(res *types.EngineResponse) {
+	if res.Order == nil {
+		return
+	}
+
+	s.waitersMutex.Lock()
+	defer s.waitersMutex.Unlock()
+
+	ch := s.engineWaiters[res.Order.Hash]
+	if ch == nil {
+		return
+	}
+
+	select {
+	case ch <- res:
+	default:
+	}
+}
+
 func (s *OrderService) handleOrderCancelled(res *types.EngineResponse) {
 	ws.SendOrderMessage("ORDER_CANCELLED", res.Order.UserAddress, res.Order)
 	s.broadcastOrderBookUpdate([]*types.Order{res.Order})
@@ -188,6 +437,8 @@ func (s *OrderService) handleOrderCancelled(res *types.EngineResponse) {
 // HandleEngineResponse listens to messages incoming from the engine and handles websocket
 // responses and database updates accordingly
 func (s *OrderService) HandleEngineResponse(res *types.EngineResponse) error {
+	s.notifyEngineWaiter(res)
+
 	switch res.Status {
 	case "ERROR":
 		s.handleEngineError(res)
@@ -253,7 +504,7 @@ func (s *OrderService) handleOrdersInvalidated(res *types.EngineResponse) error
 // handleEngineError returns an websocket error message to the client
 func (s *OrderService) handleEngineError(res *types.EngineResponse) {
 	o := res.Order
-	ws.SendOrderMessage("ERROR", o.UserAddress, nil)
+	ws.SendOrderErrorMessage(o.UserAddress, ErrEngineRejected, o)
 }
 
 // handleEngineOrderAdded returns a websocket message informing the client that his order has been added
@@ -303,14 +554,14 @@ func (s *OrderService) handleEngineOrderMatched(res *types.EngineResponse) {
 		err := s.tradeDao.Create(validMatches.Trades...)
 		if err != nil {
 			logger.Error(err)
-			ws.SendOrderMessage("ERROR", taker, err)
+			ws.SendOrderErrorMessage(taker, err, o)
 			return
 		}
 
 		err = s.broker.PublishTrades(&validMatches)
 		if err != nil {
 			logger.Error(err)
-			ws.SendOrderMessage("ERROR", taker, err)
+			ws.SendOrderErrorMessage(taker, err, o)
 			return
 		}
 
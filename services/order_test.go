@@ -2,14 +2,18 @@ package services
 
 import (
 	"math/big"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/Proofsuite/amp-matching-engine/rabbitmq"
 
 	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/Proofsuite/amp-matching-engine/utils/testutils"
 	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCancelTrades(t *testing.T) {
@@ -24,10 +28,13 @@ func TestCancelTrades(t *testing.T) {
 	orderService := NewOrderService(
 		orderDao,
 		pairDao,
+		new(mocks.TokenDao),
 		accountDao,
 		tradeDao,
 		engine,
 		ethereum,
+		new(mocks.FeeService),
+		new(mocks.ComplianceService),
 		amqp,
 	)
 
@@ -52,3 +59,75 @@ func TestCancelTrades(t *testing.T) {
 	orderDao.AssertCalled(t, "GetByHashes", hashes)
 	engine.AssertCalled(t, "CancelTrades", orders, amounts)
 }
+
+func TestEngineWaiter(t *testing.T) {
+	s := &OrderService{
+		engineWaiters: make(map[common.Hash]chan *types.EngineResponse),
+		waitersMutex:  &sync.Mutex{},
+	}
+
+	o := &types.Order{Hash: common.HexToHash("0x1")}
+	ch := s.addEngineWaiter(o.Hash)
+
+	// responses of other orders are not handed over
+	s.notifyEngineWaiter(&types.EngineResponse{Status: "ORDER_ADDED", Order: &types.Order{Hash: common.HexToHash("0x2")}})
+	s.notifyEngineWaiter(&types.EngineResponse{Status: "ORDER_ADDED", Order: o})
+
+	res, err := waitEngineResponse(ch, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "ORDER_ADDED", res.Status)
+
+	s.notifyEngineWaiter(&types.EngineResponse{Status: "ERROR", Order: o})
+	_, err = waitEngineResponse(ch, time.Second)
+	assert.Equal(t, ErrEngineRejected, err)
+
+	s.removeEngineWaiter(o.Hash)
+	s.notifyEngineWaiter(&types.EngineResponse{Status: "ORDER_ADDED", Order: o})
+	_, err = waitEngineResponse(ch, 10*time.Millisecond)
+	assert.Equal(t, ErrEngineTimeout, err)
+}
+
+func TestNewOrderEIP712LegacyExchange(t *testing.T) {
+	exchange := common.HexToAddress("0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f")
+	legacy := common.HexToAddress("0x7a9f3cb3ee3f8a93df8d3d8201c0fa722dae65cc")
+
+	app.Config.ChainID = 1
+	app.Config.Ethereum = map[string]string{"exchange_address": exchange.Hex()}
+	app.Config.ExchangeAddresses = []string{legacy.Hex()}
+	app.Config.EIP712ExchangeAddresses = []string{exchange.Hex()}
+	defer func() {
+		app.Config.ChainID = 0
+		app.Config.ExchangeAddresses = nil
+		app.Config.EIP712ExchangeAddresses = nil
+	}()
+
+	pairDao := new(mocks.PairDao)
+	orderService := &OrderService{pairDao: pairDao}
+
+	// the settlement transactions do not carry the signature scheme: an order signed as typed data
+	// on a pair bound to an exchange contract verifying legacy signatures could never be settled
+	w := testutils.GetTestWallet1()
+	o := &types.Order{
+		ExchangeAddress:  legacy,
+		ChainID:          1,
+		UserAddress:      w.Address,
+		BaseToken:        testutils.GetTestAddress1(),
+		QuoteToken:       testutils.GetTestAddress2(),
+		Amount:           big.NewInt(1e18),
+		PricePoint:       big.NewInt(100),
+		Side:             "BUY",
+		Nonce:            big.NewInt(1),
+		MakeFee:          big.NewInt(1),
+		TakeFee:          big.NewInt(1),
+		SignatureVersion: types.SignatureVersionEIP712,
+	}
+
+	err := o.Sign(w)
+	if err != nil {
+		t.Fatalf("Could not sign order: %v", err)
+	}
+
+	err = orderService.NewOrder(o)
+	assert.EqualError(t, err, "Order 'signatureVersion' is not supported by the exchange contract")
+	pairDao.AssertNotCalled(t, "GetByTokenAddress", o.BaseToken, o.QuoteToken)
+}
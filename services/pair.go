@@ -35,6 +35,9 @@ func NewPairService(
 	return &PairService{pairDao, tokenDao, tradeDao, orderDao, eng, provider}
 }
 
+// CreatePairs creates the pairs of a base token against all the quote tokens. The base token is
+// registered first if needed, with its metadata read from the token contract. The pairs of a token
+// on which a non-standard behaviour was detected are created inactive, until an admin activates them.
 func (s *PairService) CreatePairs(addr common.Address) ([]*types.Pair, error) {
 	quotes, err := s.tokenDao.GetQuoteTokens()
 	if err != nil {
@@ -49,25 +52,21 @@ func (s *PairService) CreatePairs(addr common.Address) ([]*types.Pair, error) {
 	}
 
 	if base == nil {
-		symbol, err := s.provider.Symbol(addr)
-		if err != nil {
-			logger.Error(err)
-			return nil, ErrNoContractCode
+		base = &types.Token{
+			Address: addr,
+			Active:  true,
+			Listed:  false,
+			Quote:   false,
 		}
 
-		decimals, err := s.provider.Decimals(addr)
+		err = inspectToken(s.provider, base)
 		if err != nil {
 			logger.Error(err)
-			return nil, ErrNoContractCode
+			return nil, err
 		}
 
-		base = &types.Token{
-			Symbol:   symbol,
-			Address:  addr,
-			Decimals: int(decimals),
-			Active:   true,
-			Listed:   false,
-			Quote:    false,
+		if base.Flagged() {
+			logger.Warningf("Token %v registered with flags %v", base.Address.Hex(), base.Flags)
 		}
 
 		err = s.tokenDao.Create(base)
@@ -93,7 +92,7 @@ func (s *PairService) CreatePairs(addr common.Address) ([]*types.Pair, error) {
 				BaseTokenSymbol:    base.Symbol,
 				BaseTokenAddress:   base.Address,
 				BaseTokenDecimals:  base.Decimals,
-				Active:             true,
+				Active:             !base.Flagged(),
 				Listed:             false,
 				MakeFee:            q.MakeFee,
 				TakeFee:            q.TakeFee,
@@ -146,25 +145,21 @@ func (s *PairService) Create(pair *types.Pair) error {
 	}
 
 	if base == nil {
-		symbol, err := s.provider.Symbol(pair.BaseTokenAddress)
-		if err != nil {
-			logger.Error(err)
-			return ErrNoContractCode
+		token := types.Token{
+			Address: pair.BaseTokenAddress,
+			Active:  true,
+			Listed:  false,
+			Quote:   false,
 		}
 
-		decimals, err := s.provider.Decimals(pair.BaseTokenAddress)
+		err = inspectToken(s.provider, &token)
 		if err != nil {
 			logger.Error(err)
-			return ErrNoContractCode
+			return err
 		}
 
-		token := types.Token{
-			Symbol:   symbol,
-			Address:  pair.BaseTokenAddress,
-			Decimals: int(decimals),
-			Active:   true,
-			Listed:   false,
-			Quote:    false,
+		if token.Flagged() {
+			logger.Warningf("Token %v registered with flags %v", token.Address.Hex(), token.Flags)
 		}
 
 		err = s.tokenDao.Create(&token)
@@ -179,7 +174,7 @@ func (s *PairService) Create(pair *types.Pair) error {
 		pair.BaseTokenSymbol = token.Symbol
 		pair.BaseTokenAddress = token.Address
 		pair.BaseTokenDecimals = token.Decimals
-		pair.Active = true
+		pair.Active = !token.Flagged()
 		pair.Listed = false
 		pair.MakeFee = quote.MakeFee
 		pair.TakeFee = quote.TakeFee
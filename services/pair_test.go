@@ -0,0 +1,43 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePairsFlaggedToken(t *testing.T) {
+	pairDao := new(mocks.PairDao)
+	tokenDao := new(mocks.TokenDao)
+
+	token := common.HexToAddress("0x1")
+	holder := common.HexToAddress("0x2")
+	quote := types.Token{Address: common.HexToAddress("0x8"), Symbol: "WETH", Decimals: 18, Quote: true}
+
+	logs := []eth.Log{transferLog(common.HexToHash("0x10"), common.HexToAddress("0x3"), holder, 1000)}
+	provider := setupTokenProvider(token, logs)
+	provider.On("CallTransfer", token, holder, transferProbeAddress, big.NewInt(1000)).Return([]byte{}, nil)
+
+	tokenDao.On("GetQuoteTokens").Return([]types.Token{quote}, nil)
+	tokenDao.On("GetByAddress", token).Return(nil, nil)
+	tokenDao.On("Create", mock.Anything).Return(nil)
+	pairDao.On("GetByTokenAddress", token, quote.Address).Return(nil, nil)
+	pairDao.On("Create", mock.Anything).Return(nil)
+
+	s := NewPairService(pairDao, tokenDao, new(mocks.TradeDao), new(mocks.OrderDao), new(mocks.Engine), provider)
+	pairs, err := s.CreatePairs(token)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// the token transfer function does not return a value
+	assert.Equal(t, 1, len(pairs))
+	assert.False(t, pairs[0].Active)
+	assert.False(t, pairs[0].Listed)
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+)
+
+// stuckPendingTimeout is the duration after which a pending trade is considered stuck
+const stuckPendingTimeout = 10 * time.Minute
+
+// ReconciliationService compares the trades collection with the events of the exchange smart
+// contracts stored by the indexer
+type ReconciliationService struct {
+	tradeDao  interfaces.TradeDao
+	eventDao  interfaces.EventDao
+	exchanges []interfaces.Exchange
+	provider  interfaces.EthereumProvider
+}
+
+// NewReconciliationService returns a new instance of ReconciliationService comparing the trades
+// with the indexed events of the given exchange contracts
+func NewReconciliationService(
+	tradeDao interfaces.TradeDao,
+	eventDao interfaces.EventDao,
+	exchanges []interfaces.Exchange,
+	provider interfaces.EthereumProvider,
+) *ReconciliationService {
+	return &ReconciliationService{tradeDao, eventDao, exchanges, provider}
+}
+
+// Reconcile compares the trade events emitted between the start and end blocks (included) with the
+// trades sent in the transactions of this block range and the trades marked as successful while
+// these blocks were mined. The trades settled in a batch are matched with the orders of the
+// LogBatchTrades events, which do not carry the settled amounts. Pending trades that were not
+// updated for a long time are reported as stuck regardless of the block range. The events of the
+// block range should be indexed for every exchange contract.
+func (s *ReconciliationService) Reconcile(start, end uint64) (*types.ReconciliationReport, error) {
+	for _, ex := range s.exchanges {
+		last, err := s.eventDao.GetLastProcessedBlock(ex.GetAddress())
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		if end > last {
+			return nil, ErrBlockRangeNotIndexed
+		}
+	}
+
+	report := types.NewReconciliationReport(start, end)
+
+	tradeEvents, err := s.eventDao.GetByBlockRange("LogTrade", start, end)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	batchTradeEvents, err := s.eventDao.GetByBlockRange("LogBatchTrades", start, end)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	errorEvents, err := s.eventDao.GetByBlockRange("LogError", start, end)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	txHashes := []common.Hash{}
+	seen := make(map[common.Hash]bool)
+	addTxHash := func(h common.Hash) {
+		if !seen[h] {
+			seen[h] = true
+			txHashes = append(txHashes, h)
+		}
+	}
+
+	for _, ev := range tradeEvents {
+		addTxHash(ev.TxHash)
+	}
+
+	for _, ev := range batchTradeEvents {
+		addTxHash(ev.TxHash)
+	}
+
+	for _, ev := range errorEvents {
+		addTxHash(ev.TxHash)
+	}
+
+	trades := []*types.Trade{}
+	if len(txHashes) > 0 {
+		trades, err = s.tradeDao.GetByTxHashes(txHashes)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	settled, err := s.getSettledTrades(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tradesByHash := make(map[common.Hash]*types.Trade)
+	for _, t := range trades {
+		tradesByHash[t.Hash] = t
+	}
+
+	// the settled trades whose transaction did not emit any event of the block range
+	unconfirmed := []*types.Trade{}
+	for _, t := range settled {
+		if tradesByHash[t.Hash] == nil {
+			tradesByHash[t.Hash] = t
+			unconfirmed = append(unconfirmed, t)
+		}
+	}
+
+	report.TradeEvents = len(tradeEvents)
+	report.Trades = len(tradesByHash)
+
+	matched := make(map[common.Hash]bool)
+	for _, ev := range tradeEvents {
+		t := tradesByHash[ev.TradeHash]
+		if t == nil {
+			t = findEventTrade(trades, ev.TxHash, ev.OrderHash)
+		}
+
+		if t == nil {
+			report.AddMismatch("ORPHAN_EVENT", ev.TradeHash, ev.TxHash, ev.BlockNumber, "Trade event does not correspond to any trade")
+			continue
+		}
+
+		matched[t.Hash] = true
+		checkSettledStatus(report, t, ev)
+
+		amount := eventBaseAmount(ev, t)
+		if amount != nil && t.Amount != nil && amount.Cmp(t.Amount) != 0 {
+			report.AddMismatch("AMOUNT_MISMATCH", t.Hash, ev.TxHash, ev.BlockNumber, fmt.Sprintf("Trade amount is %v but %v was settled", t.Amount, amount))
+		}
+	}
+
+	// each maker and taker order pair of a batch event is a settled trade
+	for _, ev := range batchTradeEvents {
+		for i := 0; i < len(ev.MakerOrderHashes) && i < len(ev.TakerOrderHashes); i++ {
+			report.TradeEvents++
+
+			t := findBatchEventTrade(trades, ev.TxHash, ev.MakerOrderHashes[i], ev.TakerOrderHashes[i])
+			if t == nil {
+				message := fmt.Sprintf("Batch trade event of orders %v and %v does not correspond to any trade", ev.MakerOrderHashes[i].Hex(), ev.TakerOrderHashes[i].Hex())
+				report.AddMismatch("ORPHAN_EVENT", common.Hash{}, ev.TxHash, ev.BlockNumber, message)
+				continue
+			}
+
+			matched[t.Hash] = true
+			checkSettledStatus(report, t, ev)
+		}
+	}
+
+	for _, t := range trades {
+		if !matched[t.Hash] && t.Status == "SUCCESS" {
+			report.AddMismatch("ORPHAN_TRADE", t.Hash, t.TxHash, 0, "Trade is successful but does not correspond to any trade event")
+		}
+	}
+
+	unmatched := []*types.Trade{}
+	for _, t := range unconfirmed {
+		if !matched[t.Hash] {
+			unmatched = append(unmatched, t)
+		}
+	}
+
+	err = s.checkReceipts(report, unmatched, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.tradeDao.GetPendingTradesBefore(time.Now().Add(-stuckPendingTimeout))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for _, t := range pending {
+		if !matched[t.Hash] {
+			report.AddMismatch("STUCK_PENDING", t.Hash, t.TxHash, 0, fmt.Sprintf("Trade is pending since %v", t.UpdatedAt))
+		}
+	}
+
+	return report, nil
+}
+
+// getSettledTrades returns the trades marked as successful between the timestamps of the start
+// and end blocks. Trades are marked as successful after their transaction is mined, the trades
+// of the end block can be updated a bit later than its timestamp.
+func (s *ReconciliationService) getSettledTrades(start, end uint64) ([]*types.Trade, error) {
+	from, err := s.provider.GetBlockTime(start)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	to, err := s.provider.GetBlockTime(end)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	trades, err := s.tradeDao.GetSettledTradesBetween(from, to.Add(stuckPendingTimeout))
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return trades, nil
+}
+
+// checkReceipts checks the transaction receipts of successful trades that do not correspond to any
+// trade event. The trades mined outside of the block range are left to the reconciliation of
+// their own block range.
+func (s *ReconciliationService) checkReceipts(report *types.ReconciliationReport, trades []*types.Trade, start, end uint64) error {
+	for _, t := range trades {
+		receipt, err := s.provider.GetTransactionReceipt(t.TxHash)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if receipt == nil {
+			report.AddMismatch("ORPHAN_TRADE", t.Hash, t.TxHash, 0, "Trade is successful but its transaction was not mined")
+			continue
+		}
+
+		block := receipt.BlockNumber.Uint64()
+		if block < start || block > end {
+			continue
+		}
+
+		if receipt.Status == eth.ReceiptStatusFailed {
+			report.AddMismatch("STATUS_MISMATCH", t.Hash, t.TxHash, block, "Trade is successful but its transaction failed")
+			continue
+		}
+
+		report.AddMismatch("ORPHAN_TRADE", t.Hash, t.TxHash, block, "Trade is successful but does not correspond to any trade event")
+	}
+
+	return nil
+}
+
+// checkSettledStatus reports the trade settled by the event if it is not marked as successful
+func checkSettledStatus(report *types.ReconciliationReport, t *types.Trade, ev *types.ContractEvent) {
+	if t.Status == "PENDING" {
+		report.AddMismatch("STUCK_PENDING", t.Hash, ev.TxHash, ev.BlockNumber, "Trade was settled but is still pending")
+	} else if t.Status != "SUCCESS" {
+		report.AddMismatch("STATUS_MISMATCH", t.Hash, ev.TxHash, ev.BlockNumber, fmt.Sprintf("Trade was settled but has status %v", t.Status))
+	}
+}
+
+// findEventTrade returns the trade sent in the given transaction that involves the given order
+func findEventTrade(trades []*types.Trade, txHash, orderHash common.Hash) *types.Trade {
+	for _, t := range trades {
+		if t.TxHash != txHash {
+			continue
+		}
+
+		if t.MakerOrderHash == orderHash || t.TakerOrderHash == orderHash {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// findBatchEventTrade returns the trade sent in the given transaction between the given maker and taker orders
+func findBatchEventTrade(trades []*types.Trade, txHash, makerOrderHash, takerOrderHash common.Hash) *types.Trade {
+	for _, t := range trades {
+		if t.TxHash == txHash && t.MakerOrderHash == makerOrderHash && t.TakerOrderHash == takerOrderHash {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// eventBaseAmount returns the base token amount settled by the event
+func eventBaseAmount(ev *types.ContractEvent, t *types.Trade) *big.Int {
+	if ev.TokenSell == t.BaseToken {
+		return ev.FilledAmountSell
+	}
+
+	if ev.TokenBuy == t.BaseToken {
+		return ev.FilledAmountBuy
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/ethereum"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReconcile(t *testing.T) {
+	tradeDao := new(mocks.TradeDao)
+	eventDao := new(mocks.EventDao)
+	exchange := new(mocks.Exchange)
+	provider := new(mocks.EthereumProvider)
+
+	txHash := common.HexToHash("0x1")
+	t1 := testutils.GetTestTrade1()
+	t1.TxHash = txHash
+	t1.Status = "SUCCESS"
+
+	t2 := testutils.GetTestTrade2()
+	t2.TxHash = txHash
+	t2.Status = "SUCCESS"
+
+	settled := &types.ContractEvent{
+		Type:             "LogTrade",
+		TxHash:           txHash,
+		BlockNumber:      2,
+		TokenSell:        t1.BaseToken,
+		TokenBuy:         t1.QuoteToken,
+		FilledAmountSell: big.NewInt(50),
+		FilledAmountBuy:  big.NewInt(5000),
+		TradeHash:        t1.Hash,
+	}
+
+	orphan := &types.ContractEvent{
+		Type:        "LogTrade",
+		TxHash:      txHash,
+		BlockNumber: 2,
+		TradeHash:   common.HexToHash("0x2"),
+	}
+
+	exchange.On("GetAddress").Return(common.HexToAddress("0x10"))
+	eventDao.On("GetLastProcessedBlock", common.HexToAddress("0x10")).Return(uint64(3), nil)
+	eventDao.On("GetByBlockRange", "LogTrade", uint64(1), uint64(3)).Return([]*types.ContractEvent{settled, orphan}, nil)
+	eventDao.On("GetByBlockRange", "LogBatchTrades", uint64(1), uint64(3)).Return([]*types.ContractEvent{}, nil)
+	eventDao.On("GetByBlockRange", "LogError", uint64(1), uint64(3)).Return([]*types.ContractEvent{}, nil)
+	tradeDao.On("GetByTxHashes", []common.Hash{txHash}).Return([]*types.Trade{&t1, &t2}, nil)
+	tradeDao.On("GetPendingTradesBefore", mock.Anything).Return([]*types.Trade{}, nil)
+
+	// trades marked as successful in the block range without any trade event in their transaction
+	failed := &types.Trade{Hash: common.HexToHash("0x3"), TxHash: common.HexToHash("0x4"), Status: "SUCCESS"}
+	later := &types.Trade{Hash: common.HexToHash("0x5"), TxHash: common.HexToHash("0x6"), Status: "SUCCESS"}
+
+	now := time.Now()
+	provider.On("GetBlockTime", uint64(1)).Return(now.Add(-time.Minute), nil)
+	provider.On("GetBlockTime", uint64(3)).Return(now, nil)
+	tradeDao.On("GetSettledTradesBetween", now.Add(-time.Minute), now.Add(stuckPendingTimeout)).Return([]*types.Trade{&t1, failed, later}, nil)
+	provider.On("GetTransactionReceipt", failed.TxHash).Return(&eth.Receipt{Status: eth.ReceiptStatusFailed, BlockNumber: big.NewInt(3)}, nil)
+	provider.On("GetTransactionReceipt", later.TxHash).Return(&eth.Receipt{Status: eth.ReceiptStatusSuccessful, BlockNumber: big.NewInt(4)}, nil)
+
+	reconciliationService := NewReconciliationService(tradeDao, eventDao, []interfaces.Exchange{exchange}, provider)
+	report, err := reconciliationService.Reconcile(1, 3)
+	if err != nil {
+		t.Errorf("Could not reconcile trades: %v", err)
+	}
+
+	mismatches := map[string]common.Hash{}
+	for _, m := range report.Mismatches {
+		mismatches[m.Type] = m.TradeHash
+	}
+
+	assert.Equal(t, 4, len(report.Mismatches))
+	assert.Equal(t, t1.Hash, mismatches["AMOUNT_MISMATCH"])
+	assert.Equal(t, common.HexToHash("0x2"), mismatches["ORPHAN_EVENT"])
+	assert.Equal(t, t2.Hash, mismatches["ORPHAN_TRADE"])
+	assert.Equal(t, failed.Hash, mismatches["STATUS_MISMATCH"])
+	provider.AssertNotCalled(t, "GetTransactionReceipt", t1.TxHash)
+}
+
+func TestReconcileBatchTrades(t *testing.T) {
+	tradeDao := new(mocks.TradeDao)
+	eventDao := new(mocks.EventDao)
+	exchange := new(mocks.Exchange)
+	provider := new(mocks.EthereumProvider)
+
+	txHash := common.HexToHash("0x1")
+	t1 := testutils.GetTestTrade1()
+	t1.TxHash = txHash
+	t1.Status = "SUCCESS"
+
+	t2 := testutils.GetTestTrade2()
+	t2.TxHash = txHash
+	t2.Status = "PENDING"
+
+	// the batch event settles both trades and a pair of orders that does not correspond to any trade
+	batch := &types.ContractEvent{
+		Type:             "LogBatchTrades",
+		TxHash:           txHash,
+		BlockNumber:      2,
+		MakerOrderHashes: []common.Hash{t1.MakerOrderHash, t2.MakerOrderHash, common.HexToHash("0x2")},
+		TakerOrderHashes: []common.Hash{t1.TakerOrderHash, t2.TakerOrderHash, common.HexToHash("0x3")},
+	}
+
+	exchange.On("GetAddress").Return(common.HexToAddress("0x10"))
+	eventDao.On("GetLastProcessedBlock", common.HexToAddress("0x10")).Return(uint64(5), nil)
+	eventDao.On("GetByBlockRange", "LogTrade", uint64(1), uint64(3)).Return([]*types.ContractEvent{}, nil)
+	eventDao.On("GetByBlockRange", "LogBatchTrades", uint64(1), uint64(3)).Return([]*types.ContractEvent{batch}, nil)
+	eventDao.On("GetByBlockRange", "LogError", uint64(1), uint64(3)).Return([]*types.ContractEvent{}, nil)
+	tradeDao.On("GetByTxHashes", []common.Hash{txHash}).Return([]*types.Trade{&t1, &t2}, nil)
+	tradeDao.On("GetPendingTradesBefore", mock.Anything).Return([]*types.Trade{}, nil)
+	tradeDao.On("GetSettledTradesBetween", mock.Anything, mock.Anything).Return([]*types.Trade{&t1}, nil)
+	provider.On("GetBlockTime", mock.Anything).Return(time.Now(), nil)
+
+	reconciliationService := NewReconciliationService(tradeDao, eventDao, []interfaces.Exchange{exchange}, provider)
+	report, err := reconciliationService.Reconcile(1, 3)
+	if err != nil {
+		t.Errorf("Could not reconcile trades: %v", err)
+	}
+
+	mismatches := map[string]*types.ReconciliationMismatch{}
+	for _, m := range report.Mismatches {
+		mismatches[m.Type] = m
+	}
+
+	// the trades settled in the batch are not reported as orphan trades
+	assert.Equal(t, 3, report.TradeEvents)
+	assert.Equal(t, 2, len(report.Mismatches))
+	assert.Equal(t, t2.Hash, mismatches["STUCK_PENDING"].TradeHash)
+	assert.Equal(t, txHash, mismatches["ORPHAN_EVENT"].TxHash)
+	assert.Nil(t, mismatches["ORPHAN_TRADE"])
+	provider.AssertNotCalled(t, "GetTransactionReceipt", mock.Anything)
+}
+
+func TestReconcileNotIndexed(t *testing.T) {
+	eventDao := new(mocks.EventDao)
+	exchange := new(mocks.Exchange)
+
+	exchange.On("GetAddress").Return(common.HexToAddress("0x10"))
+	eventDao.On("GetLastProcessedBlock", common.HexToAddress("0x10")).Return(uint64(2), nil)
+
+	reconciliationService := NewReconciliationService(new(mocks.TradeDao), eventDao, []interfaces.Exchange{exchange}, new(mocks.EthereumProvider))
+	_, err := reconciliationService.Reconcile(1, 3)
+	assert.Equal(t, ErrBlockRangeNotIndexed, err)
+	eventDao.AssertNotCalled(t, "GetByBlockRange", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReconcileSimulatedChain(t *testing.T) {
+	wallet := testutils.GetTestWallet1()
+
+	walletDao := new(mocks.WalletDao)
+	walletDao.On("GetDefaultAdminWallet").Return(wallet, nil)
+
+	walletService := NewWalletService(walletDao)
+	txService := NewTxService(walletService, wallet)
+
+	provider := ethereum.NewSimulatedEthereumProvider([]common.Address{wallet.Address})
+	client := provider.Client.(*ethereum.SimulatedClient)
+	deployer := testutils.NewDeployer(walletService, txService, client)
+
+	exchange, _, _, err := deployer.DeployExchange(testutils.GetTestAddress1(), testutils.GetTestAddress2())
+	if err != nil {
+		t.Errorf("Could not deploy exchange: %v", err)
+	}
+
+	client.Commit()
+
+	tradeDao := new(mocks.TradeDao)
+	tradeDao.On("GetPendingTradesBefore", mock.Anything).Return([]*types.Trade{}, nil)
+	tradeDao.On("GetSettledTradesBetween", mock.Anything, mock.Anything).Return([]*types.Trade{}, nil)
+
+	// the events of the simulated chain are indexed before the trades are reconciled
+	eventDao := new(mocks.EventDao)
+	eventDao.On("GetLastProcessedBlock", exchange.GetAddress()).Return(uint64(0), nil).Once()
+	eventDao.On("RemoveReorged", exchange.GetAddress(), uint64(0), uint64(1), []common.Hash{}).Return(nil)
+	eventDao.On("SetLastProcessedBlock", exchange.GetAddress(), uint64(1)).Return(nil)
+	eventDao.On("GetLastProcessedBlock", exchange.GetAddress()).Return(uint64(1), nil)
+	eventDao.On("GetByBlockRange", mock.Anything, uint64(0), uint64(1)).Return([]*types.ContractEvent{}, nil)
+
+	err = NewIndexerService(eventDao, []interfaces.Exchange{exchange}, provider).Backfill()
+	if err != nil {
+		t.Errorf("Could not index the exchange events: %v", err)
+	}
+
+	reconciliationService := NewReconciliationService(tradeDao, eventDao, []interfaces.Exchange{exchange}, provider)
+	report, err := reconciliationService.Reconcile(0, 1)
+	if err != nil {
+		t.Errorf("Could not reconcile trades: %v", err)
+	}
+
+	assert.True(t, report.Reconciled())
+	assert.Equal(t, 0, report.TradeEvents)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StatementService computes the fee and PnL statements of an account from its trade history
+type StatementService struct {
+	exportService interfaces.ExportService
+}
+
+// NewStatementService returns a new instance of StatementService
+func NewStatementService(exportService interfaces.ExportService) *StatementService {
+	return &StatementService{exportService}
+}
+
+// GetStatement aggregates the successful trades of an account by pair and period between
+// from (included) and to (excluded). Zero times leave the range open. The whole trade
+// history is read to compute the cost basis of the positions, including the trades
+// preceding the statement range.
+func (s *StatementService) GetStatement(a common.Address, period string, from, to time.Time) (*types.AccountStatement, error) {
+	err := types.ValidateStatementPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := map[string]*types.Position{}
+	entries := map[string]*types.StatementEntry{}
+	res := []*types.StatementEntry{}
+
+	err = s.exportService.ExportTrades(a, func(t *types.TradeExport) error {
+		if t.Status != "SUCCESS" {
+			return nil
+		}
+
+		// the side of a trade whose order cannot be found is unknown
+		if t.Side == "" {
+			logger.Warningf("Skipping trade %v of an unknown order from the statement", t.Hash.Hex())
+			return nil
+		}
+
+		p := positions[t.PairName]
+		if p == nil {
+			p = &types.Position{}
+			positions[t.PairName] = p
+		}
+
+		pnl := p.Apply(t)
+
+		if (!from.IsZero() && t.CreatedAt.Before(from)) || (!to.IsZero() && !t.CreatedAt.Before(to)) {
+			return nil
+		}
+
+		start := types.PeriodStart(t.CreatedAt, period)
+		key := t.PairName + "::" + start.Format(time.RFC3339)
+
+		e := entries[key]
+		if e == nil {
+			e = &types.StatementEntry{PairName: t.PairName, PeriodStart: start}
+			entries[key] = e
+			res = append(res, e)
+		}
+
+		e.AddTrade(t, pnl)
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].PeriodStart.Equal(res[j].PeriodStart) {
+			return res[i].PeriodStart.Before(res[j].PeriodStart)
+		}
+
+		return res[i].PairName < res[j].PairName
+	})
+
+	statement := &types.AccountStatement{
+		Address: a,
+		Period:  period,
+		Entries: res,
+	}
+
+	if !from.IsZero() {
+		statement.From = &from
+	}
+
+	if !to.IsZero() {
+		statement.To = &to
+	}
+
+	return statement, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStatement(t *testing.T) {
+	exportService := new(mocks.ExportService)
+	user := common.HexToAddress("0x1")
+
+	july := time.Date(2018, 7, 10, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2018, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	trades := []*types.TradeExport{
+		{CreatedAt: july, PairName: "ZRX/WETH", Role: types.TradeRoleTaker, Side: "BUY", Amount: 10, Price: 1, Fee: 0.1, Status: "SUCCESS"},
+		{CreatedAt: august, PairName: "ZRX/WETH", Role: types.TradeRoleMaker, Side: "SELL", Amount: 5, Price: 2, Fee: 0.05, Status: "SUCCESS"},
+		{CreatedAt: august, PairName: "ZRX/WETH", Role: types.TradeRoleMaker, Side: "SELL", Amount: 5, Price: 2, Status: "PENDING"},
+		{CreatedAt: august, PairName: "AE/WETH", Role: types.TradeRoleTaker, Side: "BUY", Amount: 2, Price: 3, Fee: 0.1, Status: "SUCCESS"},
+	}
+
+	exportService.On("ExportTrades", user, mock.Anything).Return(func(a common.Address, fn func(*types.TradeExport) error) error {
+		for _, tr := range trades {
+			err := fn(tr)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	statementService := NewStatementService(exportService)
+
+	from := time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC)
+	res, err := statementService.GetStatement(user, types.StatementPeriodMonth, from, time.Time{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, &from, res.From)
+	assert.Nil(t, res.To)
+	assert.Equal(t, 2, len(res.Entries))
+
+	assert.Equal(t, "AE/WETH", res.Entries[0].PairName)
+	assert.Equal(t, 0.1, res.Entries[0].TakerFees)
+
+	// the july trade is out of the range but sets the cost basis of the august sell
+	zrx := res.Entries[1]
+	assert.Equal(t, "ZRX/WETH", zrx.PairName)
+	assert.Equal(t, from, zrx.PeriodStart)
+	assert.Equal(t, 1, zrx.TradeCount)
+	assert.Equal(t, 5.0, zrx.SellVolume)
+	assert.Equal(t, 0.05, zrx.MakerFees)
+	assert.Equal(t, 5.0, zrx.RealizedPnL)
+
+	_, err = statementService.GetStatement(user, "hour", time.Time{}, time.Time{})
+	assert.Error(t, err)
+}
@@ -1,26 +1,46 @@
 package services
 
 import (
+	"errors"
+	"math/big"
+
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
 	"github.com/globalsign/mgo/bson"
 
 	"github.com/Proofsuite/amp-matching-engine/types"
 )
 
+// the number of most recent blocks in which the transfers of a token are searched when the token is registered
+const tokenInspectionBlocks = 10000
+
+// the maximum share of the amount sent, in basis points, that is considered as a transfer fee
+const maxTransferFeeBps = 1000
+
+// the recipient of the simulated token transfers
+var transferProbeAddress = common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
 // TokenService struct with daos required, responsible for communicating with daos.
 // TokenService functions are responsible for interacting with daos and implements business logics.
 type TokenService struct {
 	tokenDao interfaces.TokenDao
+	provider interfaces.EthereumProvider
 }
 
 // NewTokenService returns a new instance of TokenService
-func NewTokenService(tokenDao interfaces.TokenDao) *TokenService {
-	return &TokenService{tokenDao}
+func NewTokenService(tokenDao interfaces.TokenDao, provider interfaces.EthereumProvider) *TokenService {
+	return &TokenService{tokenDao, provider}
 }
 
-// Create inserts a new token into the database
+// Create inserts a new token into the database. The token metadata is read from the token contract
+// and must match the metadata supplied. Tokens on which a non-standard behaviour is detected are
+// registered with flags and are not listed.
 func (s *TokenService) Create(token *types.Token) error {
+	if (token.Address == common.Address{}) {
+		return errors.New("Token 'address' parameter is required")
+	}
+
 	t, err := s.tokenDao.GetByAddress(token.Address)
 	if err != nil {
 		logger.Error(err)
@@ -31,6 +51,17 @@ func (s *TokenService) Create(token *types.Token) error {
 		return ErrTokenExists
 	}
 
+	err = inspectToken(s.provider, token)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if token.Flagged() {
+		logger.Warningf("Token %v registered with flags %v", token.Address.Hex(), token.Flags)
+		token.Listed = false
+	}
+
 	err = s.tokenDao.Create(token)
 	if err != nil {
 		logger.Error(err)
@@ -76,3 +107,182 @@ func (s *TokenService) GetBaseTokens() ([]types.Token, error) {
 func (s *TokenService) GetListedBaseTokens() ([]types.Token, error) {
 	return s.tokenDao.GetListedBaseTokens()
 }
+
+// inspectToken reads the metadata of a token from the token contract and checks it against the
+// metadata supplied, if any. A transfer is then simulated from a recent holder of the token:
+// tokens whose transfers revert or return false are refused, tokens whose transfer function does
+// not return a value are flagged. Since a simulated call does not show the balance received,
+// fee-on-transfer tokens are detected from the recent transfers of the token.
+func inspectToken(provider interfaces.EthereumProvider, t *types.Token) error {
+	symbol, err := provider.Symbol(t.Address)
+	if err != nil {
+		logger.Error(err)
+		return ErrNoContractCode
+	}
+
+	decimals, err := provider.Decimals(t.Address)
+	if err != nil {
+		logger.Error(err)
+		return ErrNoContractCode
+	}
+
+	supply, err := provider.TotalSupply(t.Address)
+	if err != nil {
+		logger.Error(err)
+		return ErrNoContractCode
+	}
+
+	name, err := provider.Name(t.Address)
+	if err != nil {
+		logger.Warning("Token has no name: ", t.Address.Hex())
+		name = ""
+	}
+
+	if (t.Symbol != "" && t.Symbol != symbol) || (t.Decimals != 0 && t.Decimals != int(decimals)) || (t.Name != "" && t.Name != name) {
+		return ErrTokenMetadataMismatch
+	}
+
+	if supply.Sign() == 0 {
+		return ErrTokenNoSupply
+	}
+
+	t.Symbol = symbol
+	t.Name = name
+	t.Decimals = int(decimals)
+	t.TotalSupply = supply
+	t.Flags = nil
+
+	// the transfers can not be checked if the recent transfers of the token can not be read
+	logs, err := provider.RecentTransfers(t.Address, tokenInspectionBlocks)
+	if err != nil {
+		logger.Error(err)
+		t.Flags = append(t.Flags, types.TokenFlagTransferNotSimulated)
+		return nil
+	}
+
+	holder, balance, err := findHolder(provider, t.Address, logs)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if holder == nil {
+		t.Flags = append(t.Flags, types.TokenFlagTransferNotSimulated)
+	} else {
+		res, err := provider.CallTransfer(t.Address, *holder, transferProbeAddress, balance)
+		if err != nil {
+			logger.Error(err)
+			return ErrTokenTransferFailed
+		}
+
+		if len(res) == 0 {
+			t.Flags = append(t.Flags, types.TokenFlagNoReturnValue)
+		} else if new(big.Int).SetBytes(res).Sign() == 0 {
+			return ErrTokenTransferFailed
+		}
+	}
+
+	if hasTransferFee(logs) {
+		t.Flags = append(t.Flags, types.TokenFlagFeeOnTransfer)
+	}
+
+	return nil
+}
+
+// findHolder returns the most recent recipient of a transfer that still holds the token, and its balance
+func findHolder(provider interfaces.EthereumProvider, token common.Address, logs []eth.Log) (*common.Address, *big.Int, error) {
+	checked := make(map[common.Address]bool)
+	for i := len(logs) - 1; i >= 0; i-- {
+		if len(logs[i].Topics) != 3 {
+			continue
+		}
+
+		holder := common.BytesToAddress(logs[i].Topics[2].Bytes())
+		if checked[holder] || holder == transferProbeAddress || (holder == common.Address{}) {
+			continue
+		}
+
+		checked[holder] = true
+		balance, err := provider.BalanceOf(holder, token)
+		if err != nil {
+			logger.Error(err)
+			return nil, nil, err
+		}
+
+		if balance.Sign() > 0 {
+			return &holder, balance, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// hasTransferFee returns true if the transfers show a fee being taken on the transfers. A fee-on-transfer
+// token emits a second Transfer event from the sender towards the fee collector in the same transaction,
+// the fee being a fixed share of the amount sent. A token is flagged when the same collector receives
+// the same small share of the amount sent in several transactions. The senders of more than two
+// transfers in a transaction (multisends) and the senders forwarding tokens received in the same
+// transaction (routers) are not considered.
+func hasTransferFee(logs []eth.Log) bool {
+	type transfer struct {
+		to    common.Address
+		value *big.Int
+	}
+
+	transfers := make(map[common.Hash]map[common.Address][]transfer)
+	received := make(map[common.Hash]map[common.Address]bool)
+	for _, l := range logs {
+		if len(l.Topics) != 3 {
+			continue
+		}
+
+		from := common.BytesToAddress(l.Topics[1].Bytes())
+		to := common.BytesToAddress(l.Topics[2].Bytes())
+		if transfers[l.TxHash] == nil {
+			transfers[l.TxHash] = make(map[common.Address][]transfer)
+			received[l.TxHash] = make(map[common.Address]bool)
+		}
+
+		received[l.TxHash][to] = true
+
+		transfers[l.TxHash][from] = append(transfers[l.TxHash][from], transfer{to, new(big.Int).SetBytes(l.Data)})
+	}
+
+	type feeShare struct {
+		collector common.Address
+		bps       int64
+	}
+
+	shares := make(map[feeShare]int)
+	for tx, senders := range transfers {
+		for from, sent := range senders {
+			if len(sent) != 2 || sent[0].to == sent[1].to || received[tx][from] {
+				continue
+			}
+
+			amount, fee := sent[0], sent[1]
+			if fee.value.Cmp(amount.value) > 0 {
+				amount, fee = fee, amount
+			}
+
+			if fee.value.Sign() == 0 {
+				continue
+			}
+
+			// the share of the fee in basis points, fees above the maximum share are not considered
+			total := new(big.Int).Add(amount.value, fee.value)
+			bps := new(big.Int).Div(new(big.Int).Mul(fee.value, big.NewInt(10000)), total).Int64()
+			if bps > maxTransferFeeBps {
+				continue
+			}
+
+			k := feeShare{fee.to, bps}
+			shares[k]++
+			if shares[k] > 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func transferLog(tx common.Hash, from, to common.Address, value int64) eth.Log {
+	return eth.Log{
+		TxHash: tx,
+		Topics: []common.Hash{transferEventTopic, from.Hash(), to.Hash()},
+		Data:   common.BigToHash(big.NewInt(value)).Bytes(),
+	}
+}
+
+func setupTokenProvider(token common.Address, logs []eth.Log) *mocks.EthereumProvider {
+	provider := new(mocks.EthereumProvider)
+	provider.On("Symbol", token).Return("ZRX", nil)
+	provider.On("Decimals", token).Return(uint8(18), nil)
+	provider.On("Name", token).Return("0x Protocol Token", nil)
+	provider.On("TotalSupply", token).Return(big.NewInt(1e18), nil)
+	provider.On("RecentTransfers", token, int64(tokenInspectionBlocks)).Return(logs, nil)
+	provider.On("BalanceOf", mock.Anything, token).Return(big.NewInt(1000), nil)
+	return provider
+}
+
+func TestInspectToken(t *testing.T) {
+	token := common.HexToAddress("0x1")
+	holder := common.HexToAddress("0x2")
+	logs := []eth.Log{transferLog(common.HexToHash("0x10"), common.HexToAddress("0x3"), holder, 1000)}
+
+	provider := setupTokenProvider(token, logs)
+	provider.On("CallTransfer", token, holder, transferProbeAddress, big.NewInt(1000)).Return(common.BigToHash(big.NewInt(1)).Bytes(), nil)
+
+	tok := &types.Token{Address: token, Symbol: "ZRX"}
+	err := inspectToken(provider, tok)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "0x Protocol Token", tok.Name)
+	assert.Equal(t, 18, tok.Decimals)
+	assert.Equal(t, big.NewInt(1e18), tok.TotalSupply)
+	assert.False(t, tok.Flagged())
+}
+
+func TestInspectTokenMetadataMismatch(t *testing.T) {
+	token := common.HexToAddress("0x1")
+	provider := setupTokenProvider(token, nil)
+
+	err := inspectToken(provider, &types.Token{Address: token, Decimals: 8})
+	assert.Equal(t, ErrTokenMetadataMismatch, err)
+}
+
+func TestInspectTokenNonStandardTransfer(t *testing.T) {
+	token := common.HexToAddress("0x1")
+	holder := common.HexToAddress("0x2")
+	collector := common.HexToAddress("0x9")
+
+	logs := []eth.Log{
+		transferLog(common.HexToHash("0x10"), common.HexToAddress("0x3"), common.HexToAddress("0x4"), 990),
+		transferLog(common.HexToHash("0x10"), common.HexToAddress("0x3"), collector, 10),
+		transferLog(common.HexToHash("0x11"), common.HexToAddress("0x5"), holder, 1980),
+		transferLog(common.HexToHash("0x11"), common.HexToAddress("0x5"), collector, 20),
+	}
+
+	provider := setupTokenProvider(token, logs)
+	provider.On("CallTransfer", token, collector, transferProbeAddress, big.NewInt(1000)).Return([]byte{}, nil)
+
+	tok := &types.Token{Address: token}
+	err := inspectToken(provider, tok)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, []string{types.TokenFlagNoReturnValue, types.TokenFlagFeeOnTransfer}, tok.Flags)
+}
+
+func TestInspectTokenTransferFailed(t *testing.T) {
+	token := common.HexToAddress("0x1")
+	holder := common.HexToAddress("0x2")
+	logs := []eth.Log{transferLog(common.HexToHash("0x10"), common.HexToAddress("0x3"), holder, 1000)}
+
+	provider := setupTokenProvider(token, logs)
+	provider.On("CallTransfer", token, holder, transferProbeAddress, big.NewInt(1000)).Return(common.Hash{}.Bytes(), nil)
+
+	err := inspectToken(provider, &types.Token{Address: token})
+	assert.Equal(t, ErrTokenTransferFailed, err)
+}
+
+func TestHasTransferFee(t *testing.T) {
+	sender := common.HexToAddress("0x3")
+	collector := common.HexToAddress("0x9")
+	router := common.HexToAddress("0x7")
+
+	// multisends to the same recipients
+	multisend := []eth.Log{
+		transferLog(common.HexToHash("0x10"), sender, common.HexToAddress("0x4"), 500),
+		transferLog(common.HexToHash("0x10"), sender, collector, 500),
+		transferLog(common.HexToHash("0x11"), sender, common.HexToAddress("0x5"), 100),
+		transferLog(common.HexToHash("0x11"), sender, collector, 100),
+		transferLog(common.HexToHash("0x12"), sender, common.HexToAddress("0x4"), 100),
+		transferLog(common.HexToHash("0x12"), sender, common.HexToAddress("0x5"), 100),
+		transferLog(common.HexToHash("0x12"), sender, collector, 1),
+		transferLog(common.HexToHash("0x13"), sender, common.HexToAddress("0x4"), 200),
+		transferLog(common.HexToHash("0x13"), sender, common.HexToAddress("0x5"), 200),
+		transferLog(common.HexToHash("0x13"), sender, collector, 2),
+	}
+
+	assert.False(t, hasTransferFee(multisend))
+
+	// a router forwarding the tokens it received, and taking its own fee
+	swaps := []eth.Log{
+		transferLog(common.HexToHash("0x20"), sender, router, 1000),
+		transferLog(common.HexToHash("0x20"), router, common.HexToAddress("0x4"), 990),
+		transferLog(common.HexToHash("0x20"), router, collector, 10),
+		transferLog(common.HexToHash("0x21"), common.HexToAddress("0x5"), router, 2000),
+		transferLog(common.HexToHash("0x21"), router, common.HexToAddress("0x6"), 1980),
+		transferLog(common.HexToHash("0x21"), router, collector, 20),
+	}
+
+	assert.False(t, hasTransferFee(swaps))
+
+	// a fee taken on the transfers of different senders
+	fees := []eth.Log{
+		transferLog(common.HexToHash("0x30"), sender, common.HexToAddress("0x4"), 990),
+		transferLog(common.HexToHash("0x30"), sender, collector, 10),
+		transferLog(common.HexToHash("0x31"), common.HexToAddress("0x5"), common.HexToAddress("0x6"), 1980),
+		transferLog(common.HexToHash("0x31"), common.HexToAddress("0x5"), collector, 20),
+	}
+
+	assert.True(t, hasTransferFee(fees))
+
+	// the same collector receiving different shares
+	fees[3] = transferLog(common.HexToHash("0x31"), common.HexToAddress("0x5"), collector, 40)
+	assert.False(t, hasTransferFee(fees))
+}
@@ -76,6 +76,11 @@ func (s *TradeService) GetSortedTrades(bt, qt common.Address, n int) ([]*types.T
 	return s.tradeDao.GetSortedTrades(bt, qt, n)
 }
 
+// GetPage fetches a page of the trades matching the query, most recent trades first
+func (s *TradeService) GetPage(q *types.HistoryQuery) (*types.TradePage, error) {
+	return s.tradeDao.GetPage(q)
+}
+
 // GetByUserAddress fetches all the trades corresponding to a user address
 func (s *TradeService) GetByUserAddress(a common.Address) ([]*types.Trade, error) {
 	return s.tradeDao.GetByUserAddress(a)
@@ -98,6 +103,11 @@ func (s *TradeService) GetByOrderHashes(hashes []common.Hash) ([]*types.Trade, e
 	return s.tradeDao.GetByOrderHashes(hashes)
 }
 
+// GetUnsentTrades returns the pending trades whose settlement transaction was not sent yet
+func (s *TradeService) GetUnsentTrades() ([]*types.Trade, error) {
+	return s.tradeDao.GetUnsentTrades()
+}
+
 func (s *TradeService) UpdatePendingTrade(t *types.Trade, txh common.Hash) (*types.Trade, error) {
 	t.Status = "PENDING"
 	t.TxHash = txh
@@ -2,18 +2,20 @@ package services
 
 import (
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/signer"
 	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
-// WalletService struct with daos required, responsible for communicating with daos
+// TxService struct with the wallet service, responsible for building the transaction options.
+// Transactions are signed through the signer of the sending wallet.
 type TxService struct {
-	WalletDao interfaces.WalletDao
-	Wallet    *types.Wallet
+	WalletService interfaces.WalletService
+	Wallet        *types.Wallet
 }
 
-func NewTxService(dao interfaces.WalletDao, w *types.Wallet) *TxService {
-	return &TxService{dao, w}
+func NewTxService(walletService interfaces.WalletService, w *types.Wallet) *TxService {
+	return &TxService{walletService, w}
 }
 
 func (s *TxService) GetTxCallOptions() *bind.CallOpts {
@@ -21,22 +23,27 @@ func (s *TxService) GetTxCallOptions() *bind.CallOpts {
 }
 
 func (s *TxService) GetTxDefaultSendOptions() (*bind.TransactOpts, error) {
-	wallet, err := s.WalletDao.GetDefaultAdminWallet()
+	wallet, err := s.WalletService.GetDefaultAdminWallet()
 	if err != nil {
 		return nil, err
 	}
 
-	return bind.NewKeyedTransactor(wallet.PrivateKey), nil
+	return s.GetCustomTxSendOptions(wallet)
 }
 
 func (s *TxService) GetTxSendOptions() (*bind.TransactOpts, error) {
-	return bind.NewKeyedTransactor(s.Wallet.PrivateKey), nil
+	return s.GetCustomTxSendOptions(s.Wallet)
 }
 
 func (s *TxService) SetTxSender(w *types.Wallet) {
 	s.Wallet = w
 }
 
-func (s *TxService) GetCustomTxSendOptions(w *types.Wallet) *bind.TransactOpts {
-	return bind.NewKeyedTransactor(w.PrivateKey)
+func (s *TxService) GetCustomTxSendOptions(w *types.Wallet) (*bind.TransactOpts, error) {
+	sig, err := signer.GetSigner(w)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.NewTransactor(sig), nil
 }
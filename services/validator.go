@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
 	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/Proofsuite/amp-matching-engine/utils"
@@ -35,8 +34,6 @@ func NewValidatorService(
 }
 
 func (s *ValidatorService) ValidateAvailableBalance(o *types.Order) error {
-	exchangeAddress := common.HexToAddress(app.Config.Ethereum["exchange_address"])
-
 	pair, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
 	if err != nil {
 		logger.Error(err)
@@ -45,33 +42,7 @@ func (s *ValidatorService) ValidateAvailableBalance(o *types.Order) error {
 
 	totalRequiredAmount := o.TotalRequiredSellAmount(pair)
 
-	var sellTokenBalance *big.Int
-	var sellTokenAllowance *big.Int
-
-	// we implement retries in the case the provider connection fell asleep
-	err = utils.Retry(3, func() error {
-		sellTokenBalance, err = s.ethereumProvider.BalanceOf(o.UserAddress, o.SellToken())
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-
-	err = utils.Retry(3, func() error {
-		sellTokenAllowance, err = s.ethereumProvider.Allowance(o.UserAddress, exchangeAddress, o.SellToken())
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-
+	sellTokenBalance, sellTokenAllowance, err := s.GetBalanceAndAllowance(o.UserAddress, o.SellToken(), pair.Exchange())
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -107,8 +78,6 @@ func (s *ValidatorService) ValidateAvailableBalance(o *types.Order) error {
 }
 
 func (s *ValidatorService) ValidateBalance(o *types.Order) error {
-	exchangeAddress := common.HexToAddress(app.Config.Ethereum["exchange_address"])
-
 	pair, err := s.pairDao.GetByTokenAddress(o.BaseToken, o.QuoteToken)
 	if err != nil {
 		logger.Error(err)
@@ -117,12 +86,54 @@ func (s *ValidatorService) ValidateBalance(o *types.Order) error {
 
 	totalRequiredAmount := o.TotalRequiredSellAmount(pair)
 
-	var sellTokenBalance *big.Int
-	var sellTokenAllowance *big.Int
+	sellTokenBalance, sellTokenAllowance, err := s.GetBalanceAndAllowance(o.UserAddress, o.SellToken(), pair.Exchange())
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	//Sell Token Balance
+	if sellTokenBalance.Cmp(totalRequiredAmount) == -1 {
+		return fmt.Errorf("Insufficient %v Balance", o.SellTokenSymbol())
+	}
+
+	if sellTokenAllowance.Cmp(totalRequiredAmount) == -1 {
+		return fmt.Errorf("Insufficient %v Allowance", o.SellTokenSymbol())
+	}
+
+	return nil
+}
+
+// GetBalanceAndAllowance returns the cached balance of the owner for the given token and its allowance
+// to the given exchange contract. The cached values are kept up to date by the balance service, which
+// is the only writer of the cache. Token balances that are not synced with the chain are read from the
+// chain. Only the allowance to the default exchange contract is cached, the allowances to the other
+// exchange contracts are read from the chain.
+func (s *ValidatorService) GetBalanceAndAllowance(owner, token, exchange common.Address) (*big.Int, *big.Int, error) {
+	tb, err := s.accountDao.GetTokenBalance(owner, token)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	if tb != nil && tb.Synced {
+		if exchange == types.DefaultExchangeAddress() {
+			return tb.Balance, tb.Allowance, nil
+		}
+
+		allowance, err := s.getAllowance(owner, token, exchange)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return tb.Balance, allowance, nil
+	}
+
+	var balance *big.Int
 
 	// we implement retries in the case the provider connection fell asleep
 	err = utils.Retry(3, func() error {
-		sellTokenBalance, err = s.ethereumProvider.BalanceOf(o.UserAddress, o.SellToken())
+		balance, err = s.ethereumProvider.BalanceOf(owner, token)
 		if err != nil {
 			return err
 		}
@@ -132,11 +143,24 @@ func (s *ValidatorService) ValidateBalance(o *types.Order) error {
 
 	if err != nil {
 		logger.Error(err)
-		return err
+		return nil, nil, err
 	}
 
-	err = utils.Retry(3, func() error {
-		sellTokenAllowance, err = s.ethereumProvider.Allowance(o.UserAddress, exchangeAddress, o.SellToken())
+	allowance, err := s.getAllowance(owner, token, exchange)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return balance, allowance, nil
+}
+
+// getAllowance reads the allowance of the owner to the exchange contract for the given token from the chain
+func (s *ValidatorService) getAllowance(owner, token, exchange common.Address) (*big.Int, error) {
+	var allowance *big.Int
+
+	err := utils.Retry(3, func() error {
+		var err error
+		allowance, err = s.ethereumProvider.Allowance(owner, exchange, token)
 		if err != nil {
 			return err
 		}
@@ -146,17 +170,8 @@ func (s *ValidatorService) ValidateBalance(o *types.Order) error {
 
 	if err != nil {
 		logger.Error(err)
-		return err
-	}
-
-	//Sell Token Balance
-	if sellTokenBalance.Cmp(totalRequiredAmount) == -1 {
-		return fmt.Errorf("Insufficient %v Balance", o.SellTokenSymbol())
+		return nil, err
 	}
 
-	if sellTokenAllowance.Cmp(totalRequiredAmount) == -1 {
-		return fmt.Errorf("Insufficient %v Allowance", o.SellTokenSymbol())
-	}
-
-	return nil
+	return allowance, nil
 }
@@ -1,18 +1,33 @@
 package services
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/signer"
 	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// WalletService struct with daos required, responsible for communicating with daos
+// WalletService struct with daos required, responsible for communicating with daos.
+// Wallets are persisted without their private keys. When a keystore is set, the wallets
+// returned by the service sign through their account in the keystore.
 type WalletService struct {
 	WalletDao interfaces.WalletDao
+	Keystore  *keystore.KeyStore
 }
 
 func NewWalletService(walletDao interfaces.WalletDao) *WalletService {
-	return &WalletService{walletDao}
+	return &WalletService{WalletDao: walletDao}
+}
+
+// NewKeystoreWalletService returns a wallet service whose wallets are backed by the
+// accounts of the given keystore
+func NewKeystoreWalletService(walletDao interfaces.WalletDao, ks *keystore.KeyStore) *WalletService {
+	return &WalletService{WalletDao: walletDao, Keystore: ks}
 }
 
 func (s *WalletService) CreateAdminWallet(a common.Address) (*types.Wallet, error) {
@@ -27,11 +42,108 @@ func (s *WalletService) CreateAdminWallet(a common.Address) (*types.Wallet, erro
 		return nil, err
 	}
 
+	s.attachKeystore(w)
 	return w, nil
 }
 
+// UnlockWallets unlocks the admin and operator wallet accounts in the keystore with the
+// given passphrase. The accounts stay unlocked until the process exits. Nothing is unlocked
+// when the wallets sign through the remote signing service, their keys are not held locally.
+func (s *WalletService) UnlockWallets(passphrase string) error {
+	if signer.UsesRemoteSigner() {
+		logger.Info("Wallets sign through the remote signer, skipping the keystore unlock")
+		return nil
+	}
+
+	admin, err := s.GetDefaultAdminWallet()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	operators, err := s.GetOperatorWallets()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	wallets := operators
+	if admin != nil {
+		wallets = append(wallets, admin)
+	}
+
+	for _, w := range wallets {
+		err := w.Unlock(passphrase)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		logger.Infof("Unlocked wallet %v", w.Address.Hex())
+	}
+
+	return nil
+}
+
+// MigratePrivateKeys moves the private keys stored in the wallets collection to the keystore,
+// encrypted with the given passphrase, and removes them from the collection. The keys of the
+// accounts already in the keystore are only removed. The keys are removed once the keystore
+// holds them, so the migration can be run again after a failure.
+func (s *WalletService) MigratePrivateKeys(passphrase string) error {
+	keys, err := s.WalletDao.GetPrivateKeys()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if len(keys) > 0 && s.Keystore == nil {
+		return errors.New("Wallet private keys can not be migrated without a keystore")
+	}
+
+	for a, k := range keys {
+		if k != "" && !s.Keystore.HasAddress(a) {
+			// the keys were stored without their leading zeros
+			if len(k) < 64 {
+				k = strings.Repeat("0", 64-len(k)) + k
+			}
+
+			key, err := crypto.HexToECDSA(k)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+
+			if crypto.PubkeyToAddress(key.PublicKey) != a {
+				return errors.New("Private key does not correspond to wallet " + a.Hex())
+			}
+
+			_, err = s.Keystore.ImportECDSA(key, passphrase)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+		}
+
+		err = s.WalletDao.RemovePrivateKey(a)
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		logger.Infof("Moved the private key of wallet %v to the keystore", a.Hex())
+	}
+
+	return nil
+}
+
 func (s *WalletService) GetDefaultAdminWallet() (*types.Wallet, error) {
-	return s.WalletDao.GetDefaultAdminWallet()
+	w, err := s.WalletDao.GetDefaultAdminWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachKeystore(w)
+	return w, nil
 }
 
 func (s *WalletService) GetOperatorAddresses() ([]common.Address, error) {
@@ -53,13 +165,46 @@ func (s *WalletService) GetOperatorAddresses() ([]common.Address, error) {
 }
 
 func (s *WalletService) GetOperatorWallets() ([]*types.Wallet, error) {
-	return s.WalletDao.GetOperatorWallets()
+	wallets, err := s.WalletDao.GetOperatorWallets()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range wallets {
+		s.attachKeystore(w)
+	}
+
+	return wallets, nil
 }
 
 func (s *WalletService) GetAll() ([]types.Wallet, error) {
-	return s.WalletDao.GetAll()
+	wallets, err := s.WalletDao.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range wallets {
+		s.attachKeystore(&wallets[i])
+	}
+
+	return wallets, nil
 }
 
 func (s *WalletService) GetByAddress(a common.Address) (*types.Wallet, error) {
-	return s.WalletDao.GetByAddress(a)
+	w, err := s.WalletDao.GetByAddress(a)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachKeystore(w)
+	return w, nil
+}
+
+// attachKeystore sets the service keystore on wallets that do not hold a private key
+func (s *WalletService) attachKeystore(w *types.Wallet) {
+	if w == nil || s.Keystore == nil || w.PrivateKey != nil {
+		return
+	}
+
+	w.Keystore = s.Keystore
 }
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigratePrivateKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	walletDao := new(mocks.WalletDao)
+
+	w := types.NewWallet()
+	imported := types.NewWallet()
+	_, err = ks.ImportECDSA(imported.PrivateKey, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletDao.On("GetPrivateKeys").Return(map[common.Address]string{
+		w.Address:        w.GetPrivateKey(),
+		imported.Address: imported.GetPrivateKey(),
+	}, nil)
+	walletDao.On("RemovePrivateKey", w.Address).Return(nil)
+	walletDao.On("RemovePrivateKey", imported.Address).Return(nil)
+
+	walletService := NewKeystoreWalletService(walletDao, ks)
+	err = walletService.MigratePrivateKeys("passphrase")
+	if err != nil {
+		t.Errorf("Could not migrate private keys: %v", err)
+	}
+
+	// the keys are removed from the wallets collection once the keystore holds them
+	assert.True(t, ks.HasAddress(w.Address))
+	assert.Equal(t, 2, len(ks.Accounts()))
+	walletDao.AssertExpectations(t)
+
+	migrated := &types.Wallet{Address: w.Address, Keystore: ks}
+	assert.Nil(t, migrated.Unlock("passphrase"))
+}
+
+func TestMigratePrivateKeysMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	walletDao := new(mocks.WalletDao)
+
+	w := types.NewWallet()
+	walletDao.On("GetPrivateKeys").Return(map[common.Address]string{
+		common.HexToAddress("0x1"): w.GetPrivateKey(),
+	}, nil)
+
+	walletService := NewKeystoreWalletService(walletDao, ks)
+	err = walletService.MigratePrivateKeys("passphrase")
+	assert.Error(t, err)
+
+	// the key is kept in the wallets collection
+	walletDao.AssertNotCalled(t, "RemovePrivateKey", common.HexToAddress("0x1"))
+	assert.Equal(t, 0, len(ks.Accounts()))
+}
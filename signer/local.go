@@ -0,0 +1,33 @@
+package signer
+
+import (
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+)
+
+// LocalSigner signs transactions in process with a wallet private key or unlocked keystore account
+type LocalSigner struct {
+	Wallet *types.Wallet
+}
+
+// NewLocalSigner returns a signer signing with the given wallet
+func NewLocalSigner(w *types.Wallet) *LocalSigner {
+	return &LocalSigner{w}
+}
+
+// Address returns the wallet address
+func (s *LocalSigner) Address() common.Address {
+	return s.Wallet.Address
+}
+
+// SignTx signs the transaction with the wallet
+func (s *LocalSigner) SignTx(tx *eth.Transaction) (*eth.Transaction, error) {
+	signed, err := s.Wallet.SignTx(tx)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return signed, nil
+}
@@ -0,0 +1,125 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SignTxArgs are the arguments of an eth_signTransaction request
+type SignTxArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// SignTxResult is the result of an eth_signTransaction request. Raw is the RLP encoded
+// signed transaction
+type SignTxResult struct {
+	Raw hexutil.Bytes    `json:"raw"`
+	Tx  *eth.Transaction `json:"tx"`
+}
+
+// RemoteSigner signs transactions through an external signing service speaking the
+// JSON-RPC eth_signTransaction protocol. Private keys never enter the matching engine.
+type RemoteSigner struct {
+	address common.Address
+	client  *rpc.Client
+}
+
+// NewRemoteSigner connects to the signing service at the given url (http, ws or ipc)
+// and returns a signer for the given account
+func NewRemoteSigner(url string, a common.Address) (*RemoteSigner, error) {
+	client, err := rpc.Dial(url)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return NewRemoteSignerFromClient(client, a), nil
+}
+
+// NewRemoteSignerFromClient returns a signer for the given account using an existing rpc client
+func NewRemoteSignerFromClient(client *rpc.Client, a common.Address) *RemoteSigner {
+	return &RemoteSigner{a, client}
+}
+
+// Address returns the signing account address
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx requests the signing service to sign the transaction. The returned transaction is
+// checked to be the requested transaction signed by the signer account.
+func (s *RemoteSigner) SignTx(tx *eth.Transaction) (*eth.Transaction, error) {
+	args := SignTxArgs{
+		From:     s.address,
+		To:       tx.To(),
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+		Value:    (*hexutil.Big)(tx.Value()),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Data:     tx.Data(),
+	}
+
+	res := &SignTxResult{}
+	err := s.client.Call(res, "eth_signTransaction", args)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	signed := &eth.Transaction{}
+	err = rlp.DecodeBytes(res.Raw, signed)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	var signer eth.Signer = eth.HomesteadSigner{}
+	if signed.Protected() {
+		signer = eth.NewEIP155Signer(signed.ChainId())
+	}
+
+	from, err := eth.Sender(signer, signed)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if from != s.address {
+		return nil, errors.New("Transaction was signed by the wrong account")
+	}
+
+	if !sameTransaction(tx, signed) {
+		return nil, errors.New("Signed transaction does not match the requested transaction")
+	}
+
+	return signed, nil
+}
+
+// sameTransaction returns true if both transactions have the same content regardless of their signature
+func sameTransaction(a, b *eth.Transaction) bool {
+	if (a.To() == nil) != (b.To() == nil) {
+		return false
+	}
+
+	if a.To() != nil && *a.To() != *b.To() {
+		return false
+	}
+
+	return a.Nonce() == b.Nonce() &&
+		a.Gas() == b.Gas() &&
+		a.GasPrice().Cmp(b.GasPrice()) == 0 &&
+		a.Value().Cmp(b.Value()) == 0 &&
+		bytes.Equal(a.Data(), b.Data())
+}
@@ -0,0 +1,77 @@
+package signer
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var logger = utils.Logger
+
+// NewTransactor returns transaction options that sign transactions through the given signer.
+// The signer is free to choose the signing scheme (e.g. EIP155), the transaction options
+// only check that transactions are sent from the signer account.
+func NewTransactor(s interfaces.Signer) *bind.TransactOpts {
+	from := s.Address()
+
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(_ eth.Signer, address common.Address, tx *eth.Transaction) (*eth.Transaction, error) {
+			if address != from {
+				return nil, errors.New("not authorized to sign this account")
+			}
+
+			return s.SignTx(tx)
+		},
+	}
+}
+
+var (
+	signers      = make(map[common.Address]interfaces.Signer)
+	signersMutex = &sync.Mutex{}
+	remoteClient *rpc.Client
+)
+
+// UsesRemoteSigner returns true if the transactions are signed by the remote signing service,
+// in which case the wallet keys are not held by the matching engine
+func UsesRemoteSigner() bool {
+	return app.Config.RemoteSignerURL != ""
+}
+
+// GetSigner returns the signer of the given wallet. Transactions are signed by the remote
+// signing service if one is configured and locally otherwise. Remote signers are built once
+// per wallet and share a single connection to the signing service.
+func GetSigner(w *types.Wallet) (interfaces.Signer, error) {
+	if !UsesRemoteSigner() {
+		return NewLocalSigner(w), nil
+	}
+
+	signersMutex.Lock()
+	defer signersMutex.Unlock()
+
+	if s, ok := signers[w.Address]; ok {
+		return s, nil
+	}
+
+	if remoteClient == nil {
+		client, err := rpc.Dial(app.Config.RemoteSignerURL)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		remoteClient = client
+	}
+
+	s := NewRemoteSignerFromClient(remoteClient, w.Address)
+	signers[w.Address] = s
+	return s, nil
+}
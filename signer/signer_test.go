@@ -0,0 +1,125 @@
+package signer_test
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/Proofsuite/amp-matching-engine/services"
+	"github.com/Proofsuite/amp-matching-engine/signer"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils"
+	"github.com/Proofsuite/amp-matching-engine/utils/testutils/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalSigner(t *testing.T) {
+	w := testutils.GetTestWallet1()
+	s := signer.NewLocalSigner(w)
+
+	tx := eth.NewTransaction(1, testutils.GetTestAddress1(), big.NewInt(10), 21000, big.NewInt(1), nil)
+	signed, err := signer.NewTransactor(s).Signer(eth.HomesteadSigner{}, w.Address, tx)
+	if err != nil {
+		t.Fatalf("Could not sign transaction: %v", err)
+	}
+
+	from, _ := eth.Sender(eth.HomesteadSigner{}, signed)
+	assert.Equal(t, w.Address, from)
+
+	_, err = signer.NewTransactor(s).Signer(eth.HomesteadSigner{}, testutils.GetTestAddress2(), tx)
+	assert.Error(t, err)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	w := testutils.GetTestWallet1()
+	s, service := testutils.NewTestRemoteSigner(w)
+	assert.Equal(t, w.Address, s.Address())
+
+	to := testutils.GetTestAddress1()
+	tx := eth.NewTransaction(3, to, big.NewInt(10), 200000, big.NewInt(1), common.FromHex("0x1234"))
+
+	signed, err := s.SignTx(tx)
+	if err != nil {
+		t.Fatalf("Could not sign transaction: %v", err)
+	}
+
+	from, _ := eth.Sender(eth.HomesteadSigner{}, signed)
+	assert.Equal(t, w.Address, from)
+	assert.Equal(t, uint64(3), signed.Nonce())
+	assert.Equal(t, common.FromHex("0x1234"), signed.Data())
+
+	assert.Equal(t, 1, len(service.Requests))
+	assert.Equal(t, w.Address, service.Requests[0].From)
+	assert.Equal(t, to, *service.Requests[0].To)
+}
+
+func TestRemoteSignerUnknownAccount(t *testing.T) {
+	server, _ := testutils.NewSignerServer(testutils.GetTestWallet1())
+	s := signer.NewRemoteSignerFromClient(rpc.DialInProc(server), testutils.GetTestAddress2())
+
+	tx := eth.NewTransaction(0, testutils.GetTestAddress1(), big.NewInt(0), 21000, big.NewInt(1), nil)
+	_, err := s.SignTx(tx)
+	assert.Error(t, err)
+}
+
+func TestGetRemoteSigner(t *testing.T) {
+	w := testutils.GetTestWallet1()
+	server, service := testutils.NewSignerServer(w)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	app.Config.RemoteSignerURL = ts.URL
+	defer func() { app.Config.RemoteSignerURL = "" }()
+
+	s1, err := signer.GetSigner(w)
+	if err != nil {
+		t.Fatalf("Could not get signer: %v", err)
+	}
+
+	// the signer of a wallet is reused instead of connecting to the signing service again
+	s2, err := signer.GetSigner(w)
+	if err != nil {
+		t.Fatalf("Could not get signer: %v", err)
+	}
+
+	assert.True(t, s1 == s2)
+
+	tx := eth.NewTransaction(1, testutils.GetTestAddress1(), big.NewInt(10), 21000, big.NewInt(1), nil)
+	signed, err := s2.SignTx(tx)
+	if err != nil {
+		t.Fatalf("Could not sign transaction: %v", err)
+	}
+
+	from, _ := eth.Sender(eth.HomesteadSigner{}, signed)
+	assert.Equal(t, w.Address, from)
+	assert.Equal(t, 1, len(service.Requests))
+
+	// the wallets signing remotely hold no key and are not unlocked locally
+	remote := &types.Wallet{Address: w.Address, Admin: true}
+	walletDao := new(mocks.WalletDao)
+	walletDao.On("GetDefaultAdminWallet").Return(remote, nil)
+	walletDao.On("GetOperatorWallets").Return([]*types.Wallet{remote}, nil)
+
+	walletService := services.NewWalletService(walletDao)
+	err = walletService.UnlockWallets("passphrase")
+	assert.Nil(t, err)
+
+	txService := services.NewTxService(walletService, remote)
+	opts, err := txService.GetTxDefaultSendOptions()
+	if err != nil {
+		t.Fatalf("Could not get transaction options: %v", err)
+	}
+
+	signed, err = opts.Signer(eth.HomesteadSigner{}, w.Address, tx)
+	if err != nil {
+		t.Fatalf("Could not sign transaction: %v", err)
+	}
+
+	from, _ = eth.Sender(eth.HomesteadSigner{}, signed)
+	assert.Equal(t, w.Address, from)
+	assert.Equal(t, 2, len(service.Requests))
+}
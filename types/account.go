@@ -22,7 +22,9 @@ type Account struct {
 }
 
 // TokenBalance holds the Balance, Allowance and the Locked balance values for a single Ethereum token
-// Balance, Allowance and Locked Balance are stored as big.Int as they represent uint256 values
+// Balance, Allowance and Locked Balance are stored as big.Int as they represent uint256 values.
+// Synced is set once the balance and allowance have been read from the chain. From then on they
+// are kept up to date from the token Transfer and Approval events.
 type TokenBalance struct {
 	Address        common.Address `json:"address" bson:"address"`
 	Symbol         string         `json:"symbol" bson:"symbol"`
@@ -30,6 +32,7 @@ type TokenBalance struct {
 	Allowance      *big.Int       `json:"allowance" bson:"allowance"`
 	PendingBalance *big.Int       `json:"pendingBalance" bson:"pendingBalance"`
 	LockedBalance  *big.Int       `json:"lockedBalance" bson:"lockedBalance"`
+	Synced         bool           `json:"synced" bson:"synced"`
 }
 
 // AccountRecord corresponds to what is stored in the DB. big.Ints are encoded as strings
@@ -50,6 +53,7 @@ type TokenBalanceRecord struct {
 	Allowance      string `json:"allowance" bson:"allowance"`
 	PendingBalance string `json:"pendingBalance" base:"pendingBalance"`
 	LockedBalance  string `json:"lockedBalance" bson:"lockedBalance"`
+	Synced         bool   `json:"synced" bson:"synced"`
 }
 
 // GetBSON implements bson.Getter
@@ -69,6 +73,7 @@ func (a *Account) GetBSON() (interface{}, error) {
 			Allowance:      value.Allowance.String(),
 			LockedBalance:  value.LockedBalance.String(),
 			PendingBalance: value.PendingBalance.String(),
+			Synced:         value.Synced,
 		}
 	}
 
@@ -111,6 +116,7 @@ func (a *Account) SetBSON(raw bson.Raw) error {
 			Allowance:      allowance,
 			LockedBalance:  lockedBalance,
 			PendingBalance: pendingBalance,
+			Synced:         value.Synced,
 		}
 	}
 
@@ -145,6 +151,7 @@ func (a *Account) MarshalJSON() ([]byte, error) {
 			"allowance":      balance.Allowance.String(),
 			"lockedBalance":  balance.LockedBalance.String(),
 			"pendingBalance": balance.PendingBalance.String(),
+			"synced":         balance.Synced,
 		}
 	}
 
@@ -207,6 +214,10 @@ func (a *Account) UnmarshalJSON(b []byte) error {
 				tb.PendingBalance.UnmarshalJSON([]byte(tokenBalance["pendingBalance"].(string)))
 			}
 
+			if tokenBalance["synced"] != nil {
+				tb.Synced = tokenBalance["synced"].(bool)
+			}
+
 			a.TokenBalances[common.HexToAddress(address)] = tb
 		}
 	}
@@ -238,6 +249,7 @@ func (a *AccountBSONUpdate) GetBSON() (interface{}, error) {
 			Allowance:      value.Allowance.String(),
 			LockedBalance:  value.LockedBalance.String(),
 			PendingBalance: value.PendingBalance.String(),
+			Synced:         value.Synced,
 		}
 	}
 
@@ -0,0 +1,24 @@
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+// EngineState describes the order books of the matching engine and the settlement queues
+// of the operator
+type EngineState struct {
+	OrderBooks     []*OrderBookState       `json:"orderbooks"`
+	PendingMatches int                     `json:"pendingMatches"`
+	Queues         []*OperatorWalletStatus `json:"queues"`
+}
+
+// OrderBookState describes the order book of a pair. Loaded is false for the pairs created
+// after the engine was started, the engine does not match their orders until it is restarted.
+type OrderBookState struct {
+	PairName   string         `json:"pairName"`
+	BaseToken  common.Address `json:"baseToken"`
+	QuoteToken common.Address `json:"quoteToken"`
+	Listed     bool           `json:"listed"`
+	Active     bool           `json:"active"`
+	Loaded     bool           `json:"loaded"`
+	BidLevels  int            `json:"bidLevels"`
+	AskLevels  int            `json:"askLevels"`
+}
@@ -0,0 +1,201 @@
+package types
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/globalsign/mgo/bson"
+)
+
+// API key scopes. The read scope gives access to the private data of the key owner and is
+// granted to every key. The trade scope allows to place and cancel orders, and the admin
+// scope gives access to the administration endpoints.
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeTrade = "trade"
+	APIKeyScopeAdmin = "admin"
+)
+
+// Headers of the REST requests authenticated with an API key. The signature is the hex
+// encoded HMAC-SHA256 of the timestamp (unix milliseconds), the method, the request URI
+// (path and query string) and the body, keyed with the API key secret.
+const (
+	APIKeyHeader          = "AMP-API-KEY"
+	APIKeyTimestampHeader = "AMP-API-TIMESTAMP"
+	APIKeySignatureHeader = "AMP-API-SIGNATURE"
+)
+
+// APIKey authenticates the REST requests of the account that created it. The secret is only
+// returned when the key is created.
+type APIKey struct {
+	ID          bson.ObjectId  `json:"id" bson:"_id"`
+	Key         string         `json:"key" bson:"key"`
+	Secret      string         `json:"-" bson:"secret"`
+	UserAddress common.Address `json:"userAddress" bson:"userAddress"`
+	Scopes      []string       `json:"scopes" bson:"scopes"`
+	Label       string         `json:"label" bson:"label"`
+	CreatedAt   time.Time      `json:"createdAt" bson:"createdAt"`
+}
+
+type APIKeyRecord struct {
+	ID          bson.ObjectId `json:"id" bson:"_id"`
+	Key         string        `json:"key" bson:"key"`
+	Secret      string        `json:"secret" bson:"secret"`
+	UserAddress string        `json:"userAddress" bson:"userAddress"`
+	Scopes      []string      `json:"scopes" bson:"scopes"`
+	Label       string        `json:"label" bson:"label"`
+	CreatedAt   time.Time     `json:"createdAt" bson:"createdAt"`
+}
+
+// APIKeyChallenge is the message an account signs to create an API key. It binds a
+// random nonce issued by the server to the account address and to the requested scopes.
+type APIKeyChallenge struct {
+	UserAddress common.Address
+	Scopes      []string
+	Nonce       common.Hash
+	ExpiresAt   time.Time
+}
+
+// NormalizeAPIKeyScopes validates a list of scopes and returns it sorted and deduplicated,
+// with the read scope added if missing
+func NormalizeAPIKeyScopes(scopes []string) ([]string, error) {
+	set := map[string]bool{APIKeyScopeRead: true}
+	for _, s := range scopes {
+		switch s {
+		case APIKeyScopeRead, APIKeyScopeTrade, APIKeyScopeAdmin:
+			set[s] = true
+		default:
+			return nil, errors.New("Scopes must be one of read, trade or admin")
+		}
+	}
+
+	res := []string{}
+	for s := range set {
+		res = append(res, s)
+	}
+
+	sort.Strings(res)
+	return res, nil
+}
+
+// HasScope returns true if the key was granted the given scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ComputeHash returns the hash of the challenge. The challenge is signed by prefixing
+// this hash with "\x19Ethereum Signed Message:\n32" (personal_sign).
+func (c *APIKeyChallenge) ComputeHash() common.Hash {
+	return crypto.Keccak256Hash(
+		c.Nonce.Bytes(),
+		c.UserAddress.Bytes(),
+		[]byte(strings.Join(c.Scopes, ",")),
+	)
+}
+
+// VerifySignature returns a true value if the signature of the challenge corresponds to
+// the challenge account
+func (c *APIKeyChallenge) VerifySignature(s *Signature) (bool, error) {
+	if s == nil {
+		return false, errors.New("Signature is required")
+	}
+
+	address, err := s.Verify(personalMessageHash(c.ComputeHash()))
+	if err != nil {
+		return false, err
+	}
+
+	if address != c.UserAddress {
+		return false, errors.New("Recovered address is incorrect")
+	}
+
+	return true, nil
+}
+
+// ComputeRequestSignature returns the hex encoded HMAC-SHA256 signature of a REST request.
+// timestamp is the value of the timestamp header and uri the request path and query string.
+func ComputeRequestSignature(secret, timestamp, method, uri string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + method + uri))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyRequestSignature returns true if signature is the signature of the request with the key secret
+func (k *APIKey) VerifyRequestSignature(signature, timestamp, method, uri string, body []byte) bool {
+	expected := ComputeRequestSignature(k.Secret, timestamp, method, uri, body)
+	return hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected))
+}
+
+func (k *APIKey) MarshalJSON() ([]byte, error) {
+	key := map[string]interface{}{
+		"key":         k.Key,
+		"userAddress": k.UserAddress.Hex(),
+		"scopes":      k.Scopes,
+		"label":       k.Label,
+		"createdAt":   k.CreatedAt.Format(time.RFC3339Nano),
+	}
+
+	if k.ID != "" {
+		key["id"] = k.ID.Hex()
+	}
+
+	return json.Marshal(key)
+}
+
+func (k *APIKey) GetBSON() (interface{}, error) {
+	return APIKeyRecord{
+		ID:          k.ID,
+		Key:         k.Key,
+		Secret:      k.Secret,
+		UserAddress: k.UserAddress.Hex(),
+		Scopes:      k.Scopes,
+		Label:       k.Label,
+		CreatedAt:   k.CreatedAt,
+	}, nil
+}
+
+func (k *APIKey) SetBSON(raw bson.Raw) error {
+	decoded := new(APIKeyRecord)
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	k.ID = decoded.ID
+	k.Key = decoded.Key
+	k.Secret = decoded.Secret
+	k.UserAddress = common.HexToAddress(decoded.UserAddress)
+	k.Scopes = decoded.Scopes
+	k.Label = decoded.Label
+	k.CreatedAt = decoded.CreatedAt
+	return nil
+}
+
+func (c *APIKeyChallenge) MarshalJSON() ([]byte, error) {
+	challenge := map[string]interface{}{
+		"userAddress": c.UserAddress.Hex(),
+		"scopes":      c.Scopes,
+		"nonce":       c.Nonce.Hex(),
+		"hash":        c.ComputeHash().Hex(),
+		"expiresAt":   c.ExpiresAt.Format(time.RFC3339Nano),
+	}
+
+	return json.Marshal(challenge)
+}
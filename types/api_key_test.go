@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAPIKeyScopes(t *testing.T) {
+	scopes, err := NormalizeAPIKeyScopes([]string{"trade", "trade"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, []string{"read", "trade"}, scopes)
+
+	_, err = NormalizeAPIKeyScopes([]string{"withdraw"})
+	assert.Error(t, err)
+}
+
+func TestAPIKeyChallengeVerifySignature(t *testing.T) {
+	w := NewWallet()
+	c := &APIKeyChallenge{
+		UserAddress: w.Address,
+		Scopes:      []string{"read", "trade"},
+		Nonce:       common.HexToHash("0x1234"),
+	}
+
+	sig, err := SignHash(c.ComputeHash(), w.PrivateKey)
+	if err != nil {
+		t.Error(err)
+	}
+
+	ok, err := c.VerifySignature(sig)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	// the signature does not cover other scopes
+	c.Scopes = []string{"admin", "read"}
+	ok, err = c.VerifySignature(sig)
+	assert.False(t, ok)
+	assert.Error(t, err)
+}
+
+func TestAPIKeyVerifyRequestSignature(t *testing.T) {
+	k := &APIKey{Key: "key", Secret: "secret", Scopes: []string{"read"}}
+
+	sig := ComputeRequestSignature("secret", "1540000000000", "POST", "/orders?a=b", []byte(`{"amount":"1"}`))
+
+	assert.True(t, k.VerifyRequestSignature(sig, "1540000000000", "POST", "/orders?a=b", []byte(`{"amount":"1"}`)))
+	assert.False(t, k.VerifyRequestSignature(sig, "1540000000001", "POST", "/orders?a=b", []byte(`{"amount":"1"}`)))
+	assert.False(t, k.VerifyRequestSignature(sig, "1540000000000", "POST", "/orders?a=b", []byte(`{"amount":"2"}`)))
+	assert.True(t, k.HasScope(APIKeyScopeRead))
+	assert.False(t, k.HasScope(APIKeyScopeTrade))
+}
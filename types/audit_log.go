@@ -0,0 +1,145 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+)
+
+// Admin actions recorded in the audit log
+const (
+	AuditActionBlockAccount      = "BLOCK_ACCOUNT"
+	AuditActionUnblockAccount    = "UNBLOCK_ACCOUNT"
+	AuditActionUpdatePairStatus  = "UPDATE_PAIR_STATUS"
+	AuditActionUpdateTokenStatus = "UPDATE_TOKEN_STATUS"
+	AuditActionCancelOrder       = "CANCEL_ORDER"
+	AuditActionPurgeQueues       = "PURGE_QUEUES"
+	AuditActionCreateFeeTier     = "CREATE_FEE_TIER"
+	AuditActionDeleteFeeTier     = "DELETE_FEE_TIER"
+	AuditActionSetFeeOverride    = "SET_FEE_OVERRIDE"
+	AuditActionDeleteFeeOverride = "DELETE_FEE_OVERRIDE"
+	AuditActionReloadDenyList    = "RELOAD_DENY_LIST"
+)
+
+// AuditLog records an admin action. Before and After are the JSON representations of the
+// target before and after the action, and Diff holds the top-level fields that were changed.
+type AuditLog struct {
+	ID        bson.ObjectId           `json:"id" bson:"_id"`
+	Actor     common.Address          `json:"actor" bson:"actor"`
+	Action    string                  `json:"action" bson:"action"`
+	Target    string                  `json:"target" bson:"target"`
+	Before    map[string]interface{}  `json:"before" bson:"before"`
+	After     map[string]interface{}  `json:"after" bson:"after"`
+	Diff      map[string]*AuditChange `json:"diff" bson:"diff"`
+	CreatedAt time.Time               `json:"createdAt" bson:"createdAt"`
+}
+
+// AuditChange is the change of a field of the target of an admin action
+type AuditChange struct {
+	From interface{} `json:"from" bson:"from"`
+	To   interface{} `json:"to" bson:"to"`
+}
+
+type AuditLogRecord struct {
+	ID        bson.ObjectId           `json:"id" bson:"_id"`
+	Actor     string                  `json:"actor" bson:"actor"`
+	Action    string                  `json:"action" bson:"action"`
+	Target    string                  `json:"target" bson:"target"`
+	Before    map[string]interface{}  `json:"before" bson:"before"`
+	After     map[string]interface{}  `json:"after" bson:"after"`
+	Diff      map[string]*AuditChange `json:"diff" bson:"diff"`
+	CreatedAt time.Time               `json:"createdAt" bson:"createdAt"`
+}
+
+// NewAuditLog returns the audit log of an action performed by actor on target. before and
+// after are encoded to JSON and compared field by field, a nil value stands for a target that
+// does not exist.
+func NewAuditLog(actor common.Address, action, target string, before, after interface{}) (*AuditLog, error) {
+	b, err := auditSnapshot(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := auditSnapshot(after)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &AuditLog{
+		Actor:  actor,
+		Action: action,
+		Target: target,
+		Before: b,
+		After:  a,
+		Diff:   map[string]*AuditChange{},
+	}
+
+	for k, v := range b {
+		if !reflect.DeepEqual(v, a[k]) {
+			l.Diff[k] = &AuditChange{From: v, To: a[k]}
+		}
+	}
+
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			l.Diff[k] = &AuditChange{From: nil, To: v}
+		}
+	}
+
+	return l, nil
+}
+
+// auditSnapshot returns the JSON representation of v as a map
+func auditSnapshot(v interface{}) (map[string]interface{}, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := map[string]interface{}{}
+	err = json.Unmarshal(b, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func (l *AuditLog) GetBSON() (interface{}, error) {
+	return &AuditLogRecord{
+		ID:        l.ID,
+		Actor:     l.Actor.Hex(),
+		Action:    l.Action,
+		Target:    l.Target,
+		Before:    l.Before,
+		After:     l.After,
+		Diff:      l.Diff,
+		CreatedAt: l.CreatedAt,
+	}, nil
+}
+
+func (l *AuditLog) SetBSON(raw bson.Raw) error {
+	decoded := &AuditLogRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	l.ID = decoded.ID
+	l.Actor = common.HexToAddress(decoded.Actor)
+	l.Action = decoded.Action
+	l.Target = decoded.Target
+	l.Before = decoded.Before
+	l.After = decoded.After
+	l.Diff = decoded.Diff
+	l.CreatedAt = decoded.CreatedAt
+	return nil
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	actor := common.HexToAddress("0x1")
+	before := &Token{Symbol: "ZRX", Address: common.HexToAddress("0x2"), Listed: true, Active: true}
+	after := *before
+	after.Listed = false
+
+	l, err := NewAuditLog(actor, AuditActionUpdateTokenStatus, "token:0x2", before, &after)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, actor, l.Actor)
+	assert.Equal(t, true, l.Before["listed"])
+	assert.Equal(t, false, l.After["listed"])
+	assert.Equal(t, map[string]*AuditChange{"listed": {From: true, To: false}}, l.Diff)
+}
+
+func TestNewAuditLogMissingTarget(t *testing.T) {
+	var before *FeeOverride
+	after := map[string]string{"name": "gold"}
+
+	l, err := NewAuditLog(common.HexToAddress("0x1"), AuditActionCreateFeeTier, "feeTier", before, after)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Nil(t, l.Before)
+	assert.Equal(t, map[string]*AuditChange{"name": {From: nil, To: "gold"}}, l.Diff)
+
+	l, err = NewAuditLog(common.HexToAddress("0x1"), AuditActionDeleteFeeTier, "feeTier", after, nil)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Nil(t, l.After)
+	assert.Equal(t, map[string]*AuditChange{"name": {From: "gold", To: nil}}, l.Diff)
+}
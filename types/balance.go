@@ -0,0 +1,17 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BalanceSubscription is sent to subscribe to the balance updates of an account. Balances are
+// private: the connection must be logged in as the account owner.
+type BalanceSubscription struct {
+	Address common.Address `json:"address"`
+}
+
+// BalanceUpdate is sent to the account owner whenever a token balance or allowance changes
+type BalanceUpdate struct {
+	Address      common.Address `json:"address"`
+	TokenBalance *TokenBalance  `json:"tokenBalance"`
+}
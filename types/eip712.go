@@ -0,0 +1,111 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Orders and cancels can be signed with two schemes. The legacy scheme signs the opaque order hash
+// with the "\x19Ethereum Signed Message" prefix (personal_sign). The EIP-712 scheme signs the
+// typed structured data (eth_signTypedData) so that wallets can display the order fields to the
+// user. In both cases the order hash computed by ComputeHash remains the order identifier.
+// Settling EIP-712 signed orders requires an exchange contract that verifies typed data signatures,
+// orders signed as typed data are only accepted by the exchange contracts listed in the
+// eip712_exchange_addresses configuration.
+const (
+	SignatureVersionLegacy = 0
+	SignatureVersionEIP712 = 1
+)
+
+const (
+	EIP712DomainName    = "AMP Exchange"
+	EIP712DomainVersion = "1"
+)
+
+var (
+	eip712DomainTypeHash = crypto.Keccak256Hash([]byte(
+		"EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)",
+	))
+
+	orderTypeHash = crypto.Keccak256Hash([]byte(
+		"Order(address userAddress,address baseToken,address quoteToken,uint256 amount,uint256 pricepoint,string side,uint256 nonce,uint256 makeFee,uint256 takeFee)",
+	))
+
+	orderCancelTypeHash = crypto.Keccak256Hash([]byte(
+		"OrderCancel(bytes32 orderHash)",
+	))
+)
+
+// EIP712Domain binds typed data signatures to an exchange contract deployment
+type EIP712Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// NewEIP712Domain returns the domain of the given exchange contract on the configured chain
+func NewEIP712Domain(exchange common.Address) *EIP712Domain {
+	return &EIP712Domain{
+		Name:              EIP712DomainName,
+		Version:           EIP712DomainVersion,
+		ChainID:           big.NewInt(app.Config.ChainID),
+		VerifyingContract: exchange,
+	}
+}
+
+// Separator returns the EIP-712 domain separator
+func (d *EIP712Domain) Separator() common.Hash {
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(d.Name)),
+		crypto.Keccak256([]byte(d.Version)),
+		common.BigToHash(d.ChainID).Bytes(),
+		common.LeftPadBytes(d.VerifyingContract.Bytes(), 32),
+	)
+}
+
+// Digest returns the EIP-712 digest of a struct hash in this domain. This digest is what
+// eth_signTypedData signs.
+func (d *EIP712Domain) Digest(structHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(
+		[]byte("\x19\x01"),
+		d.Separator().Bytes(),
+		structHash.Bytes(),
+	)
+}
+
+// TypedDataHash returns the EIP-712 struct hash of the order
+func (o *Order) TypedDataHash() common.Hash {
+	return crypto.Keccak256Hash(
+		orderTypeHash.Bytes(),
+		common.LeftPadBytes(o.UserAddress.Bytes(), 32),
+		common.LeftPadBytes(o.BaseToken.Bytes(), 32),
+		common.LeftPadBytes(o.QuoteToken.Bytes(), 32),
+		common.BigToHash(o.Amount).Bytes(),
+		common.BigToHash(o.PricePoint).Bytes(),
+		crypto.Keccak256([]byte(o.Side)),
+		common.BigToHash(o.Nonce).Bytes(),
+		common.BigToHash(o.MakeFee).Bytes(),
+		common.BigToHash(o.TakeFee).Bytes(),
+	)
+}
+
+// TypedDataHash returns the EIP-712 struct hash of the order cancel
+func (oc *OrderCancel) TypedDataHash() common.Hash {
+	return crypto.Keccak256Hash(
+		orderCancelTypeHash.Bytes(),
+		oc.OrderHash.Bytes(),
+	)
+}
+
+// personalMessageHash returns the hash signed by personal_sign for a 32 bytes message
+func personalMessageHash(h common.Hash) common.Hash {
+	return crypto.Keccak256Hash(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		h.Bytes(),
+	)
+}
@@ -0,0 +1,155 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// The vectors below were computed with an independent keccak256 and secp256k1 implementation
+func eip712TestOrder() *Order {
+	amount, _ := new(big.Int).SetString("1000000000000000000", 10)
+
+	return &Order{
+		ExchangeAddress:  common.HexToAddress("0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"),
+		ChainID:          1,
+		UserAddress:      common.HexToAddress("0xE8E84ee367BC63ddB38d3D01bCCEF106c194dc47"),
+		BaseToken:        common.HexToAddress("0x24c7db6f5da8310212c0ce7a2a390bedad37c829"),
+		QuoteToken:       common.HexToAddress("0x4bc89ac6f1c55ea645294f3fed949813a768ac6d"),
+		Amount:           amount,
+		PricePoint:       big.NewInt(100),
+		Side:             "BUY",
+		Nonce:            big.NewInt(1),
+		MakeFee:          big.NewInt(1),
+		TakeFee:          big.NewInt(1),
+		SignatureVersion: SignatureVersionEIP712,
+	}
+}
+
+func TestEIP712Domain(t *testing.T) {
+	app.Config.ChainID = 1
+	d := NewEIP712Domain(common.HexToAddress("0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"))
+
+	assert.Equal(t, common.HexToHash("0x8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f"), eip712DomainTypeHash)
+	assert.Equal(t, common.HexToHash("0x306ebb4048d7524fe30454b0b71c6e04093d49ea549e2f6f575e14f3e20bbe39"), orderTypeHash)
+	assert.Equal(t, common.HexToHash("0x66232a1ff744a3c7f88190c3041d071928f641910986fca3bc11cc338f5f9bb6"), orderCancelTypeHash)
+	assert.Equal(t, common.HexToHash("0x05e8547f01a446b3c67e21b6cf0877261fe54699139f7db676aeb18bc1ad8f5d"), d.Separator())
+
+	// the chain ID is part of the domain
+	app.Config.ChainID = 3
+	assert.NotEqual(t, d.Separator(), NewEIP712Domain(d.VerifyingContract).Separator())
+	app.Config.ChainID = 1
+}
+
+func TestOrderEIP712Signature(t *testing.T) {
+	app.Config.ChainID = 1
+	w := NewWalletFromPrivateKey("7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660")
+	o := eip712TestOrder()
+
+	assert.Equal(t, common.HexToHash("0xb035305ee2f535839bd4fbf5024a5d6df958592bcac245ffbce872a624107049"), o.TypedDataHash())
+	assert.Equal(t, common.HexToHash("0xf81c158e8e2ecdf167c8883abf4399cafdb84c3f0e0517fa3208404dc19de85f"), o.SigningHash())
+
+	err := o.Sign(w)
+	if err != nil {
+		t.Errorf("Could not sign order: %v", err)
+	}
+
+	// the order hash remains the order identifier whatever the signature version
+	assert.Equal(t, common.HexToHash("0xc5a49070cfd44840434004013d024553a956646fe13282bb8ad2d1128cf9894c"), o.Hash)
+	assert.Equal(t, &Signature{
+		V: 28,
+		R: common.HexToHash("0xca44ff722530fe78a6504e0c624dab6cec561af1a1d9272c85edc672d149063a"),
+		S: common.HexToHash("0x1144bb2dcaa9facb0e64f6804d1978cb8bbc2ea51b8f7fd9afc897015266b294"),
+	}, o.Signature)
+
+	valid, err := o.VerifySignature()
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	// a typed data signature is not a valid legacy signature
+	o.SignatureVersion = SignatureVersionLegacy
+	valid, _ = o.VerifySignature()
+	assert.False(t, valid)
+
+	err = o.Sign(w)
+	if err != nil {
+		t.Errorf("Could not sign order: %v", err)
+	}
+
+	valid, err = o.VerifySignature()
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	o.SignatureVersion = SignatureVersionEIP712
+	valid, _ = o.VerifySignature()
+	assert.False(t, valid)
+}
+
+func TestOrderCancelEIP712Signature(t *testing.T) {
+	app.Config.ChainID = 1
+	app.Config.Ethereum = map[string]string{"exchange_address": "0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"}
+
+	w := NewWalletFromPrivateKey("7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660")
+	o := eip712TestOrder()
+	o.Hash = o.ComputeHash()
+
+	oc := &OrderCancel{OrderHash: o.Hash, ChainID: 1, SignatureVersion: SignatureVersionEIP712}
+	assert.Equal(t, common.HexToHash("0xb8cd645d5d3bbed685d2b7c8931606f3ddad3c30117e2cb0b582e8e8b8a2edb4"), oc.TypedDataHash())
+	assert.Equal(t, common.HexToHash("0x1a3ed14548454c368974ca6d36527367358ef9777f82755f16a55e469e53d8b6"), oc.SigningHash())
+
+	err := oc.Sign(w)
+	if err != nil {
+		t.Errorf("Could not sign order cancel: %v", err)
+	}
+
+	assert.Equal(t, common.HexToHash("0xb7d1bd3648a9380412fc7efede905d3c04a4523a4bb4cc4ca3deed17ce58848d"), oc.Hash)
+	assert.Equal(t, &Signature{
+		V: 28,
+		R: common.HexToHash("0x6cf899b1157c5432b54951afce26361f594687780bbb9fcf6cb6a46e7e25b390"),
+		S: common.HexToHash("0x6bd77450412e068c6242df740ded142c46d91ff17fffa3f01ebe4f9deb1ff49f"),
+	}, oc.Signature)
+
+	valid, err := oc.VerifySignature(o)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	sender, err := oc.GetSenderAddress()
+	assert.Nil(t, err)
+	assert.Equal(t, w.Address, sender)
+
+	oc.SignatureVersion = SignatureVersionLegacy
+	valid, _ = oc.VerifySignature(o)
+	assert.False(t, valid)
+}
+
+func TestOrderEIP712Exchange(t *testing.T) {
+	app.Config.ChainID = 1
+	app.Config.Ethereum = map[string]string{"exchange_address": "0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"}
+	defer func() { app.Config.EIP712ExchangeAddresses = nil }()
+
+	w := NewWalletFromPrivateKey("7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660")
+	o := eip712TestOrder()
+	o.Sign(w)
+
+	// the exchange contract cannot verify typed data signatures
+	app.Config.EIP712ExchangeAddresses = nil
+	err := o.Validate()
+	assert.EqualError(t, err, "Order 'signatureVersion' is not supported by the exchange contract")
+
+	app.Config.EIP712ExchangeAddresses = []string{"0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"}
+	err = o.Validate()
+	assert.Nil(t, err)
+
+	// legacy signatures are accepted by every exchange contract while no chain ID is configured
+	app.Config.EIP712ExchangeAddresses = nil
+	app.Config.ChainID = 0
+	defer func() { app.Config.ChainID = 1 }()
+	o.SignatureVersion = SignatureVersionLegacy
+	o.Sign(w)
+
+	err = o.Validate()
+	assert.Nil(t, err)
+}
@@ -42,8 +42,18 @@ func (m *Matches) String() string {
 	return fmt.Sprintf("%v: %v", m.TakerOrder.PairName, m.TakerOrder.Hash.Hex())
 }
 
+// PairCode returns the code of the pair of the matches. Matches without taker order (resting
+// orders invalidated on their own) take the pair of their first maker order.
 func (m *Matches) PairCode() (string, error) {
-	return m.TakerOrder.PairCode()
+	if m.TakerOrder != nil {
+		return m.TakerOrder.PairCode()
+	}
+
+	if len(m.MakerOrders) == 0 {
+		return "", errors.New("Matches without order")
+	}
+
+	return m.MakerOrders[0].PairCode()
 }
 
 func (m *Matches) TradeAmounts() []*big.Int {
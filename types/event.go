@@ -0,0 +1,215 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/utils/math"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+)
+
+// ContractEvent is a decoded event (log) emitted by the exchange smart contract.
+// Depending on the event type (LogTrade, LogBatchTrades or LogError) only a subset
+// of the fields are set.
+// The TxHash and LogIndex uniquely identify an event.
+// Removed is set in the case the event was removed from the canonical chain because of
+// a chain reorganisation.
+type ContractEvent struct {
+	ID               bson.ObjectId  `json:"id,omitempty" bson:"_id"`
+	Type             string         `json:"type" bson:"type"`
+	ContractAddress  common.Address `json:"contractAddress" bson:"contractAddress"`
+	BlockNumber      uint64         `json:"blockNumber" bson:"blockNumber"`
+	BlockHash        common.Hash    `json:"blockHash" bson:"blockHash"`
+	TxHash           common.Hash    `json:"txHash" bson:"txHash"`
+	LogIndex         uint           `json:"logIndex" bson:"logIndex"`
+	Removed          bool           `json:"removed" bson:"removed"`
+	Maker            common.Address `json:"maker,omitempty" bson:"maker"`
+	Taker            common.Address `json:"taker,omitempty" bson:"taker"`
+	TokenSell        common.Address `json:"tokenSell,omitempty" bson:"tokenSell"`
+	TokenBuy         common.Address `json:"tokenBuy,omitempty" bson:"tokenBuy"`
+	FilledAmountSell *big.Int       `json:"filledAmountSell,omitempty" bson:"filledAmountSell"`
+	FilledAmountBuy  *big.Int       `json:"filledAmountBuy,omitempty" bson:"filledAmountBuy"`
+	PaidFeeMake      *big.Int       `json:"paidFeeMake,omitempty" bson:"paidFeeMake"`
+	PaidFeeTake      *big.Int       `json:"paidFeeTake,omitempty" bson:"paidFeeTake"`
+	OrderHash        common.Hash    `json:"orderHash,omitempty" bson:"orderHash"`
+	TradeHash        common.Hash    `json:"tradeHash,omitempty" bson:"tradeHash"`
+	TokenPairHash    common.Hash    `json:"tokenPairHash,omitempty" bson:"tokenPairHash"`
+	MakerOrderHashes []common.Hash  `json:"makerOrderHashes,omitempty" bson:"makerOrderHashes"`
+	TakerOrderHashes []common.Hash  `json:"takerOrderHashes,omitempty" bson:"takerOrderHashes"`
+	ErrorID          uint8          `json:"errorId,omitempty" bson:"errorId"`
+	MakerOrderHash   common.Hash    `json:"makerOrderHash,omitempty" bson:"makerOrderHash"`
+	TakerOrderHash   common.Hash    `json:"takerOrderHash,omitempty" bson:"takerOrderHash"`
+	CreatedAt        time.Time      `json:"createdAt" bson:"createdAt"`
+}
+
+type ContractEventRecord struct {
+	ID               bson.ObjectId `json:"id" bson:"_id"`
+	Type             string        `json:"type" bson:"type"`
+	ContractAddress  string        `json:"contractAddress" bson:"contractAddress"`
+	BlockNumber      int64         `json:"blockNumber" bson:"blockNumber"`
+	BlockHash        string        `json:"blockHash" bson:"blockHash"`
+	TxHash           string        `json:"txHash" bson:"txHash"`
+	LogIndex         int           `json:"logIndex" bson:"logIndex"`
+	Removed          bool          `json:"removed" bson:"removed"`
+	Maker            string        `json:"maker,omitempty" bson:"maker,omitempty"`
+	Taker            string        `json:"taker,omitempty" bson:"taker,omitempty"`
+	TokenSell        string        `json:"tokenSell,omitempty" bson:"tokenSell,omitempty"`
+	TokenBuy         string        `json:"tokenBuy,omitempty" bson:"tokenBuy,omitempty"`
+	FilledAmountSell string        `json:"filledAmountSell,omitempty" bson:"filledAmountSell,omitempty"`
+	FilledAmountBuy  string        `json:"filledAmountBuy,omitempty" bson:"filledAmountBuy,omitempty"`
+	PaidFeeMake      string        `json:"paidFeeMake,omitempty" bson:"paidFeeMake,omitempty"`
+	PaidFeeTake      string        `json:"paidFeeTake,omitempty" bson:"paidFeeTake,omitempty"`
+	OrderHash        string        `json:"orderHash,omitempty" bson:"orderHash,omitempty"`
+	TradeHash        string        `json:"tradeHash,omitempty" bson:"tradeHash,omitempty"`
+	TokenPairHash    string        `json:"tokenPairHash,omitempty" bson:"tokenPairHash,omitempty"`
+	MakerOrderHashes []string      `json:"makerOrderHashes,omitempty" bson:"makerOrderHashes,omitempty"`
+	TakerOrderHashes []string      `json:"takerOrderHashes,omitempty" bson:"takerOrderHashes,omitempty"`
+	ErrorID          int           `json:"errorId,omitempty" bson:"errorId,omitempty"`
+	MakerOrderHash   string        `json:"makerOrderHash,omitempty" bson:"makerOrderHash,omitempty"`
+	TakerOrderHash   string        `json:"takerOrderHash,omitempty" bson:"takerOrderHash,omitempty"`
+	CreatedAt        time.Time     `json:"createdAt" bson:"createdAt"`
+}
+
+// MarshalJSON returns the json encoded byte array representing the contract event struct
+func (e *ContractEvent) MarshalJSON() ([]byte, error) {
+	record, err := e.GetBSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(record)
+}
+
+func (e *ContractEvent) GetBSON() (interface{}, error) {
+	er := ContractEventRecord{
+		ID:              e.ID,
+		Type:            e.Type,
+		ContractAddress: e.ContractAddress.Hex(),
+		BlockNumber:     int64(e.BlockNumber),
+		BlockHash:       e.BlockHash.Hex(),
+		TxHash:          e.TxHash.Hex(),
+		LogIndex:        int(e.LogIndex),
+		Removed:         e.Removed,
+		ErrorID:         int(e.ErrorID),
+		CreatedAt:       e.CreatedAt,
+	}
+
+	if (e.Maker != common.Address{}) {
+		er.Maker = e.Maker.Hex()
+	}
+
+	if (e.Taker != common.Address{}) {
+		er.Taker = e.Taker.Hex()
+	}
+
+	if (e.TokenSell != common.Address{}) {
+		er.TokenSell = e.TokenSell.Hex()
+	}
+
+	if (e.TokenBuy != common.Address{}) {
+		er.TokenBuy = e.TokenBuy.Hex()
+	}
+
+	if e.FilledAmountSell != nil {
+		er.FilledAmountSell = e.FilledAmountSell.String()
+	}
+
+	if e.FilledAmountBuy != nil {
+		er.FilledAmountBuy = e.FilledAmountBuy.String()
+	}
+
+	if e.PaidFeeMake != nil {
+		er.PaidFeeMake = e.PaidFeeMake.String()
+	}
+
+	if e.PaidFeeTake != nil {
+		er.PaidFeeTake = e.PaidFeeTake.String()
+	}
+
+	if (e.OrderHash != common.Hash{}) {
+		er.OrderHash = e.OrderHash.Hex()
+	}
+
+	if (e.TradeHash != common.Hash{}) {
+		er.TradeHash = e.TradeHash.Hex()
+	}
+
+	if (e.TokenPairHash != common.Hash{}) {
+		er.TokenPairHash = e.TokenPairHash.Hex()
+	}
+
+	if (e.MakerOrderHash != common.Hash{}) {
+		er.MakerOrderHash = e.MakerOrderHash.Hex()
+	}
+
+	if (e.TakerOrderHash != common.Hash{}) {
+		er.TakerOrderHash = e.TakerOrderHash.Hex()
+	}
+
+	for _, h := range e.MakerOrderHashes {
+		er.MakerOrderHashes = append(er.MakerOrderHashes, h.Hex())
+	}
+
+	for _, h := range e.TakerOrderHashes {
+		er.TakerOrderHashes = append(er.TakerOrderHashes, h.Hex())
+	}
+
+	return er, nil
+}
+
+func (e *ContractEvent) SetBSON(raw bson.Raw) error {
+	decoded := &ContractEventRecord{}
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	e.ID = decoded.ID
+	e.Type = decoded.Type
+	e.ContractAddress = common.HexToAddress(decoded.ContractAddress)
+	e.BlockNumber = uint64(decoded.BlockNumber)
+	e.BlockHash = common.HexToHash(decoded.BlockHash)
+	e.TxHash = common.HexToHash(decoded.TxHash)
+	e.LogIndex = uint(decoded.LogIndex)
+	e.Removed = decoded.Removed
+	e.Maker = common.HexToAddress(decoded.Maker)
+	e.Taker = common.HexToAddress(decoded.Taker)
+	e.TokenSell = common.HexToAddress(decoded.TokenSell)
+	e.TokenBuy = common.HexToAddress(decoded.TokenBuy)
+	e.OrderHash = common.HexToHash(decoded.OrderHash)
+	e.TradeHash = common.HexToHash(decoded.TradeHash)
+	e.TokenPairHash = common.HexToHash(decoded.TokenPairHash)
+	e.ErrorID = uint8(decoded.ErrorID)
+	e.MakerOrderHash = common.HexToHash(decoded.MakerOrderHash)
+	e.TakerOrderHash = common.HexToHash(decoded.TakerOrderHash)
+	e.CreatedAt = decoded.CreatedAt
+
+	if decoded.FilledAmountSell != "" {
+		e.FilledAmountSell = math.ToBigInt(decoded.FilledAmountSell)
+	}
+
+	if decoded.FilledAmountBuy != "" {
+		e.FilledAmountBuy = math.ToBigInt(decoded.FilledAmountBuy)
+	}
+
+	if decoded.PaidFeeMake != "" {
+		e.PaidFeeMake = math.ToBigInt(decoded.PaidFeeMake)
+	}
+
+	if decoded.PaidFeeTake != "" {
+		e.PaidFeeTake = math.ToBigInt(decoded.PaidFeeTake)
+	}
+
+	for _, h := range decoded.MakerOrderHashes {
+		e.MakerOrderHashes = append(e.MakerOrderHashes, common.HexToHash(h))
+	}
+
+	for _, h := range decoded.TakerOrderHashes {
+		e.TakerOrderHashes = append(e.TakerOrderHashes, common.HexToHash(h))
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/Proofsuite/amp-matching-engine/app"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultExchangeAddress returns the address of the default exchange contract. Pairs that are
+// not bound to a specific exchange contract are settled on this contract.
+func DefaultExchangeAddress() common.Address {
+	return common.HexToAddress(app.Config.Ethereum["exchange_address"])
+}
+
+// ExchangeAddresses returns the addresses of all the configured exchange contracts,
+// starting with the default exchange contract
+func ExchangeAddresses() []common.Address {
+	addresses := []common.Address{}
+	for _, a := range app.Config.Exchanges() {
+		addresses = append(addresses, common.HexToAddress(a))
+	}
+
+	return addresses
+}
+
+// IsExchangeAddress returns true if the given address is one of the configured exchange contracts
+func IsExchangeAddress(a common.Address) bool {
+	for _, ex := range ExchangeAddresses() {
+		if ex == a {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SupportsEIP712 returns true if the given exchange contract is configured as verifying EIP-712
+// typed data signatures. The settlement transactions (see batchTradesArguments in the contracts
+// package) do not send the signature scheme of the orders: each exchange contract verifies the
+// signatures with its own scheme. Orders signed as typed data can therefore only be accepted on
+// the pairs bound to an exchange contract listed here.
+func SupportsEIP712(a common.Address) bool {
+	for _, ex := range app.Config.EIP712ExchangeAddresses {
+		ex = strings.TrimSpace(ex)
+		if common.IsHexAddress(ex) && common.HexToAddress(ex) == a {
+			return true
+		}
+	}
+
+	return false
+}
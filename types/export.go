@@ -0,0 +1,188 @@
+package types
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Trade roles of an account in an exported trade
+const (
+	TradeRoleMaker = "MAKER"
+	TradeRoleTaker = "TAKER"
+)
+
+// OrderExportHeader is the CSV header of an order history export
+var OrderExportHeader = []string{
+	"createdAt",
+	"updatedAt",
+	"hash",
+	"clientOrderId",
+	"pairName",
+	"side",
+	"status",
+	"price",
+	"amount",
+	"filledAmount",
+	"makeFee",
+	"takeFee",
+}
+
+// TradeExportHeader is the CSV header of a trade history export
+var TradeExportHeader = []string{
+	"createdAt",
+	"hash",
+	"txHash",
+	"pairName",
+	"role",
+	"side",
+	"orderHash",
+	"price",
+	"amount",
+	"fee",
+	"status",
+}
+
+// OrderExport is an order of an account history export. Amounts and prices are
+// converted to decimal values with the token decimals of the order pair.
+type OrderExport struct {
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+	Hash          common.Hash `json:"hash"`
+	ClientOrderID string      `json:"clientOrderId,omitempty"`
+	PairName      string      `json:"pairName"`
+	Side          string      `json:"side"`
+	Status        string      `json:"status"`
+	Price         float64     `json:"price"`
+	Amount        float64     `json:"amount"`
+	FilledAmount  float64     `json:"filledAmount"`
+	MakeFee       float64     `json:"makeFee"`
+	TakeFee       float64     `json:"takeFee"`
+}
+
+// NewOrderExport returns the export of an order of the pair p
+func NewOrderExport(o *Order, p *Pair) *OrderExport {
+	return &OrderExport{
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+		Hash:          o.Hash,
+		ClientOrderID: o.ClientOrderID,
+		PairName:      p.Name(),
+		Side:          o.Side,
+		Status:        o.Status,
+		Price:         parseOrZero(p.ParsePricePoint, o.PricePoint),
+		Amount:        parseOrZero(p.ParseAmount, o.Amount),
+		FilledAmount:  parseOrZero(p.ParseAmount, o.FilledAmount),
+		MakeFee:       parseOrZero(p.ParseQuoteAmount, o.MakeFee),
+		TakeFee:       parseOrZero(p.ParseQuoteAmount, o.TakeFee),
+	}
+}
+
+// CSVRecord returns the CSV fields of the order in the order of OrderExportHeader
+func (e *OrderExport) CSVRecord() []string {
+	return []string{
+		formatExportTime(e.CreatedAt),
+		formatExportTime(e.UpdatedAt),
+		e.Hash.Hex(),
+		e.ClientOrderID,
+		e.PairName,
+		e.Side,
+		e.Status,
+		formatExportFloat(e.Price),
+		formatExportFloat(e.Amount),
+		formatExportFloat(e.FilledAmount),
+		formatExportFloat(e.MakeFee),
+		formatExportFloat(e.TakeFee),
+	}
+}
+
+// TradeExport is a trade of an account history export, seen from the account side.
+// The side, order hash and fee are the ones of the account order (the maker order
+// if the role is MAKER, the taker order otherwise).
+type TradeExport struct {
+	CreatedAt time.Time   `json:"createdAt"`
+	Hash      common.Hash `json:"hash"`
+	TxHash    common.Hash `json:"txHash"`
+	PairName  string      `json:"pairName"`
+	Role      string      `json:"role"`
+	Side      string      `json:"side"`
+	OrderHash common.Hash `json:"orderHash"`
+	Price     float64     `json:"price"`
+	Amount    float64     `json:"amount"`
+	Fee       float64     `json:"fee"`
+	Status    string      `json:"status"`
+}
+
+// NewTradeExport returns the export of a trade of the pair p for the account order o.
+// role is either TradeRoleMaker or TradeRoleTaker. The side and fee are left empty if
+// the account order is nil.
+func NewTradeExport(t *Trade, p *Pair, o *Order, role string) *TradeExport {
+	e := &TradeExport{
+		CreatedAt: t.CreatedAt,
+		Hash:      t.Hash,
+		TxHash:    t.TxHash,
+		PairName:  p.Name(),
+		Role:      role,
+		OrderHash: t.TakerOrderHash,
+		Price:     parseOrZero(p.ParsePricePoint, t.PricePoint),
+		Amount:    parseOrZero(p.ParseAmount, t.Amount),
+		Status:    t.Status,
+	}
+
+	if role == TradeRoleMaker {
+		e.OrderHash = t.MakerOrderHash
+	}
+
+	if o == nil {
+		return e
+	}
+
+	fee := o.TakeFee
+	if role == TradeRoleMaker {
+		fee = o.MakeFee
+	}
+
+	e.Side = o.Side
+	e.Fee = parseOrZero(p.ParseQuoteAmount, fee)
+	return e
+}
+
+// CSVRecord returns the CSV fields of the trade in the order of TradeExportHeader
+func (e *TradeExport) CSVRecord() []string {
+	txHash := ""
+	if e.TxHash != (common.Hash{}) {
+		txHash = e.TxHash.Hex()
+	}
+
+	return []string{
+		formatExportTime(e.CreatedAt),
+		e.Hash.Hex(),
+		txHash,
+		e.PairName,
+		e.Role,
+		e.Side,
+		e.OrderHash.Hex(),
+		formatExportFloat(e.Price),
+		formatExportFloat(e.Amount),
+		formatExportFloat(e.Fee),
+		e.Status,
+	}
+}
+
+func parseOrZero(parse func(*big.Int) float64, a *big.Int) float64 {
+	if a == nil {
+		return 0
+	}
+
+	return parse(a)
+}
+
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatExportFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,343 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/utils/math"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+)
+
+// FeeVolumePeriod is the trailing period over which the trading volume of an account
+// is computed to determine its fee tier
+const FeeVolumePeriod = 30 * 24 * time.Hour
+
+// FeeTier defines the fees applicable to the accounts whose trailing 30 days trading volume
+// on the pairs of a quote token is at least MinVolume. Volumes and fees are expressed in
+// quote token units.
+type FeeTier struct {
+	ID         bson.ObjectId  `json:"id,omitempty" bson:"_id"`
+	Name       string         `json:"name" bson:"name"`
+	QuoteToken common.Address `json:"quoteToken" bson:"quoteToken"`
+	MinVolume  *big.Int       `json:"minVolume" bson:"minVolume"`
+	MakeFee    *big.Int       `json:"makeFee" bson:"makeFee"`
+	TakeFee    *big.Int       `json:"takeFee" bson:"takeFee"`
+	CreatedAt  time.Time      `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
+
+type FeeTierRecord struct {
+	ID         bson.ObjectId `json:"id" bson:"_id"`
+	Name       string        `json:"name" bson:"name"`
+	QuoteToken string        `json:"quoteToken" bson:"quoteToken"`
+	MinVolume  string        `json:"minVolume" bson:"minVolume"`
+	MakeFee    string        `json:"makeFee" bson:"makeFee"`
+	TakeFee    string        `json:"takeFee" bson:"takeFee"`
+	CreatedAt  time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  time.Time     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// FeeOverride sets the fees of an account on the pairs of a quote token regardless of its
+// trading volume (eg. for market makers)
+type FeeOverride struct {
+	ID          bson.ObjectId  `json:"id,omitempty" bson:"_id"`
+	UserAddress common.Address `json:"userAddress" bson:"userAddress"`
+	QuoteToken  common.Address `json:"quoteToken" bson:"quoteToken"`
+	MakeFee     *big.Int       `json:"makeFee" bson:"makeFee"`
+	TakeFee     *big.Int       `json:"takeFee" bson:"takeFee"`
+	CreatedAt   time.Time      `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
+
+type FeeOverrideRecord struct {
+	ID          bson.ObjectId `json:"id" bson:"_id"`
+	UserAddress string        `json:"userAddress" bson:"userAddress"`
+	QuoteToken  string        `json:"quoteToken" bson:"quoteToken"`
+	MakeFee     string        `json:"makeFee" bson:"makeFee"`
+	TakeFee     string        `json:"takeFee" bson:"takeFee"`
+	CreatedAt   time.Time     `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt" bson:"updatedAt"`
+}
+
+// AccountFees is the fee schedule applicable to an account on a pair. The fees of the
+// account orders must be equal to MakeFee and TakeFee. Tier is empty when the account
+// has not reached any tier, in which case the pair fees apply.
+type AccountFees struct {
+	UserAddress common.Address
+	BaseToken   common.Address
+	QuoteToken  common.Address
+	Volume      *big.Int
+	Tier        string
+	Override    bool
+	MakeFee     *big.Int
+	TakeFee     *big.Int
+}
+
+// validateFees checks the make and take fees of a fee tier or a fee override. The exchange
+// contract takes fees as uint256 values, maker rebates (negative fees) are therefore not supported.
+func validateFees(makeFee, takeFee *big.Int) error {
+	if makeFee == nil {
+		return errors.New("'makeFee' parameter is required")
+	}
+
+	if takeFee == nil {
+		return errors.New("'takeFee' parameter is required")
+	}
+
+	if makeFee.Sign() < 0 || takeFee.Sign() < 0 {
+		return errors.New("Negative fees (maker rebates) are not supported by the exchange contract")
+	}
+
+	return nil
+}
+
+// Validate checks the fee tier parameters
+func (t *FeeTier) Validate() error {
+	if t.Name == "" {
+		return errors.New("Fee tier 'name' parameter is required")
+	}
+
+	if (t.QuoteToken == common.Address{}) {
+		return errors.New("Fee tier 'quoteToken' parameter is required")
+	}
+
+	if t.MinVolume == nil || t.MinVolume.Sign() < 0 {
+		return errors.New("Fee tier 'minVolume' parameter should be positive")
+	}
+
+	return validateFees(t.MakeFee, t.TakeFee)
+}
+
+// Validate checks the fee override parameters
+func (o *FeeOverride) Validate() error {
+	if (o.UserAddress == common.Address{}) {
+		return errors.New("Fee override 'userAddress' parameter is required")
+	}
+
+	if (o.QuoteToken == common.Address{}) {
+		return errors.New("Fee override 'quoteToken' parameter is required")
+	}
+
+	return validateFees(o.MakeFee, o.TakeFee)
+}
+
+// SelectFeeTier returns the tier with the highest minimum volume reached by volume,
+// or nil if no tier is reached
+func SelectFeeTier(tiers []*FeeTier, volume *big.Int) *FeeTier {
+	var selected *FeeTier
+	for _, t := range tiers {
+		if math.IsEqualOrGreaterThan(volume, t.MinVolume) {
+			if selected == nil || math.IsStrictlyGreaterThan(t.MinVolume, selected.MinVolume) {
+				selected = t
+			}
+		}
+	}
+
+	return selected
+}
+
+func (t *FeeTier) MarshalJSON() ([]byte, error) {
+	tier := map[string]interface{}{
+		"name":       t.Name,
+		"quoteToken": t.QuoteToken.Hex(),
+		"createdAt":  t.CreatedAt.Format(time.RFC3339Nano),
+		"updatedAt":  t.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	if t.ID != "" {
+		tier["id"] = t.ID.Hex()
+	}
+
+	if t.MinVolume != nil {
+		tier["minVolume"] = t.MinVolume.String()
+	}
+
+	if t.MakeFee != nil {
+		tier["makeFee"] = t.MakeFee.String()
+	}
+
+	if t.TakeFee != nil {
+		tier["takeFee"] = t.TakeFee.String()
+	}
+
+	return json.Marshal(tier)
+}
+
+func (t *FeeTier) UnmarshalJSON(b []byte) error {
+	tier := map[string]interface{}{}
+
+	err := json.Unmarshal(b, &tier)
+	if err != nil {
+		return err
+	}
+
+	if tier["id"] != nil && bson.IsObjectIdHex(tier["id"].(string)) {
+		t.ID = bson.ObjectIdHex(tier["id"].(string))
+	}
+
+	if tier["name"] != nil {
+		t.Name = tier["name"].(string)
+	}
+
+	if tier["quoteToken"] != nil {
+		t.QuoteToken = common.HexToAddress(tier["quoteToken"].(string))
+	}
+
+	if tier["minVolume"] != nil {
+		t.MinVolume = math.ToBigInt(fmt.Sprintf("%v", tier["minVolume"]))
+	}
+
+	if tier["makeFee"] != nil {
+		t.MakeFee = math.ToBigInt(fmt.Sprintf("%v", tier["makeFee"]))
+	}
+
+	if tier["takeFee"] != nil {
+		t.TakeFee = math.ToBigInt(fmt.Sprintf("%v", tier["takeFee"]))
+	}
+
+	return nil
+}
+
+func (t *FeeTier) GetBSON() (interface{}, error) {
+	return FeeTierRecord{
+		ID:         t.ID,
+		Name:       t.Name,
+		QuoteToken: t.QuoteToken.Hex(),
+		MinVolume:  t.MinVolume.String(),
+		MakeFee:    t.MakeFee.String(),
+		TakeFee:    t.TakeFee.String(),
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+	}, nil
+}
+
+func (t *FeeTier) SetBSON(raw bson.Raw) error {
+	decoded := new(FeeTierRecord)
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	t.ID = decoded.ID
+	t.Name = decoded.Name
+	t.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	t.MinVolume = math.ToBigInt(decoded.MinVolume)
+	t.MakeFee = math.ToBigInt(decoded.MakeFee)
+	t.TakeFee = math.ToBigInt(decoded.TakeFee)
+	t.CreatedAt = decoded.CreatedAt
+	t.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
+
+func (o *FeeOverride) MarshalJSON() ([]byte, error) {
+	override := map[string]interface{}{
+		"userAddress": o.UserAddress.Hex(),
+		"quoteToken":  o.QuoteToken.Hex(),
+		"createdAt":   o.CreatedAt.Format(time.RFC3339Nano),
+		"updatedAt":   o.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	if o.ID != "" {
+		override["id"] = o.ID.Hex()
+	}
+
+	if o.MakeFee != nil {
+		override["makeFee"] = o.MakeFee.String()
+	}
+
+	if o.TakeFee != nil {
+		override["takeFee"] = o.TakeFee.String()
+	}
+
+	return json.Marshal(override)
+}
+
+func (o *FeeOverride) UnmarshalJSON(b []byte) error {
+	override := map[string]interface{}{}
+
+	err := json.Unmarshal(b, &override)
+	if err != nil {
+		return err
+	}
+
+	if override["id"] != nil && bson.IsObjectIdHex(override["id"].(string)) {
+		o.ID = bson.ObjectIdHex(override["id"].(string))
+	}
+
+	if override["userAddress"] != nil {
+		o.UserAddress = common.HexToAddress(override["userAddress"].(string))
+	}
+
+	if override["quoteToken"] != nil {
+		o.QuoteToken = common.HexToAddress(override["quoteToken"].(string))
+	}
+
+	if override["makeFee"] != nil {
+		o.MakeFee = math.ToBigInt(fmt.Sprintf("%v", override["makeFee"]))
+	}
+
+	if override["takeFee"] != nil {
+		o.TakeFee = math.ToBigInt(fmt.Sprintf("%v", override["takeFee"]))
+	}
+
+	return nil
+}
+
+func (o *FeeOverride) GetBSON() (interface{}, error) {
+	return FeeOverrideRecord{
+		ID:          o.ID,
+		UserAddress: o.UserAddress.Hex(),
+		QuoteToken:  o.QuoteToken.Hex(),
+		MakeFee:     o.MakeFee.String(),
+		TakeFee:     o.TakeFee.String(),
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}, nil
+}
+
+func (o *FeeOverride) SetBSON(raw bson.Raw) error {
+	decoded := new(FeeOverrideRecord)
+
+	err := raw.Unmarshal(decoded)
+	if err != nil {
+		return err
+	}
+
+	o.ID = decoded.ID
+	o.UserAddress = common.HexToAddress(decoded.UserAddress)
+	o.QuoteToken = common.HexToAddress(decoded.QuoteToken)
+	o.MakeFee = math.ToBigInt(decoded.MakeFee)
+	o.TakeFee = math.ToBigInt(decoded.TakeFee)
+	o.CreatedAt = decoded.CreatedAt
+	o.UpdatedAt = decoded.UpdatedAt
+	return nil
+}
+
+func (f *AccountFees) MarshalJSON() ([]byte, error) {
+	fees := map[string]interface{}{
+		"userAddress": f.UserAddress.Hex(),
+		"baseToken":   f.BaseToken.Hex(),
+		"quoteToken":  f.QuoteToken.Hex(),
+		"tier":        f.Tier,
+		"override":    f.Override,
+	}
+
+	if f.Volume != nil {
+		fees["volume"] = f.Volume.String()
+	}
+
+	if f.MakeFee != nil {
+		fees["makeFee"] = f.MakeFee.String()
+	}
+
+	if f.TakeFee != nil {
+		fees["takeFee"] = f.TakeFee.String()
+	}
+
+	return json.Marshal(fees)
+}
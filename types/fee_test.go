@@ -0,0 +1,57 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectFeeTier(t *testing.T) {
+	tiers := []*FeeTier{
+		{Name: "gold", MinVolume: big.NewInt(1000)},
+		{Name: "silver", MinVolume: big.NewInt(100)},
+		{Name: "platinum", MinVolume: big.NewInt(10000)},
+	}
+
+	assert.Nil(t, SelectFeeTier(tiers, big.NewInt(99)))
+	assert.Equal(t, "silver", SelectFeeTier(tiers, big.NewInt(100)).Name)
+	assert.Equal(t, "gold", SelectFeeTier(tiers, big.NewInt(9999)).Name)
+	assert.Equal(t, "platinum", SelectFeeTier(tiers, big.NewInt(20000)).Name)
+	assert.Nil(t, SelectFeeTier(nil, big.NewInt(20000)))
+}
+
+func TestFeeTierValidate(t *testing.T) {
+	tier := &FeeTier{
+		Name:       "silver",
+		QuoteToken: common.HexToAddress("0x1"),
+		MinVolume:  big.NewInt(100),
+		MakeFee:    big.NewInt(0),
+		TakeFee:    big.NewInt(10),
+	}
+
+	assert.Nil(t, tier.Validate())
+
+	tier.MakeFee = big.NewInt(-1)
+	assert.Error(t, tier.Validate())
+
+	override := &FeeOverride{
+		UserAddress: common.HexToAddress("0x2"),
+		QuoteToken:  common.HexToAddress("0x1"),
+		MakeFee:     big.NewInt(0),
+	}
+
+	assert.Error(t, override.Validate())
+
+	override.TakeFee = big.NewInt(1)
+	assert.Nil(t, override.Validate())
+}
+
+func TestOrderValidateFees(t *testing.T) {
+	o := &Order{MakeFee: big.NewInt(1), TakeFee: big.NewInt(2)}
+
+	assert.Nil(t, o.ValidateFees(big.NewInt(1), big.NewInt(2)))
+	assert.Error(t, o.ValidateFees(big.NewInt(0), big.NewInt(2)))
+	assert.Error(t, o.ValidateFees(big.NewInt(1), big.NewInt(0)))
+}
@@ -1,6 +1,15 @@
 package types
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type OperatorMessage struct {
 	MessageType string
@@ -16,6 +25,169 @@ func (m *OperatorMessage) String() string {
 	return fmt.Sprintf("%v: %v", m.MessageType, m.Matches.String())
 }
 
+// PendingTradeBatch groups the matches of one or several taker orders
+// that are settled together in a single executeBatchTrades transaction
 type PendingTradeBatch struct {
-	Matches *Matches
+	Matches []*Matches `json:"matches"`
+}
+
+func NewPendingTradeBatch(matches ...*Matches) *PendingTradeBatch {
+	return &PendingTradeBatch{
+		Matches: matches,
+	}
+}
+
+// With returns a new batch containing the matches of the batch followed by m.
+// The original batch is left unchanged
+func (b *PendingTradeBatch) With(m *Matches) *PendingTradeBatch {
+	matches := make([]*Matches, 0, len(b.Matches)+1)
+	matches = append(matches, b.Matches...)
+	matches = append(matches, m)
+
+	return NewPendingTradeBatch(matches...)
+}
+
+// ExchangeAddress returns the exchange contract settling the batch. All the matches of a batch
+// are settled on the same exchange contract
+func (b *PendingTradeBatch) ExchangeAddress() common.Address {
+	if len(b.Matches) == 0 || b.Matches[0].TakerOrder == nil {
+		return common.Address{}
+	}
+
+	return b.Matches[0].TakerOrder.ExchangeAddress
+}
+
+// Length returns the number of trades contained in the batch
+func (b *PendingTradeBatch) Length() int {
+	ln := 0
+	for _, m := range b.Matches {
+		ln += m.Length()
+	}
+
+	return ln
+}
+
+func (b *PendingTradeBatch) Trades() []*Trade {
+	trades := []*Trade{}
+	for _, m := range b.Matches {
+		trades = append(trades, m.Trades...)
+	}
+
+	return trades
+}
+
+// SingleTrades returns the trades of the batch, each as a single (maker order, trade) match
+func (b *PendingTradeBatch) SingleTrades() []*Matches {
+	matches := []*Matches{}
+	for _, m := range b.Matches {
+		for i := 0; i < m.Length(); i++ {
+			matches = append(matches, m.NthMatch(i))
+		}
+	}
+
+	return matches
+}
+
+// SplitByMakerOrder separates the trades of the given maker order from the rest of the batch.
+// It returns the batch without these trades and the removed trades grouped by taker order.
+func (b *PendingTradeBatch) SplitByMakerOrder(h common.Hash) (*PendingTradeBatch, []*Matches) {
+	kept := NewPendingTradeBatch()
+	removed := []*Matches{}
+
+	for _, m := range b.Matches {
+		keptMatches := &Matches{TakerOrder: m.TakerOrder}
+		removedMatches := &Matches{TakerOrder: m.TakerOrder}
+
+		for i, mo := range m.MakerOrders {
+			if mo.Hash == h {
+				removedMatches.AppendMatch(mo, m.Trades[i])
+			} else {
+				keptMatches.AppendMatch(mo, m.Trades[i])
+			}
+		}
+
+		if keptMatches.Length() > 0 {
+			kept.Matches = append(kept.Matches, keptMatches)
+		}
+
+		if removedMatches.Length() > 0 {
+			removed = append(removed, removedMatches)
+		}
+	}
+
+	return kept, removed
+}
+
+// SplitByTakerOrder separates the matches of the given taker order from the rest of the batch.
+// It returns the batch without these matches and the removed matches.
+func (b *PendingTradeBatch) SplitByTakerOrder(h common.Hash) (*PendingTradeBatch, []*Matches) {
+	kept := NewPendingTradeBatch()
+	removed := []*Matches{}
+
+	for _, m := range b.Matches {
+		if m.TakerOrder.Hash == h {
+			removed = append(removed, m)
+		} else {
+			kept.Matches = append(kept.Matches, m)
+		}
+	}
+
+	return kept, removed
+}
+
+func (b *PendingTradeBatch) Validate() error {
+	if len(b.Matches) == 0 {
+		return errors.New("Batch should contain at least one match")
+	}
+
+	for _, m := range b.Matches {
+		err := m.Validate()
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *PendingTradeBatch) String() string {
+	matches := []string{}
+	for _, m := range b.Matches {
+		matches = append(matches, m.String())
+	}
+
+	return fmt.Sprintf("[%v]", strings.Join(matches, ", "))
+}
+
+// OperatorWalletStatus describes the health of an operator wallet. A wallet is removed from
+// the settlement rotation while it is not healthy, the reason being given in Message.
+type OperatorWalletStatus struct {
+	Address     common.Address `json:"address"`
+	Queue       string         `json:"queue"`
+	Balance     *big.Int       `json:"balance"`
+	QueueLength int            `json:"queueLength"`
+	PendingTxs  int            `json:"pendingTxs"`
+	SentTxs     int            `json:"sentTxs"`
+	FailedTxs   int            `json:"failedTxs"`
+	FailureRate float64        `json:"failureRate"`
+	LowBalance  bool           `json:"lowBalance"`
+	Healthy     bool           `json:"healthy"`
+	Message     string         `json:"message,omitempty"`
+	LastCheckAt time.Time      `json:"lastCheckAt"`
+}
+
+// MarshalJSON returns the json encoded operator wallet status. The balance is encoded as a string
+func (s *OperatorWalletStatus) MarshalJSON() ([]byte, error) {
+	type status OperatorWalletStatus
+
+	balance := ""
+	if s.Balance != nil {
+		balance = s.Balance.String()
+	}
+
+	return json.Marshal(&struct {
+		*status
+		Balance string `json:"balance"`
+	}{(*status)(s), balance})
 }
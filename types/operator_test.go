@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingTradeBatchWith(t *testing.T) {
+	m1 := &Matches{
+		MakerOrders: []*Order{&Order{}, &Order{}},
+		TakerOrder:  &Order{},
+		Trades:      []*Trade{&Trade{}, &Trade{}},
+	}
+
+	m2 := &Matches{
+		MakerOrders: []*Order{&Order{}},
+		TakerOrder:  &Order{},
+		Trades:      []*Trade{&Trade{}},
+	}
+
+	b1 := NewPendingTradeBatch(m1)
+	b2 := b1.With(m2)
+
+	assert.Equal(t, 1, len(b1.Matches), "With should not modify the original batch")
+	assert.Equal(t, 2, b1.Length())
+	assert.Equal(t, []*Matches{m1, m2}, b2.Matches)
+	assert.Equal(t, 3, b2.Length())
+	assert.Equal(t, 3, len(b2.Trades()))
+}
+
+func TestPendingTradeBatchValidate(t *testing.T) {
+	b := NewPendingTradeBatch()
+
+	err := b.Validate()
+	assert.NotNil(t, err, "An empty batch should not be valid")
+}
+
+func TestPendingTradeBatchSplitByMakerOrder(t *testing.T) {
+	mo1 := &Order{Hash: common.HexToHash("0x1")}
+	mo2 := &Order{Hash: common.HexToHash("0x2")}
+	to1 := &Order{Hash: common.HexToHash("0x3")}
+	to2 := &Order{Hash: common.HexToHash("0x4")}
+
+	m1 := &Matches{MakerOrders: []*Order{mo1, mo2}, TakerOrder: to1, Trades: []*Trade{&Trade{}, &Trade{}}}
+	m2 := &Matches{MakerOrders: []*Order{mo1}, TakerOrder: to2, Trades: []*Trade{&Trade{}}}
+	b := NewPendingTradeBatch(m1, m2)
+
+	kept, removed := b.SplitByMakerOrder(mo1.Hash)
+	assert.Equal(t, 1, kept.Length())
+	assert.Equal(t, mo2, kept.Matches[0].MakerOrders[0])
+	assert.Equal(t, 2, len(removed))
+	assert.Equal(t, to1, removed[0].TakerOrder)
+	assert.Equal(t, to2, removed[1].TakerOrder)
+
+	kept, removed = b.SplitByTakerOrder(to1.Hash)
+	assert.Equal(t, []*Matches{m2}, kept.Matches)
+	assert.Equal(t, []*Matches{m1}, removed)
+	assert.Equal(t, 3, len(b.SingleTrades()))
+}
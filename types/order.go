@@ -11,7 +11,6 @@ import (
 	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/Proofsuite/amp-matching-engine/utils/math"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/sha3"
 	"github.com/globalsign/mgo/bson"
 )
@@ -21,22 +20,37 @@ type Order struct {
 	ID              bson.ObjectId  `json:"id" bson:"_id"`
 	UserAddress     common.Address `json:"userAddress" bson:"userAddress"`
 	ExchangeAddress common.Address `json:"exchangeAddress" bson:"exchangeAddress"`
+	ChainID         int64          `json:"chainId" bson:"chainId"`
 	BaseToken       common.Address `json:"baseToken" bson:"baseToken"`
 	QuoteToken      common.Address `json:"quoteToken" bson:"quoteToken"`
 	Status          string         `json:"status" bson:"status"`
 	Side            string         `json:"side" bson:"side"`
 	Hash            common.Hash    `json:"hash" bson:"hash"`
 	Signature       *Signature     `json:"signature,omitempty" bson:"signature"`
-	PricePoint      *big.Int       `json:"pricepoint" bson:"pricepoint"`
-	Amount          *big.Int       `json:"amount" bson:"amount"`
-	FilledAmount    *big.Int       `json:"filledAmount" bson:"filledAmount"`
-	Nonce           *big.Int       `json:"nonce" bson:"nonce"`
-	MakeFee         *big.Int       `json:"makeFee" bson:"makeFee"`
-	TakeFee         *big.Int       `json:"takeFee" bson:"takeFee"`
-	PairName        string         `json:"pairName" bson:"pairName"`
-	CreatedAt       time.Time      `json:"createdAt" bson:"createdAt"`
-	UpdatedAt       time.Time      `json:"updatedAt" bson:"updatedAt"`
-}
+	// SignatureVersion is the scheme the order is signed with (legacy or EIP-712)
+	SignatureVersion int       `json:"signatureVersion" bson:"signatureVersion"`
+	PricePoint       *big.Int  `json:"pricepoint" bson:"pricepoint"`
+	Amount           *big.Int  `json:"amount" bson:"amount"`
+	FilledAmount     *big.Int  `json:"filledAmount" bson:"filledAmount"`
+	Nonce            *big.Int  `json:"nonce" bson:"nonce"`
+	MakeFee          *big.Int  `json:"makeFee" bson:"makeFee"`
+	TakeFee          *big.Int  `json:"takeFee" bson:"takeFee"`
+	PairName         string    `json:"pairName" bson:"pairName"`
+	ClientOrderID    string    `json:"clientOrderId,omitempty" bson:"clientOrderId,omitempty"`
+	CreatedAt        time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// ErrOrderNonceUsed is returned when an order reuses the nonce of another order of its user
+var ErrOrderNonceUsed = errors.New("Order nonce has already been used")
+
+// ErrClientOrderIDUsed is returned when an order reuses the client order ID of an open order of its user
+var ErrClientOrderIDUsed = errors.New("Order 'clientOrderId' is already used by an open order")
+
+// MaxClientOrderIDLength is the maximum length of a client order ID. Client order IDs are optional
+// identifiers chosen by the users, unique among the open orders of a user. They are not part of the
+// signed order hash.
+const MaxClientOrderIDLength = 64
 
 func (o *Order) String() string {
 	return fmt.Sprintf("Pair: %v, Pricepoint: %v, Hash: %v", o.PairName, o.PricePoint.String(), o.Hash.Hex())
@@ -44,7 +58,7 @@ func (o *Order) String() string {
 
 // TODO: Verify userAddress, baseToken, quoteToken, etc. conditions are working
 func (o *Order) Validate() error {
-	if o.ExchangeAddress != common.HexToAddress(app.Config.Ethereum["exchange_address"]) {
+	if !IsExchangeAddress(o.ExchangeAddress) {
 		return errors.New("Order 'exchangeAddress' parameter is incorrect")
 	}
 
@@ -56,6 +70,10 @@ func (o *Order) Validate() error {
 		return errors.New("Order 'nonce' parameter is required")
 	}
 
+	if len(o.ClientOrderID) > MaxClientOrderIDLength {
+		return errors.New("Order 'clientOrderId' parameter is too long")
+	}
+
 	if (o.BaseToken == common.Address{}) {
 		return errors.New("Order 'baseToken' parameter is required")
 	}
@@ -88,6 +106,24 @@ func (o *Order) Validate() error {
 		return errors.New("Order 'signature' parameter is required")
 	}
 
+	if o.SignatureVersion != SignatureVersionLegacy && o.SignatureVersion != SignatureVersionEIP712 {
+		return errors.New("Order 'signatureVersion' parameter is invalid")
+	}
+
+	if o.SignatureVersion == SignatureVersionEIP712 && !SupportsEIP712(o.ExchangeAddress) {
+		return errors.New("Order 'signatureVersion' is not supported by the exchange contract")
+	}
+
+	// the legacy order hash is the hash rebuilt by the exchange contract and does not cover the chain
+	// ID, only the EIP-712 domain does. Once a chain ID is configured, legacy orders are rejected.
+	if o.SignatureVersion == SignatureVersionLegacy && app.Config.ChainID != 0 {
+		return errors.New("Order 'signatureVersion' should be EIP-712 on this chain")
+	}
+
+	if o.SignatureVersion == SignatureVersionEIP712 && app.Config.ChainID == 0 {
+		return errors.New("Order 'signatureVersion' requires a configured chain ID")
+	}
+
 	if math.IsSmallerThan(o.Nonce, big.NewInt(0)) {
 		return errors.New("Order 'nonce' parameter should be positive")
 	}
@@ -112,7 +148,9 @@ func (o *Order) Validate() error {
 	return nil
 }
 
-// ComputeHash calculates the orderRequest hash
+// ComputeHash calculates the orderRequest hash. This is the hash rebuilt by the exchange
+// contract when it verifies the order signatures, so it must not include fields the contract
+// does not know about such as the chain ID.
 func (o *Order) ComputeHash() common.Hash {
 	sha := sha3.NewKeccak256()
 	sha.Write(o.ExchangeAddress.Bytes())
@@ -128,16 +166,25 @@ func (o *Order) ComputeHash() common.Hash {
 	return common.BytesToHash(sha.Sum(nil))
 }
 
+// SigningHash returns the hash signed by the user, depending on the signature version: the
+// prefixed order hash for legacy signatures or the EIP-712 digest of the order typed data
+func (o *Order) SigningHash() common.Hash {
+	if o.SignatureVersion == SignatureVersionEIP712 {
+		return NewEIP712Domain(o.ExchangeAddress).Digest(o.TypedDataHash())
+	}
+
+	return personalMessageHash(o.ComputeHash())
+}
+
 // VerifySignature checks that the orderRequest signature corresponds to the address in the userAddress field
 func (o *Order) VerifySignature() (bool, error) {
 	o.Hash = o.ComputeHash()
 
-	message := crypto.Keccak256(
-		[]byte("\x19Ethereum Signed Message:\n32"),
-		o.Hash.Bytes(),
-	)
+	if o.Signature == nil {
+		return false, errors.New("Signature is missing")
+	}
 
-	address, err := o.Signature.Verify(common.BytesToHash(message))
+	address, err := o.Signature.Verify(o.SigningHash())
 	if err != nil {
 		return false, err
 	}
@@ -150,10 +197,10 @@ func (o *Order) VerifySignature() (bool, error) {
 }
 
 // Sign first calculates the order hash, then computes a signature of this hash
-// with the given wallet
+// with the given wallet, following the order signature version
 func (o *Order) Sign(w *Wallet) error {
 	hash := o.ComputeHash()
-	sig, err := w.SignHash(hash)
+	sig, err := w.SignDigest(o.SigningHash())
 	if err != nil {
 		return err
 	}
@@ -163,19 +210,24 @@ func (o *Order) Sign(w *Wallet) error {
 	return nil
 }
 
-func (o *Order) Process(p *Pair) error {
-	if o.FilledAmount == nil {
-		o.FilledAmount = big.NewInt(0)
-	}
-
-	if !math.IsEqual(o.MakeFee, p.MakeFee) {
+// ValidateFees checks that the signed order fees are the fees applicable to the order account
+func (o *Order) ValidateFees(makeFee, takeFee *big.Int) error {
+	if !math.IsEqual(o.MakeFee, makeFee) {
 		return errors.New("Invalid MakeFee")
 	}
 
-	if !math.IsEqual(o.TakeFee, p.TakeFee) {
+	if !math.IsEqual(o.TakeFee, takeFee) {
 		return errors.New("Invalid TakeFee")
 	}
 
+	return nil
+}
+
+func (o *Order) Process(p *Pair) error {
+	if o.FilledAmount == nil {
+		o.FilledAmount = big.NewInt(0)
+	}
+
 	o.PairName = p.Name()
 	o.CreatedAt = time.Now()
 	o.UpdatedAt = time.Now()
@@ -280,6 +332,10 @@ func (o *Order) TotalRequiredSellAmount(p *Pair) *big.Int {
 	if o.Side == "BUY" {
 		sellAmount := math.Div(math.Mul(o.Amount, o.PricePoint), pairMultiplier)
 		fee := math.Max(p.MakeFee, p.TakeFee)
+		if o.MakeFee != nil && o.TakeFee != nil {
+			fee = math.Max(o.MakeFee, o.TakeFee)
+		}
+
 		requiredSellTokenAmount = math.Add(sellAmount, fee)
 	} else {
 		requiredSellTokenAmount = o.Amount
@@ -346,17 +402,19 @@ func (o *Order) QuoteTokenSymbol() string {
 // MarshalJSON implements the json.Marshal interface
 func (o *Order) MarshalJSON() ([]byte, error) {
 	order := map[string]interface{}{
-		"exchangeAddress": o.ExchangeAddress,
-		"userAddress":     o.UserAddress,
-		"baseToken":       o.BaseToken,
-		"quoteToken":      o.QuoteToken,
-		"side":            o.Side,
-		"status":          o.Status,
-		"pairName":        o.PairName,
-		"amount":          o.Amount.String(),
-		"pricepoint":      o.PricePoint.String(),
-		"makeFee":         o.MakeFee.String(),
-		"takeFee":         o.TakeFee.String(),
+		"exchangeAddress":  o.ExchangeAddress,
+		"chainId":          o.ChainID,
+		"userAddress":      o.UserAddress,
+		"baseToken":        o.BaseToken,
+		"quoteToken":       o.QuoteToken,
+		"side":             o.Side,
+		"status":           o.Status,
+		"pairName":         o.PairName,
+		"signatureVersion": o.SignatureVersion,
+		"amount":           o.Amount.String(),
+		"pricepoint":       o.PricePoint.String(),
+		"makeFee":          o.MakeFee.String(),
+		"takeFee":          o.TakeFee.String(),
 		// NOTE: Currently removing this to simplify public API, might reinclude
 		// later. An alternative would be to create additional simplified type
 		"createdAt": o.CreatedAt.Format(time.RFC3339Nano),
@@ -383,6 +441,10 @@ func (o *Order) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	if o.ClientOrderID != "" {
+		order["clientOrderId"] = o.ClientOrderID
+	}
+
 	return json.Marshal(order)
 }
 
@@ -406,6 +468,10 @@ func (o *Order) UnmarshalJSON(b []byte) error {
 		o.ExchangeAddress = common.HexToAddress(order["exchangeAddress"].(string))
 	}
 
+	if order["chainId"] != nil {
+		o.ChainID = int64(order["chainId"].(float64))
+	}
+
 	if order["userAddress"] != nil {
 		o.UserAddress = common.HexToAddress(order["userAddress"].(string))
 	}
@@ -454,6 +520,14 @@ func (o *Order) UnmarshalJSON(b []byte) error {
 		o.Status = order["status"].(string)
 	}
 
+	if order["signatureVersion"] != nil {
+		o.SignatureVersion = int(order["signatureVersion"].(float64))
+	}
+
+	if order["clientOrderId"] != nil {
+		o.ClientOrderID = order["clientOrderId"].(string)
+	}
+
 	if order["signature"] != nil {
 		signature := order["signature"].(map[string]interface{})
 		o.Signature = &Signature{
@@ -478,21 +552,24 @@ func (o *Order) UnmarshalJSON(b []byte) error {
 
 // OrderRecord is the object that will be saved in the database
 type OrderRecord struct {
-	ID              bson.ObjectId    `json:"id" bson:"_id"`
-	UserAddress     string           `json:"userAddress" bson:"userAddress"`
-	ExchangeAddress string           `json:"exchangeAddress" bson:"exchangeAddress"`
-	BaseToken       string           `json:"baseToken" bson:"baseToken"`
-	QuoteToken      string           `json:"quoteToken" bson:"quoteToken"`
-	Status          string           `json:"status" bson:"status"`
-	Side            string           `json:"side" bson:"side"`
-	Hash            string           `json:"hash" bson:"hash"`
-	PricePoint      string           `json:"pricepoint" bson:"pricepoint"`
-	Amount          string           `json:"amount" bson:"amount"`
-	FilledAmount    string           `json:"filledAmount" bson:"filledAmount"`
-	Nonce           string           `json:"nonce" bson:"nonce"`
-	MakeFee         string           `json:"makeFee" bson:"makeFee"`
-	TakeFee         string           `json:"takeFee" bson:"takeFee"`
-	Signature       *SignatureRecord `json:"signature,omitempty" bson:"signature"`
+	ID               bson.ObjectId    `json:"id" bson:"_id"`
+	UserAddress      string           `json:"userAddress" bson:"userAddress"`
+	ExchangeAddress  string           `json:"exchangeAddress" bson:"exchangeAddress"`
+	ChainID          int64            `json:"chainId" bson:"chainId"`
+	BaseToken        string           `json:"baseToken" bson:"baseToken"`
+	QuoteToken       string           `json:"quoteToken" bson:"quoteToken"`
+	Status           string           `json:"status" bson:"status"`
+	Side             string           `json:"side" bson:"side"`
+	Hash             string           `json:"hash" bson:"hash"`
+	PricePoint       string           `json:"pricepoint" bson:"pricepoint"`
+	Amount           string           `json:"amount" bson:"amount"`
+	FilledAmount     string           `json:"filledAmount" bson:"filledAmount"`
+	Nonce            string           `json:"nonce" bson:"nonce"`
+	MakeFee          string           `json:"makeFee" bson:"makeFee"`
+	TakeFee          string           `json:"takeFee" bson:"takeFee"`
+	Signature        *SignatureRecord `json:"signature,omitempty" bson:"signature"`
+	SignatureVersion int              `json:"signatureVersion" bson:"signatureVersion"`
+	ClientOrderID    string           `json:"clientOrderId,omitempty" bson:"clientOrderId,omitempty"`
 
 	PairName  string    `json:"pairName" bson:"pairName"`
 	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
@@ -501,21 +578,24 @@ type OrderRecord struct {
 
 func (o *Order) GetBSON() (interface{}, error) {
 	or := OrderRecord{
-		PairName:        o.PairName,
-		ExchangeAddress: o.ExchangeAddress.Hex(),
-		UserAddress:     o.UserAddress.Hex(),
-		BaseToken:       o.BaseToken.Hex(),
-		QuoteToken:      o.QuoteToken.Hex(),
-		Status:          o.Status,
-		Side:            o.Side,
-		Hash:            o.Hash.Hex(),
-		Amount:          o.Amount.String(),
-		PricePoint:      o.PricePoint.String(),
-		Nonce:           o.Nonce.String(),
-		MakeFee:         o.MakeFee.String(),
-		TakeFee:         o.TakeFee.String(),
-		CreatedAt:       o.CreatedAt,
-		UpdatedAt:       o.UpdatedAt,
+		PairName:         o.PairName,
+		ExchangeAddress:  o.ExchangeAddress.Hex(),
+		ChainID:          o.ChainID,
+		UserAddress:      o.UserAddress.Hex(),
+		BaseToken:        o.BaseToken.Hex(),
+		QuoteToken:       o.QuoteToken.Hex(),
+		Status:           o.Status,
+		Side:             o.Side,
+		Hash:             o.Hash.Hex(),
+		Amount:           o.Amount.String(),
+		PricePoint:       o.PricePoint.String(),
+		Nonce:            o.Nonce.String(),
+		MakeFee:          o.MakeFee.String(),
+		TakeFee:          o.TakeFee.String(),
+		SignatureVersion: o.SignatureVersion,
+		ClientOrderID:    o.ClientOrderID,
+		CreatedAt:        o.CreatedAt,
+		UpdatedAt:        o.UpdatedAt,
 	}
 
 	if o.ID.Hex() == "" {
@@ -541,24 +621,27 @@ func (o *Order) GetBSON() (interface{}, error) {
 
 func (o *Order) SetBSON(raw bson.Raw) error {
 	decoded := new(struct {
-		ID              bson.ObjectId    `json:"id,omitempty" bson:"_id"`
-		PairName        string           `json:"pairName" bson:"pairName"`
-		ExchangeAddress string           `json:"exchangeAddress" bson:"exchangeAddress"`
-		UserAddress     string           `json:"userAddress" bson:"userAddress"`
-		BaseToken       string           `json:"baseToken" bson:"baseToken"`
-		QuoteToken      string           `json:"quoteToken" bson:"quoteToken"`
-		Status          string           `json:"status" bson:"status"`
-		Side            string           `json:"side" bson:"side"`
-		Hash            string           `json:"hash" bson:"hash"`
-		PricePoint      string           `json:"pricepoint" bson:"pricepoint"`
-		Amount          string           `json:"amount" bson:"amount"`
-		FilledAmount    string           `json:"filledAmount" bson:"filledAmount"`
-		Nonce           string           `json:"nonce" bson:"nonce"`
-		MakeFee         string           `json:"makeFee" bson:"makeFee"`
-		TakeFee         string           `json:"takeFee" bson:"takeFee"`
-		Signature       *SignatureRecord `json:"signature" bson:"signature"`
-		CreatedAt       time.Time        `json:"createdAt" bson:"createdAt"`
-		UpdatedAt       time.Time        `json:"updatedAt" bson:"updatedAt"`
+		ID               bson.ObjectId    `json:"id,omitempty" bson:"_id"`
+		PairName         string           `json:"pairName" bson:"pairName"`
+		ExchangeAddress  string           `json:"exchangeAddress" bson:"exchangeAddress"`
+		ChainID          int64            `json:"chainId" bson:"chainId"`
+		UserAddress      string           `json:"userAddress" bson:"userAddress"`
+		BaseToken        string           `json:"baseToken" bson:"baseToken"`
+		QuoteToken       string           `json:"quoteToken" bson:"quoteToken"`
+		Status           string           `json:"status" bson:"status"`
+		Side             string           `json:"side" bson:"side"`
+		Hash             string           `json:"hash" bson:"hash"`
+		PricePoint       string           `json:"pricepoint" bson:"pricepoint"`
+		Amount           string           `json:"amount" bson:"amount"`
+		FilledAmount     string           `json:"filledAmount" bson:"filledAmount"`
+		Nonce            string           `json:"nonce" bson:"nonce"`
+		MakeFee          string           `json:"makeFee" bson:"makeFee"`
+		TakeFee          string           `json:"takeFee" bson:"takeFee"`
+		Signature        *SignatureRecord `json:"signature" bson:"signature"`
+		SignatureVersion int              `json:"signatureVersion" bson:"signatureVersion"`
+		ClientOrderID    string           `json:"clientOrderId" bson:"clientOrderId"`
+		CreatedAt        time.Time        `json:"createdAt" bson:"createdAt"`
+		UpdatedAt        time.Time        `json:"updatedAt" bson:"updatedAt"`
 	})
 
 	err := raw.Unmarshal(decoded)
@@ -570,6 +653,7 @@ func (o *Order) SetBSON(raw bson.Raw) error {
 	o.ID = decoded.ID
 	o.PairName = decoded.PairName
 	o.ExchangeAddress = common.HexToAddress(decoded.ExchangeAddress)
+	o.ChainID = decoded.ChainID
 	o.UserAddress = common.HexToAddress(decoded.UserAddress)
 	o.BaseToken = common.HexToAddress(decoded.BaseToken)
 	o.QuoteToken = common.HexToAddress(decoded.QuoteToken)
@@ -580,6 +664,8 @@ func (o *Order) SetBSON(raw bson.Raw) error {
 	o.Status = decoded.Status
 	o.Side = decoded.Side
 	o.Hash = common.HexToHash(decoded.Hash)
+	o.SignatureVersion = decoded.SignatureVersion
+	o.ClientOrderID = decoded.ClientOrderID
 
 	if decoded.Amount != "" {
 		o.Amount = math.ToBigInt(decoded.Amount)
@@ -615,25 +701,31 @@ func (o OrderBSONUpdate) GetBSON() (interface{}, error) {
 	now := time.Now()
 
 	set := bson.M{
-		"pairName":        o.PairName,
-		"exchangeAddress": o.ExchangeAddress.Hex(),
-		"userAddress":     o.UserAddress.Hex(),
-		"baseToken":       o.BaseToken.Hex(),
-		"quoteToken":      o.QuoteToken.Hex(),
-		"status":          o.Status,
-		"side":            o.Side,
-		"pricepoint":      o.PricePoint.String(),
-		"amount":          o.Amount.String(),
-		"nonce":           o.Nonce.String(),
-		"makeFee":         o.MakeFee.String(),
-		"takeFee":         o.TakeFee.String(),
-		"updatedAt":       now,
+		"pairName":         o.PairName,
+		"exchangeAddress":  o.ExchangeAddress.Hex(),
+		"chainId":          o.ChainID,
+		"userAddress":      o.UserAddress.Hex(),
+		"baseToken":        o.BaseToken.Hex(),
+		"quoteToken":       o.QuoteToken.Hex(),
+		"status":           o.Status,
+		"side":             o.Side,
+		"pricepoint":       o.PricePoint.String(),
+		"amount":           o.Amount.String(),
+		"nonce":            o.Nonce.String(),
+		"makeFee":          o.MakeFee.String(),
+		"takeFee":          o.TakeFee.String(),
+		"signatureVersion": o.SignatureVersion,
+		"updatedAt":        now,
 	}
 
 	if o.FilledAmount != nil {
 		set["filledAmount"] = o.FilledAmount.String()
 	}
 
+	if o.ClientOrderID != "" {
+		set["clientOrderId"] = o.ClientOrderID
+	}
+
 	if o.Signature != nil {
 		set["signature"] = bson.M{
 			"V": o.Signature.V,
@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 
+	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/sha3"
 )
 
@@ -16,9 +17,12 @@ import (
 // the OrderCancel must include a signature by the Maker of the order corresponding
 // to the OrderHash.
 type OrderCancel struct {
-	OrderHash common.Hash `json:"orderHash"`
-	Hash      common.Hash `json:"hash"`
-	Signature *Signature  `json:"signature"`
+	OrderHash        common.Hash    `json:"orderHash"`
+	ExchangeAddress  common.Address `json:"exchangeAddress"`
+	ChainID          int64          `json:"chainId"`
+	Hash             common.Hash    `json:"hash"`
+	Signature        *Signature     `json:"signature"`
+	SignatureVersion int            `json:"signatureVersion"`
 }
 
 // NewOrderCancel returns a new empty OrderCancel object
@@ -33,8 +37,11 @@ func NewOrderCancel() *OrderCancel {
 // MarshalJSON returns the json encoded byte array representing the OrderCancel struct
 func (oc *OrderCancel) MarshalJSON() ([]byte, error) {
 	orderCancel := map[string]interface{}{
-		"orderHash": oc.OrderHash,
-		"hash":      oc.Hash,
+		"orderHash":        oc.OrderHash,
+		"exchangeAddress":  oc.ExchangeAddress,
+		"chainId":          oc.ChainID,
+		"hash":             oc.Hash,
+		"signatureVersion": oc.SignatureVersion,
 		"signature": map[string]interface{}{
 			"V": oc.Signature.V,
 			"R": oc.Signature.R,
@@ -69,6 +76,22 @@ func (oc *OrderCancel) UnmarshalJSON(b []byte) error {
 	}
 	oc.Hash = common.HexToHash(parsed["hash"].(string))
 
+	if parsed["exchangeAddress"] != nil {
+		oc.ExchangeAddress = common.HexToAddress(parsed["exchangeAddress"].(string))
+	}
+
+	if parsed["chainId"] != nil {
+		oc.ChainID = int64(parsed["chainId"].(float64))
+	}
+
+	if parsed["signatureVersion"] != nil {
+		oc.SignatureVersion = int(parsed["signatureVersion"].(float64))
+	}
+
+	if parsed["signature"] == nil {
+		return errors.New("Signature is missing")
+	}
+
 	sig := parsed["signature"].(map[string]interface{})
 	oc.Signature = &Signature{
 		V: byte(sig["V"].(float64)),
@@ -79,19 +102,47 @@ func (oc *OrderCancel) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// SigningHash returns the hash signed by the order maker, depending on the signature version: the
+// prefixed cancel hash for legacy signatures or the EIP-712 digest of the cancel typed data. The
+// cancel is bound to the exchange contract of the order, or to the default exchange contract if
+// its exchange address is not set.
+func (oc *OrderCancel) SigningHash() common.Hash {
+	exchange := oc.ExchangeAddress
+	if (exchange == common.Address{}) {
+		exchange = DefaultExchangeAddress()
+	}
+
+	return oc.signingHash(exchange)
+}
+
+func (oc *OrderCancel) signingHash(exchange common.Address) common.Hash {
+	if oc.SignatureVersion == SignatureVersionEIP712 {
+		return NewEIP712Domain(exchange).Digest(oc.TypedDataHash())
+	}
+
+	return personalMessageHash(oc.Hash)
+}
+
 // VerifySignature returns a true value if the OrderCancel object signature
 // corresponds to the Maker of the given order
 func (oc *OrderCancel) VerifySignature(o *Order) (bool, error) {
-	message := crypto.Keccak256(
-		[]byte("\x19Ethereum Signed Message:\n32"),
-		oc.Hash.Bytes(),
-	)
-
 	if o == nil {
 		return false, errors.New("Recovered address is incorrect")
 	}
 
-	address, err := oc.Signature.Verify(common.BytesToHash(message))
+	if oc.SignatureVersion != SignatureVersionLegacy && oc.SignatureVersion != SignatureVersionEIP712 {
+		return false, errors.New("Invalid signature version")
+	}
+
+	if oc.ChainID != app.Config.ChainID {
+		return false, errors.New("Invalid chain ID")
+	}
+
+	if oc.Hash != oc.ComputeHash() {
+		return false, errors.New("Invalid hash")
+	}
+
+	address, err := oc.Signature.Verify(oc.signingHash(o.ExchangeAddress))
 	if err != nil {
 		return false, err
 	}
@@ -104,12 +155,7 @@ func (oc *OrderCancel) VerifySignature(o *Order) (bool, error) {
 }
 
 func (oc *OrderCancel) GetSenderAddress() (common.Address, error) {
-	message := crypto.Keccak256(
-		[]byte("\x19Ethereum Signed Message:\n32"),
-		oc.Hash.Bytes(),
-	)
-
-	address, err := oc.Signature.Verify(common.BytesToHash(message))
+	address, err := oc.Signature.Verify(oc.SigningHash())
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -120,19 +166,20 @@ func (oc *OrderCancel) GetSenderAddress() (common.Address, error) {
 // ComputeHash computes the hash of an order cancel message
 func (oc *OrderCancel) ComputeHash() common.Hash {
 	sha := sha3.NewKeccak256()
+	sha.Write(common.BigToHash(big.NewInt(oc.ChainID)).Bytes())
 	sha.Write(oc.OrderHash.Bytes())
 	return common.BytesToHash(sha.Sum(nil))
 }
 
 // Sign first computes the order cancel hash, then signs and sets the signature
+// following the cancel signature version
 func (oc *OrderCancel) Sign(w *Wallet) error {
-	h := oc.ComputeHash()
-	sig, err := w.SignHash(h)
+	oc.Hash = oc.ComputeHash()
+	sig, err := w.SignDigest(oc.SigningHash())
 	if err != nil {
 		return err
 	}
 
-	oc.Hash = h
 	oc.Signature = sig
 	return nil
 }
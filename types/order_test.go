@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-test/deep"
 	"github.com/stretchr/testify/assert"
@@ -284,3 +285,86 @@ func TestOrderBSON(t *testing.T) {
 
 // 	assert.Equal(decoded, account)
 // }
+
+func TestOrderChainReplay(t *testing.T) {
+	app.Config.ChainID = 1
+	app.Config.Ethereum = map[string]string{"exchange_address": "0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"}
+	app.Config.EIP712ExchangeAddresses = []string{"0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"}
+	defer func() { app.Config.EIP712ExchangeAddresses = nil }()
+
+	w := NewWalletFromPrivateKey("7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660")
+	o := eip712TestOrder()
+	o.Sign(w)
+
+	err := o.Validate()
+	assert.Nil(t, err)
+
+	// the same order signed for another network is not valid on this one
+	app.Config.ChainID = 3
+	replayed := eip712TestOrder()
+	replayed.Sign(w)
+
+	app.Config.ChainID = 1
+	err = replayed.Validate()
+	assert.EqualError(t, err, "Recovered address is incorrect")
+
+	// legacy signatures do not cover the chain ID, they are rejected once it is configured
+	legacy := eip712TestOrder()
+	legacy.SignatureVersion = SignatureVersionLegacy
+	legacy.Sign(w)
+
+	err = legacy.Validate()
+	assert.EqualError(t, err, "Order 'signatureVersion' should be EIP-712 on this chain")
+
+	app.Config.ChainID = 0
+	defer func() { app.Config.ChainID = 1 }()
+	err = legacy.Validate()
+	assert.Nil(t, err)
+
+	err = o.Validate()
+	assert.EqualError(t, err, "Order 'signatureVersion' requires a configured chain ID")
+}
+
+func TestOrderClientOrderID(t *testing.T) {
+	app.Config.ChainID = 1
+	app.Config.Ethereum = map[string]string{"exchange_address": "0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"}
+
+	w := NewWalletFromPrivateKey("7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660")
+	o := eip712TestOrder()
+	o.SignatureVersion = SignatureVersionLegacy
+	o.Sign(w)
+	hash := o.Hash
+
+	// the client order ID is not part of the signed hash
+	o.ClientOrderID = "bot-1"
+	assert.Equal(t, hash, o.ComputeHash())
+
+	encoded, err := json.Marshal(o)
+	if err != nil {
+		t.Error(err)
+	}
+
+	decoded := &Order{}
+	err = json.Unmarshal(encoded, decoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "bot-1", decoded.ClientOrderID)
+
+	data, err := bson.Marshal(o)
+	if err != nil {
+		t.Error(err)
+	}
+
+	decoded = &Order{}
+	err = bson.Unmarshal(data, decoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "bot-1", decoded.ClientOrderID)
+
+	o.ClientOrderID = string(make([]byte, MaxClientOrderIDLength+1))
+	assert.EqualError(t, o.Validate(), "Order 'clientOrderId' parameter is too long")
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+)
+
+// DefaultPageLimit and MaxPageLimit bound the number of documents returned by a paginated query
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// Cursor identifies the last document of a page. Results are sorted from the most recent to
+// the oldest document, ties on createdAt being broken with the document _id.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        bson.ObjectId
+}
+
+// NewCursor returns the cursor positioned on the document created at t with the given id
+func NewCursor(t time.Time, id bson.ObjectId) *Cursor {
+	return &Cursor{CreatedAt: t, ID: id}
+}
+
+// Encode returns the opaque representation of the cursor returned to API clients.
+// Mongo stores dates with a millisecond precision, so is the cursor timestamp.
+func (c *Cursor) Encode() string {
+	s := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano()/int64(time.Millisecond), c.ID.Hex())
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeCursor parses a cursor previously returned by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	parts := strings.Split(string(b), ":")
+	if len(parts) != 2 || !bson.IsObjectIdHex(parts[1]) {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	ms, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	t := time.Unix(0, ms*int64(time.Millisecond)).UTC()
+	return NewCursor(t, bson.ObjectIdHex(parts[1])), nil
+}
+
+// HistoryQuery holds the filters and pagination parameters of the order and trade lists.
+// Zero values are ignored: a zero address matches any user and zero times leave the range open.
+type HistoryQuery struct {
+	Address    common.Address
+	BaseToken  common.Address
+	QuoteToken common.Address
+	Side       string
+	Status     string
+	From       time.Time
+	To         time.Time
+	Cursor     *Cursor
+	Limit      int
+}
+
+// Validate checks the consistency of the query filters
+func (q *HistoryQuery) Validate() error {
+	if q.Limit < 1 || q.Limit > MaxPageLimit {
+		return fmt.Errorf("Limit must be between 1 and %d", MaxPageLimit)
+	}
+
+	if (q.BaseToken == common.Address{}) != (q.QuoteToken == common.Address{}) {
+		return errors.New("baseToken and quoteToken must be provided together")
+	}
+
+	if q.Side != "" && q.Side != "BUY" && q.Side != "SELL" {
+		return errors.New("Side must be BUY or SELL")
+	}
+
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return errors.New("'to' must not be before 'from'")
+	}
+
+	return nil
+}
+
+// HasPair returns true if the query is restricted to a single pair
+func (q *HistoryQuery) HasPair() bool {
+	return q.BaseToken != common.Address{}
+}
+
+// OrderPage is a page of orders sorted from the most recent to the oldest.
+// NextCursor is empty when there are no more orders to fetch.
+type OrderPage struct {
+	Orders     []*Order `json:"items"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+}
+
+// TradePage is a page of trades sorted from the most recent to the oldest.
+// NextCursor is empty when there are no more trades to fetch.
+type TradePage struct {
+	Trades     []*Trade `json:"items"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncoding(t *testing.T) {
+	c := NewCursor(time.Unix(1405544146, 123000000).UTC(), bson.ObjectIdHex("537f700b537461b70c5f0001"))
+
+	decoded, err := DecodeCursor(c.Encode())
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, c.ID, decoded.ID)
+	assert.True(t, c.CreatedAt.Equal(decoded.CreatedAt))
+
+	_, err = DecodeCursor("not a cursor")
+	assert.Error(t, err)
+
+	_, err = DecodeCursor("MTIzOm5vdGFuaWQ")
+	assert.Error(t, err)
+}
+
+func TestHistoryQueryValidate(t *testing.T) {
+	q := &HistoryQuery{Limit: DefaultPageLimit}
+	assert.Nil(t, q.Validate())
+
+	q = &HistoryQuery{Limit: 0}
+	assert.Error(t, q.Validate())
+
+	q = &HistoryQuery{Limit: MaxPageLimit + 1}
+	assert.Error(t, q.Validate())
+
+	q = &HistoryQuery{Limit: 10, BaseToken: common.HexToAddress("0x1")}
+	assert.Error(t, q.Validate())
+
+	q = &HistoryQuery{Limit: 10, BaseToken: common.HexToAddress("0x1"), QuoteToken: common.HexToAddress("0x2")}
+	assert.Nil(t, q.Validate())
+	assert.True(t, q.HasPair())
+
+	q = &HistoryQuery{Limit: 10, Side: "HOLD"}
+	assert.Error(t, q.Validate())
+
+	q = &HistoryQuery{Limit: 10, From: time.Unix(200, 0), To: time.Unix(100, 0)}
+	assert.Error(t, q.Validate())
+}
@@ -26,6 +26,7 @@ type Pair struct {
 	Rank               int            `json:"rank,omitempty" bson:"rank"`
 	MakeFee            *big.Int       `json:"makeFee,omitempty" bson:"makeFee"`
 	TakeFee            *big.Int       `json:"takeFee,omitempty" bson:"takeFee"`
+	ExchangeAddress    common.Address `json:"exchangeAddress,omitempty" bson:"exchangeAddress"`
 	CreatedAt          time.Time      `json:"-" bson:"createdAt"`
 	UpdatedAt          time.Time      `json:"-" bson:"updatedAt"`
 }
@@ -66,6 +67,10 @@ func (p *Pair) UnmarshalJSON(b []byte) error {
 		p.Rank = pair["rank"].(int)
 	}
 
+	if pair["exchangeAddress"] != nil {
+		p.ExchangeAddress = common.HexToAddress(pair["exchangeAddress"].(string))
+	}
+
 	return nil
 	//TODO do we need the rest of the fields ?
 }
@@ -91,6 +96,10 @@ func (p *Pair) MarshalJSON() ([]byte, error) {
 		pair["takeFee"] = p.TakeFee.String()
 	}
 
+	if (p.ExchangeAddress != common.Address{}) {
+		pair["exchangeAddress"] = p.ExchangeAddress.Hex()
+	}
+
 	return json.Marshal(pair)
 }
 
@@ -120,6 +129,7 @@ type PairRecord struct {
 	MakeFee            string    `json:"makeFee" bson:"makeFee"`
 	TakeFee            string    `json:"takeFee" bson:"takeFee"`
 	Rank               int       `json:"rank" bson:"rank"`
+	ExchangeAddress    string    `json:"exchangeAddress,omitempty" bson:"exchangeAddress,omitempty"`
 	CreatedAt          time.Time `json:"createdAt" bson:"createdAt"`
 	UpdatedAt          time.Time `json:"updatedAt" bson:"updatedAt"`
 }
@@ -139,6 +149,16 @@ func (p *Pair) PairMultiplier() *big.Int {
 	return math.Mul(defaultMultiplier, baseTokenMultiplier)
 }
 
+// Exchange returns the address of the exchange contract settling the pair. Pairs that
+// are not bound to an exchange contract are settled on the default exchange contract
+func (p *Pair) Exchange() common.Address {
+	if (p.ExchangeAddress == common.Address{}) {
+		return DefaultExchangeAddress()
+	}
+
+	return p.ExchangeAddress
+}
+
 func (p *Pair) Code() string {
 	code := p.BaseTokenSymbol + "/" + p.QuoteTokenSymbol + "::" + p.BaseTokenAddress.Hex() + "::" + p.QuoteTokenAddress.Hex()
 	return code
@@ -162,6 +182,11 @@ func (p *Pair) ParseAmount(a *big.Int) float64 {
 	return amount
 }
 
+// ParseQuoteAmount converts an amount of quote tokens (eg. fees) to a decimal value
+func (p *Pair) ParseQuoteAmount(a *big.Int) float64 {
+	return math.DivideToFloat(a, p.QuoteTokenMultiplier())
+}
+
 func (p *Pair) ParsePricePoint(pp *big.Int) float64 {
 	nominator := pp
 	denominator := math.Mul(math.Exp(big.NewInt(10), big.NewInt(18)), p.QuoteTokenMultiplier())
@@ -201,13 +226,17 @@ func (p *Pair) SetBSON(raw bson.Raw) error {
 	p.MakeFee = makeFee
 	p.TakeFee = takeFee
 
+	if decoded.ExchangeAddress != "" {
+		p.ExchangeAddress = common.HexToAddress(decoded.ExchangeAddress)
+	}
+
 	p.CreatedAt = decoded.CreatedAt
 	p.UpdatedAt = decoded.UpdatedAt
 	return nil
 }
 
 func (p *Pair) GetBSON() (interface{}, error) {
-	return &PairRecord{
+	pr := &PairRecord{
 		ID:                 p.ID,
 		BaseTokenSymbol:    p.BaseTokenSymbol,
 		BaseTokenAddress:   p.BaseTokenAddress.Hex(),
@@ -222,7 +251,13 @@ func (p *Pair) GetBSON() (interface{}, error) {
 		TakeFee:            p.TakeFee.String(),
 		CreatedAt:          p.CreatedAt,
 		UpdatedAt:          p.UpdatedAt,
-	}, nil
+	}
+
+	if (p.ExchangeAddress != common.Address{}) {
+		pr.ExchangeAddress = p.ExchangeAddress.Hex()
+	}
+
+	return pr, nil
 }
 
 func (p Pair) ValidateAddresses() error {
@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/globalsign/mgo/bson"
@@ -19,6 +20,7 @@ func ComparePair(t *testing.T, a, b *Pair) {
 	assert.Equal(t, a.Active, b.Active)
 	assert.Equal(t, a.MakeFee, b.MakeFee)
 	assert.Equal(t, a.TakeFee, b.TakeFee)
+	assert.Equal(t, a.ExchangeAddress, b.ExchangeAddress)
 }
 
 func TestPairBSON(t *testing.T) {
@@ -45,3 +47,22 @@ func TestPairBSON(t *testing.T) {
 
 	ComparePair(t, pair, decoded)
 }
+
+func TestPairExchange(t *testing.T) {
+	app.Config.Ethereum = map[string]string{"exchange_address": "0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f"}
+	app.Config.ExchangeAddresses = []string{"0x1d3b1cb9bbb03e9e2d29e1d4de1e1d3a3b1e1f0a"}
+	defer func() { app.Config.ExchangeAddresses = nil }()
+
+	defaultExchange := common.HexToAddress("0x8a93df8d3d8201c0fa722dae65cc7a9f3cb3ee3f")
+	otherExchange := common.HexToAddress("0x1d3b1cb9bbb03e9e2d29e1d4de1e1d3a3b1e1f0a")
+
+	pair := &Pair{}
+	assert.Equal(t, defaultExchange, pair.Exchange())
+
+	pair.ExchangeAddress = otherExchange
+	assert.Equal(t, otherExchange, pair.Exchange())
+
+	assert.True(t, IsExchangeAddress(defaultExchange))
+	assert.True(t, IsExchangeAddress(otherExchange))
+	assert.False(t, IsExchangeAddress(common.HexToAddress("0x7a9f3cd060ab180f36c17fe6bdf9974f577d77aa")))
+}
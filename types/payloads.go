@@ -18,6 +18,7 @@ import (
 type NewOrderPayload struct {
 	PairName        string         `json:"pairName"`
 	ExchangeAddress common.Address `json:"exchangeAddress"`
+	ChainID         int64          `json:"chainId"`
 	UserAddress     common.Address `json:"userAddress"`
 	BaseToken       common.Address `json:"baseToken"`
 	QuoteToken      common.Address `json:"quoteToken"`
@@ -35,6 +36,7 @@ func (p NewOrderPayload) MarshalJSON() ([]byte, error) {
 	encoded := map[string]interface{}{
 		"pairName":        p.PairName,
 		"exchangeAddress": p.ExchangeAddress,
+		"chainId":         p.ChainID,
 		"userAddress":     p.UserAddress,
 		"amount":          p.Amount.String(),
 		"pricepoint":      p.PricePoint.String(),
@@ -73,6 +75,10 @@ func (p *NewOrderPayload) UnmarshalJSON(b []byte) error {
 		p.ExchangeAddress = common.HexToAddress(decoded["exchangeAddress"].(string))
 	}
 
+	if decoded["chainId"] != nil {
+		p.ChainID = int64(decoded["chainId"].(float64))
+	}
+
 	if decoded["amount"] != nil {
 		p.Amount = math.ToBigInt(decoded["amount"].(string))
 	}
@@ -135,6 +141,7 @@ func (p *NewOrderPayload) ToOrder() (o *Order, err error) {
 		MakeFee:     p.MakeFee,
 		TakeFee:     p.TakeFee,
 		UserAddress: p.UserAddress,
+		ChainID:     p.ChainID,
 		BaseToken:   p.BaseToken,
 		QuoteToken:  p.QuoteToken,
 		Amount:      p.Amount,
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ReconciliationReport lists the differences found between the trades collection and
+// the trade events emitted by the exchange smart contract over a block range.
+// The mismatch types are:
+// ORPHAN_EVENT: a trade event does not correspond to any trade
+// ORPHAN_TRADE: a successful trade does not correspond to any trade event
+// STUCK_PENDING: a trade is still pending although it was settled or was sent a long time ago
+// STATUS_MISMATCH: a trade settled on-chain does not have the SUCCESS status
+// AMOUNT_MISMATCH: the amount of a trade is different from the amount settled on-chain
+type ReconciliationReport struct {
+	StartBlock  uint64                    `json:"startBlock"`
+	EndBlock    uint64                    `json:"endBlock"`
+	TradeEvents int                       `json:"tradeEvents"`
+	Trades      int                       `json:"trades"`
+	Mismatches  []*ReconciliationMismatch `json:"mismatches"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+}
+
+type ReconciliationMismatch struct {
+	Type        string      `json:"type"`
+	TradeHash   common.Hash `json:"tradeHash"`
+	TxHash      common.Hash `json:"txHash"`
+	BlockNumber uint64      `json:"blockNumber,omitempty"`
+	Message     string      `json:"message"`
+}
+
+func NewReconciliationReport(start, end uint64) *ReconciliationReport {
+	return &ReconciliationReport{
+		StartBlock: start,
+		EndBlock:   end,
+		Mismatches: []*ReconciliationMismatch{},
+		CreatedAt:  time.Now(),
+	}
+}
+
+func (r *ReconciliationReport) AddMismatch(mismatchType string, tradeHash, txHash common.Hash, blockNumber uint64, message string) {
+	r.Mismatches = append(r.Mismatches, &ReconciliationMismatch{
+		Type:        mismatchType,
+		TradeHash:   tradeHash,
+		TxHash:      txHash,
+		BlockNumber: blockNumber,
+		Message:     message,
+	})
+}
+
+// Reconciled returns true if no mismatch was found
+func (r *ReconciliationReport) Reconciled() bool {
+	return len(r.Mismatches) == 0
+}
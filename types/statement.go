@@ -0,0 +1,148 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Statement periods
+const (
+	StatementPeriodDay   = "day"
+	StatementPeriodWeek  = "week"
+	StatementPeriodMonth = "month"
+	StatementPeriodYear  = "year"
+)
+
+// AccountStatement aggregates the successful trades of an account by pair and period.
+// Volumes are expressed in base tokens, fees, prices and PnL in quote tokens.
+type AccountStatement struct {
+	Address common.Address    `json:"address"`
+	Period  string            `json:"period"`
+	From    *time.Time        `json:"from,omitempty"`
+	To      *time.Time        `json:"to,omitempty"`
+	Entries []*StatementEntry `json:"entries"`
+}
+
+// StatementEntry holds the aggregates of an account trades on a pair during a period.
+// The realized PnL is computed with the average cost method over the whole trade history
+// of the pair, and does not include the fees.
+type StatementEntry struct {
+	PairName         string    `json:"pairName"`
+	PeriodStart      time.Time `json:"periodStart"`
+	TradeCount       int       `json:"tradeCount"`
+	BuyVolume        float64   `json:"buyVolume"`
+	SellVolume       float64   `json:"sellVolume"`
+	QuoteVolume      float64   `json:"quoteVolume"`
+	AverageBuyPrice  float64   `json:"averageBuyPrice"`
+	AverageSellPrice float64   `json:"averageSellPrice"`
+	MakerFees        float64   `json:"makerFees"`
+	TakerFees        float64   `json:"takerFees"`
+	RealizedPnL      float64   `json:"realizedPnl"`
+	buyQuoteVolume   float64
+	sellQuoteVolume  float64
+}
+
+// ValidateStatementPeriod returns an error if p is not a supported statement period
+func ValidateStatementPeriod(p string) error {
+	switch p {
+	case StatementPeriodDay, StatementPeriodWeek, StatementPeriodMonth, StatementPeriodYear:
+		return nil
+	}
+
+	return errors.New("Period must be one of day, week, month or year")
+}
+
+// PeriodStart returns the beginning (UTC) of the statement period containing t.
+// Weeks start on mondays.
+func PeriodStart(t time.Time, period string) time.Time {
+	t = t.UTC()
+	y, m, d := t.Date()
+
+	switch period {
+	case StatementPeriodWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, time.UTC)
+	case StatementPeriodMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case StatementPeriodYear:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// AddTrade adds an account trade to the entry. pnl is the PnL realized by the trade.
+func (e *StatementEntry) AddTrade(t *TradeExport, pnl float64) {
+	quote := t.Amount * t.Price
+
+	e.TradeCount++
+	e.QuoteVolume += quote
+	e.RealizedPnL += pnl
+
+	if t.Side == "BUY" {
+		e.BuyVolume += t.Amount
+		e.buyQuoteVolume += quote
+		e.AverageBuyPrice = e.buyQuoteVolume / e.BuyVolume
+	} else {
+		e.SellVolume += t.Amount
+		e.sellQuoteVolume += quote
+		e.AverageSellPrice = e.sellQuoteVolume / e.SellVolume
+	}
+
+	if t.Role == TradeRoleMaker {
+		e.MakerFees += t.Fee
+	} else {
+		e.TakerFees += t.Fee
+	}
+}
+
+// positionDust is the position amount under which a position is considered closed,
+// to absorb float rounding errors
+const positionDust = 1e-12
+
+// Position tracks the position of an account on a pair to compute the realized PnL
+// with the average cost method. Short positions (more sold than bought) are supported.
+type Position struct {
+	Amount       float64
+	AveragePrice float64
+}
+
+// Apply updates the position with an account trade and returns the realized PnL
+func (p *Position) Apply(t *TradeExport) float64 {
+	amount := t.Amount
+	if t.Side == "SELL" {
+		amount = -amount
+	}
+
+	// the trade increases the position
+	if p.Amount == 0 || (p.Amount > 0) == (amount > 0) {
+		total := math.Abs(p.Amount) + math.Abs(amount)
+		if total != 0 {
+			p.AveragePrice = (math.Abs(p.Amount)*p.AveragePrice + math.Abs(amount)*t.Price) / total
+		}
+
+		p.Amount += amount
+		return 0
+	}
+
+	// the trade closes the position, partially or entirely
+	closed := math.Min(math.Abs(amount), math.Abs(p.Amount))
+	pnl := closed * (t.Price - p.AveragePrice)
+	if p.Amount < 0 {
+		pnl = -pnl
+	}
+
+	p.Amount += amount
+
+	if math.Abs(p.Amount) < positionDust {
+		p.Amount = 0
+		p.AveragePrice = 0
+	} else if math.Abs(amount) > closed {
+		p.AveragePrice = t.Price
+	}
+
+	return pnl
+}
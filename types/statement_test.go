@@ -0,0 +1,66 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodStart(t *testing.T) {
+	// wednesday
+	ts := time.Date(2018, 8, 15, 13, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2018, 8, 15, 0, 0, 0, 0, time.UTC), PeriodStart(ts, StatementPeriodDay))
+	assert.Equal(t, time.Date(2018, 8, 13, 0, 0, 0, 0, time.UTC), PeriodStart(ts, StatementPeriodWeek))
+	assert.Equal(t, time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC), PeriodStart(ts, StatementPeriodMonth))
+	assert.Equal(t, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), PeriodStart(ts, StatementPeriodYear))
+
+	// sunday belongs to the week started on the previous monday
+	sunday := time.Date(2018, 8, 19, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2018, 8, 13, 0, 0, 0, 0, time.UTC), PeriodStart(sunday, StatementPeriodWeek))
+
+	assert.Nil(t, ValidateStatementPeriod(StatementPeriodWeek))
+	assert.Error(t, ValidateStatementPeriod("hour"))
+}
+
+func TestPositionApply(t *testing.T) {
+	p := &Position{}
+
+	assert.Equal(t, 0.0, p.Apply(&TradeExport{Side: "BUY", Amount: 10, Price: 1}))
+	assert.Equal(t, 0.0, p.Apply(&TradeExport{Side: "BUY", Amount: 10, Price: 2}))
+	assert.Equal(t, 20.0, p.Amount)
+	assert.Equal(t, 1.5, p.AveragePrice)
+
+	// partial close
+	assert.Equal(t, 5.0, p.Apply(&TradeExport{Side: "SELL", Amount: 10, Price: 2}))
+	assert.Equal(t, 10.0, p.Amount)
+	assert.Equal(t, 1.5, p.AveragePrice)
+
+	// close and open a short position
+	assert.Equal(t, -5.0, p.Apply(&TradeExport{Side: "SELL", Amount: 15, Price: 1}))
+	assert.Equal(t, -5.0, p.Amount)
+	assert.Equal(t, 1.0, p.AveragePrice)
+
+	// cover the short position at a lower price
+	assert.Equal(t, 2.5, p.Apply(&TradeExport{Side: "BUY", Amount: 5, Price: 0.5}))
+	assert.Equal(t, 0.0, p.Amount)
+	assert.Equal(t, 0.0, p.AveragePrice)
+}
+
+func TestStatementEntryAddTrade(t *testing.T) {
+	e := &StatementEntry{}
+	e.AddTrade(&TradeExport{Side: "BUY", Role: TradeRoleMaker, Amount: 10, Price: 1, Fee: 0.1}, 0)
+	e.AddTrade(&TradeExport{Side: "BUY", Role: TradeRoleTaker, Amount: 10, Price: 2, Fee: 0.2}, 0)
+	e.AddTrade(&TradeExport{Side: "SELL", Role: TradeRoleTaker, Amount: 5, Price: 3, Fee: 0.2}, 7.5)
+
+	assert.Equal(t, 3, e.TradeCount)
+	assert.Equal(t, 20.0, e.BuyVolume)
+	assert.Equal(t, 5.0, e.SellVolume)
+	assert.Equal(t, 45.0, e.QuoteVolume)
+	assert.Equal(t, 1.5, e.AverageBuyPrice)
+	assert.Equal(t, 3.0, e.AverageSellPrice)
+	assert.Equal(t, 0.1, e.MakerFees)
+	assert.InDelta(t, 0.4, e.TakerFees, 1e-9)
+	assert.Equal(t, 7.5, e.RealizedPnL)
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -11,18 +12,32 @@ import (
 	"github.com/go-ozzo/ozzo-validation"
 )
 
+// Token flags record the non-standard behaviours detected on a token contract when the token
+// is registered. Flagged tokens are registered but not listed.
+const (
+	// the transfer function does not return a boolean
+	TokenFlagNoReturnValue = "NO_RETURN_VALUE"
+	// the recipient of a transfer seems to receive less than the transferred amount
+	TokenFlagFeeOnTransfer = "FEE_ON_TRANSFER"
+	// no holder of the token was found to simulate a transfer
+	TokenFlagTransferNotSimulated = "TRANSFER_NOT_SIMULATED"
+)
+
 // Token struct is used to model the token data in the system and DB
 type Token struct {
-	ID       bson.ObjectId  `json:"-" bson:"_id"`
-	Symbol   string         `json:"symbol" bson:"symbol"`
-	Address  common.Address `json:"address" bson:"address"`
-	Decimals int            `json:"decimals" bson:"decimals"`
-	Active   bool           `json:"active" bson:"active"`
-	Listed   bool           `json:"listed" bson:"listed"`
-	Quote    bool           `json:"quote" bson:"quote"`
-	MakeFee  *big.Int       `json:"makeFee,omitempty" bson:"makeFee,omitempty"`
-	TakeFee  *big.Int       `json:"takeFee,omitempty" bson:"makeFee,omitempty"`
-	Rank     int            `json:"rank,omitempty" bson:"rank,omitempty"`
+	ID          bson.ObjectId  `json:"-" bson:"_id"`
+	Symbol      string         `json:"symbol" bson:"symbol"`
+	Name        string         `json:"name" bson:"name"`
+	Address     common.Address `json:"address" bson:"address"`
+	Decimals    int            `json:"decimals" bson:"decimals"`
+	TotalSupply *big.Int       `json:"totalSupply" bson:"totalSupply"`
+	Flags       []string       `json:"flags,omitempty" bson:"flags,omitempty"`
+	Active      bool           `json:"active" bson:"active"`
+	Listed      bool           `json:"listed" bson:"listed"`
+	Quote       bool           `json:"quote" bson:"quote"`
+	MakeFee     *big.Int       `json:"makeFee,omitempty" bson:"makeFee,omitempty"`
+	TakeFee     *big.Int       `json:"takeFee,omitempty" bson:"makeFee,omitempty"`
+	Rank        int            `json:"rank,omitempty" bson:"rank,omitempty"`
 
 	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
@@ -30,16 +45,19 @@ type Token struct {
 
 // TokenRecord is the struct which is stored in db
 type TokenRecord struct {
-	ID       bson.ObjectId `json:"-" bson:"_id"`
-	Symbol   string        `json:"symbol" bson:"symbol"`
-	Address  string        `json:"address" bson:"address"`
-	Decimals int           `json:"decimals" bson:"decimals"`
-	Active   bool          `json:"active" bson:"active"`
-	Listed   bool          `json:"listed" bson:"listed"`
-	Quote    bool          `json:"quote" bson:"quote"`
-	MakeFee  string        `json:"makeFee,omitempty" bson:"makeFee,omitempty"`
-	TakeFee  string        `json:"takeFee,omitempty" bson:"takeFee,omitempty"`
-	Rank     int           `json:"rank,omitempty" bson:"rank,omitempty"`
+	ID          bson.ObjectId `json:"-" bson:"_id"`
+	Symbol      string        `json:"symbol" bson:"symbol"`
+	Name        string        `json:"name" bson:"name"`
+	Address     string        `json:"address" bson:"address"`
+	Decimals    int           `json:"decimals" bson:"decimals"`
+	TotalSupply string        `json:"totalSupply,omitempty" bson:"totalSupply,omitempty"`
+	Flags       []string      `json:"flags,omitempty" bson:"flags,omitempty"`
+	Active      bool          `json:"active" bson:"active"`
+	Listed      bool          `json:"listed" bson:"listed"`
+	Quote       bool          `json:"quote" bson:"quote"`
+	MakeFee     string        `json:"makeFee,omitempty" bson:"makeFee,omitempty"`
+	TakeFee     string        `json:"takeFee,omitempty" bson:"takeFee,omitempty"`
+	Rank        int           `json:"rank,omitempty" bson:"rank,omitempty"`
 
 	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
@@ -54,10 +72,16 @@ func (t Token) Validate() error {
 	)
 }
 
+// Flagged returns true if a non-standard behaviour was detected on the token contract
+func (t *Token) Flagged() bool {
+	return len(t.Flags) > 0
+}
+
 func (t *Token) MarshalJSON() ([]byte, error) {
 	token := map[string]interface{}{
 		"id":        t.ID,
 		"symbol":    t.Symbol,
+		"name":      t.Name,
 		"address":   t.Address.Hex(),
 		"decimals":  t.Decimals,
 		"active":    t.Active,
@@ -68,6 +92,14 @@ func (t *Token) MarshalJSON() ([]byte, error) {
 		"rank":      t.Rank,
 	}
 
+	if t.TotalSupply != nil {
+		token["totalSupply"] = t.TotalSupply.String()
+	}
+
+	if len(t.Flags) > 0 {
+		token["flags"] = t.Flags
+	}
+
 	if t.MakeFee != nil {
 		token["makeFee"] = t.MakeFee.String()
 	}
@@ -104,13 +136,27 @@ func (t *Token) UnmarshalJSON(b []byte) error {
 	}
 
 	if token["decimals"] != nil {
-		t.Decimals = token["decimals"].(int)
+		t.Decimals = int(token["decimals"].(float64))
 	}
 
 	if token["symbol"] != nil {
 		t.Symbol = token["symbol"].(string)
 	}
 
+	if token["name"] != nil {
+		t.Name = token["name"].(string)
+	}
+
+	if token["totalSupply"] != nil {
+		t.TotalSupply = math.ToBigInt(fmt.Sprintf("%v", token["totalSupply"]))
+	}
+
+	if token["flags"] != nil {
+		for _, f := range token["flags"].([]interface{}) {
+			t.Flags = append(t.Flags, f.(string))
+		}
+	}
+
 	if token["id"] != nil {
 		t.ID = bson.ObjectIdHex(token["id"].(string))
 	}
@@ -134,7 +180,7 @@ func (t *Token) UnmarshalJSON(b []byte) error {
 	}
 
 	if token["rank"] != nil {
-		t.Rank = token["rank"].(int)
+		t.Rank = int(token["rank"].(float64))
 	}
 
 	return nil
@@ -145,8 +191,10 @@ func (t *Token) GetBSON() (interface{}, error) {
 	tr := TokenRecord{
 		ID:        t.ID,
 		Symbol:    t.Symbol,
+		Name:      t.Name,
 		Address:   t.Address.Hex(),
 		Decimals:  t.Decimals,
+		Flags:     t.Flags,
 		Active:    t.Active,
 		Listed:    t.Listed,
 		Quote:     t.Quote,
@@ -155,6 +203,10 @@ func (t *Token) GetBSON() (interface{}, error) {
 		UpdatedAt: t.UpdatedAt,
 	}
 
+	if t.TotalSupply != nil {
+		tr.TotalSupply = t.TotalSupply.String()
+	}
+
 	if t.MakeFee != nil {
 		tr.MakeFee = t.MakeFee.String()
 	}
@@ -177,11 +229,13 @@ func (t *Token) SetBSON(raw bson.Raw) error {
 
 	t.ID = decoded.ID
 	t.Symbol = decoded.Symbol
+	t.Name = decoded.Name
 	if common.IsHexAddress(decoded.Address) {
 		t.Address = common.HexToAddress(decoded.Address)
 	}
 
 	t.Decimals = decoded.Decimals
+	t.Flags = decoded.Flags
 	t.Active = decoded.Active
 	t.Listed = decoded.Listed
 	t.Quote = decoded.Quote
@@ -189,6 +243,10 @@ func (t *Token) SetBSON(raw bson.Raw) error {
 	t.UpdatedAt = decoded.UpdatedAt
 	t.Rank = decoded.Rank
 
+	if decoded.TotalSupply != "" {
+		t.TotalSupply = math.ToBigInt(decoded.TotalSupply)
+	}
+
 	if decoded.MakeFee != "" {
 		t.MakeFee = math.ToBigInt(decoded.MakeFee)
 	}
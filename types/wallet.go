@@ -3,17 +3,26 @@ package types
 import (
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/globalsign/mgo/bson"
 )
 
-// Wallet holds both the address and the private key of an ethereum account
+// Wallet holds the address of an ethereum account and the means to sign with it.
+// Wallets are either backed by an encrypted keystore, in which case the account
+// must be unlocked in the keystore, or by a raw private key (e.g. test wallets).
+// Neither the keystore nor the private key are persisted.
 type Wallet struct {
 	ID         bson.ObjectId
 	Address    common.Address
 	PrivateKey *ecdsa.PrivateKey
+	Keystore   *keystore.KeyStore
 	Admin      bool
 	Operator   bool
 }
@@ -43,13 +52,61 @@ func NewWalletFromPrivateKey(key string) *Wallet {
 	}
 }
 
+// Unlock unlocks the wallet account in the wallet keystore with the given passphrase
+func (w *Wallet) Unlock(passphrase string) error {
+	if w.Keystore == nil {
+		return errors.New("Wallet is not backed by a keystore")
+	}
+
+	acc := accounts.Account{Address: w.Address}
+	if !w.Keystore.HasAddress(w.Address) {
+		return errors.New("Account not found in keystore: " + w.Address.Hex())
+	}
+
+	return w.Keystore.Unlock(acc, passphrase)
+}
+
+// NewTransactor returns transaction options signing with the wallet private key
+// or with the unlocked keystore account. Transactions sent from a wallet that can
+// not sign fail when they are signed.
+func (w *Wallet) NewTransactor() *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: w.Address,
+		Signer: func(signer eth.Signer, address common.Address, tx *eth.Transaction) (*eth.Transaction, error) {
+			if address != w.Address {
+				return nil, errors.New("not authorized to sign this account")
+			}
+
+			return w.SignTx(tx)
+		},
+	}
+}
+
+// SignTx signs a transaction with the wallet private key or with the unlocked keystore account
+func (w *Wallet) SignTx(tx *eth.Transaction) (*eth.Transaction, error) {
+	if w.PrivateKey != nil {
+		return eth.SignTx(tx, eth.HomesteadSigner{}, w.PrivateKey)
+	}
+
+	if w.Keystore != nil {
+		return w.Keystore.SignTx(accounts.Account{Address: w.Address}, tx, nil)
+	}
+
+	return nil, errors.New("Wallet can not sign transactions")
+}
+
 // GetAddress returns the wallet address
 func (w *Wallet) GetAddress() string {
 	return w.Address.Hex()
 }
 
-// GetPrivateKey returns the wallet private key
+// GetPrivateKey returns the wallet private key. Keystore wallets do not expose
+// their private key, in which case an empty string is returned
 func (w *Wallet) GetPrivateKey() string {
+	if w.PrivateKey == nil {
+		return ""
+	}
+
 	return hex.EncodeToString(w.PrivateKey.D.Bytes())
 }
 
@@ -58,19 +115,18 @@ func (w *Wallet) Validate() error {
 }
 
 type WalletRecord struct {
-	ID         bson.ObjectId `json:"id,omitempty" bson:"_id"`
-	Address    string        `json:"address" bson:"address"`
-	PrivateKey string        `json:"privateKey" bson:"privateKey"`
-	Admin      bool          `json:"admin" bson:"admin"`
-	Operator   bool          `json:"operator" bson:"operator"`
+	ID       bson.ObjectId `json:"id,omitempty" bson:"_id"`
+	Address  string        `json:"address" bson:"address"`
+	Admin    bool          `json:"admin" bson:"admin"`
+	Operator bool          `json:"operator" bson:"operator"`
 }
 
 func (w *Wallet) GetBSON() (interface{}, error) {
 	return WalletRecord{
-		ID:         w.ID,
-		Address:    w.Address.Hex(),
-		PrivateKey: hex.EncodeToString(w.PrivateKey.D.Bytes()),
-		Admin:      w.Admin,
+		ID:       w.ID,
+		Address:  w.Address.Hex(),
+		Admin:    w.Admin,
+		Operator: w.Operator,
 	}, nil
 }
 
@@ -84,12 +140,6 @@ func (w *Wallet) SetBSON(raw bson.Raw) error {
 
 	w.ID = decoded.ID
 	w.Address = common.HexToAddress(decoded.Address)
-	w.PrivateKey, err = crypto.HexToECDSA(decoded.PrivateKey)
-	if err != nil {
-		logger.Error(err)
-		return err
-	}
-
 	w.Admin = decoded.Admin
 	w.Operator = decoded.Operator
 	return nil
@@ -103,7 +153,22 @@ func (w *Wallet) SignHash(h common.Hash) (*Signature, error) {
 		h.Bytes(),
 	)
 
-	sigBytes, err := crypto.Sign(message, w.PrivateKey)
+	return w.SignDigest(common.BytesToHash(message))
+}
+
+// SignDigest signs a digest as is, without the Ethereum signed message prefix.
+// It is used for EIP-712 typed data whose digest already carries its own prefix
+func (w *Wallet) SignDigest(d common.Hash) (*Signature, error) {
+	var sigBytes []byte
+	var err error
+	if w.PrivateKey != nil {
+		sigBytes, err = crypto.Sign(d.Bytes(), w.PrivateKey)
+	} else if w.Keystore != nil {
+		sigBytes, err = w.Keystore.SignHash(accounts.Account{Address: w.Address}, d.Bytes())
+	} else {
+		err = errors.New("Wallet can not sign messages")
+	}
+
 	if err != nil {
 		return &Signature{}, err
 	}
@@ -118,13 +183,5 @@ func (w *Wallet) SignHash(h common.Hash) (*Signature, error) {
 }
 
 func (w *Wallet) SignOrder(o *Order) error {
-	hash := o.ComputeHash()
-	sig, err := w.SignHash(hash)
-	if err != nil {
-		return err
-	}
-
-	o.Hash = hash
-	o.Signature = sig
-	return nil
+	return o.Sign(w)
 }
@@ -2,11 +2,18 @@ package types
 
 import (
 	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
 	"reflect"
 	"testing"
 
-	"github.com/stretchr/testify/assert"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/globalsign/mgo/bson"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewWallet(t *testing.T) {
@@ -63,10 +70,63 @@ func TestBSON(t *testing.T) {
 		"Address should be encoded and decoded correctly",
 	)
 
-	assert.Equal(
+	assert.Nil(
 		t,
-		hex.EncodeToString(decoded.PrivateKey.D.Bytes()),
-		"7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660",
-		"Private key should be encoded and decoded correctly",
+		decoded.PrivateKey,
+		"Private key should not be encoded",
 	)
 }
+
+func TestKeystoreWallet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	key, _ := crypto.HexToECDSA("7c78c6e2f65d0d84c44ac0f7b53d6e4dd7a82c35f51b251d387c2a69df712660")
+	acc, err := ks.ImportECDSA(key, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := &Wallet{Address: acc.Address, Keystore: ks}
+	err = w.Unlock("wrong passphrase")
+	if err == nil {
+		t.Error("Expected wallet unlock to fail with a wrong passphrase")
+	}
+
+	err = w.Unlock("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "0xE8E84ee367BC63ddB38d3D01bCCEF106c194dc47", w.GetAddress())
+	assert.Equal(t, "", w.GetPrivateKey())
+
+	// keystore and private key wallets must produce the same signatures
+	h := common.HexToHash("0x1")
+	sig, err := w.SignHash(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := NewWalletFromPrivateKey(hex.EncodeToString(crypto.FromECDSA(key))).SignHash(h)
+	assert.Equal(t, expected, sig)
+
+	tx := eth.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	opts := w.NewTransactor()
+	signed, err := opts.Signer(eth.HomesteadSigner{}, w.Address, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := eth.Sender(eth.HomesteadSigner{}, signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, w.Address, sender)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/sha3"
+)
+
+// WebsocketLogin authenticates a websocket connection as an account owner. The nonce is issued
+// by the server to the connection and can only be used once.
+type WebsocketLogin struct {
+	Address   common.Address `json:"address"`
+	Nonce     common.Hash    `json:"nonce"`
+	Signature *Signature     `json:"signature"`
+}
+
+// ComputeHash computes the hash signed (with personal_sign) to log in
+func (l *WebsocketLogin) ComputeHash() common.Hash {
+	sha := sha3.NewKeccak256()
+	sha.Write([]byte("login"))
+	sha.Write(l.Address.Bytes())
+	sha.Write(l.Nonce.Bytes())
+	return common.BytesToHash(sha.Sum(nil))
+}
+
+// Sign computes the hash and signs the login with the given wallet
+func (l *WebsocketLogin) Sign(w *Wallet) error {
+	l.Address = w.Address
+
+	sig, err := w.SignHash(l.ComputeHash())
+	if err != nil {
+		return err
+	}
+
+	l.Signature = sig
+	return nil
+}
+
+// VerifySignature returns an error if the login is not signed by the account owner
+func (l *WebsocketLogin) VerifySignature() error {
+	if l.Signature == nil {
+		return errors.New("Signature is missing")
+	}
+
+	address, err := l.Signature.Verify(personalMessageHash(l.ComputeHash()))
+	if err != nil {
+		return err
+	}
+
+	if address != l.Address {
+		return errors.New("Recovered address is incorrect")
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebsocketLoginVerifySignature(t *testing.T) {
+	w := NewWallet()
+	l := &WebsocketLogin{Nonce: common.HexToHash("0x1")}
+
+	err := l.Sign(w)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.NoError(t, l.VerifySignature())
+
+	// the signature is bound to the nonce
+	l.Nonce = common.HexToHash("0x2")
+	assert.Error(t, l.VerifySignature())
+
+	// and to the account
+	l.Nonce = common.HexToHash("0x1")
+	l.Address = NewWallet().Address
+	assert.Error(t, l.VerifySignature())
+}
@@ -2,13 +2,22 @@ package httputils
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
+	"strings"
+
+	"github.com/Proofsuite/amp-matching-engine/errors"
 )
 
 func WriteError(w http.ResponseWriter, code int, message string) {
 	Write(w, code, map[string]string{"error": message})
 }
 
+// WriteAPIError writes a structured error with the status code of the error
+func WriteAPIError(w http.ResponseWriter, err *errors.APIError) {
+	Write(w, err.Status, map[string]interface{}{"error": err})
+}
+
 func WriteJSON(w http.ResponseWriter, code int, payload interface{}) {
 	Write(w, code, map[string]interface{}{"data": payload})
 }
@@ -18,3 +27,20 @@ func Write(w http.ResponseWriter, code int, payload interface{}) {
 	w.WriteHeader(code)
 	w.Write(response)
 }
+
+// ClientIP returns the IP address of the client of a request. When trustProxy is set, the first
+// address of the X-Forwarded-For header is used, which requires a reverse proxy overwriting it.
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			return strings.TrimSpace(strings.Split(xff, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,156 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Rate limit categories. Order placements, order cancels and the other (market data)
+// requests have separate budgets.
+const (
+	Orders     = "orders"
+	Cancels    = "cancels"
+	MarketData = "market_data"
+)
+
+// sweepInterval is the interval at which the idle buckets are removed
+const sweepInterval = time.Minute
+
+// Limiter is a set of token buckets keyed by client identifier (IP, API key, account address).
+// Each bucket holds up to burst tokens and is refilled at rate tokens per second. A limiter
+// with a rate that is not positive allows every request.
+type Limiter struct {
+	rate      float64
+	burst     float64
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	mu        sync.Mutex
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// Result is the outcome of a rate limited request. Remaining and ResetAfter describe the most
+// constrained bucket of the request. RetryAfter is the time after which a rejected request
+// can be retried.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// NewLimiter returns a limiter refilling rate tokens per second up to burst tokens
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rate:      rate,
+		burst:     float64(burst),
+		buckets:   map[string]*bucket{},
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of each key. The request is allowed only if every bucket
+// holds a token, in which case a token is taken from all of them; a rejected request takes none.
+func (l *Limiter) Allow(keys ...string) Result {
+	if l.rate <= 0 {
+		return Result{Allowed: true, Limit: int(l.burst), Remaining: int(l.burst)}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		l.sweep(now)
+	}
+
+	var min *bucket
+	buckets := []*bucket{}
+	for _, k := range keys {
+		b := l.bucket(k, now)
+		buckets = append(buckets, b)
+
+		if min == nil || b.tokens < min.tokens {
+			min = b
+		}
+	}
+
+	if min == nil {
+		return Result{Allowed: true, Limit: int(l.burst), Remaining: int(l.burst)}
+	}
+
+	res := Result{Limit: int(l.burst)}
+	if min.tokens < 1 {
+		res.RetryAfter = l.duration(1 - min.tokens)
+		res.ResetAfter = l.duration(l.burst - min.tokens)
+		return res
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+
+	res.Allowed = true
+	res.Remaining = int(math.Floor(min.tokens))
+	res.ResetAfter = l.duration(l.burst - min.tokens)
+	return res
+}
+
+// bucket returns the refilled bucket of a key
+func (l *Limiter) bucket(k string, now time.Time) *bucket {
+	b := l.buckets[k]
+	if b == nil {
+		b = &bucket{tokens: l.burst, updatedAt: now}
+		l.buckets[k] = b
+		return b
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed*l.rate)
+		b.updatedAt = now
+	}
+
+	return b
+}
+
+// sweep removes the buckets that are full again, they are recreated on the next request
+func (l *Limiter) sweep(now time.Time) {
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.updatedAt).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+
+	l.lastSweep = now
+}
+
+// duration returns the time needed to refill the given number of tokens
+func (l *Limiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// Limits holds the limiters of the rate limit categories
+type Limits map[string]*Limiter
+
+// Allow takes a token from the buckets of the keys in the limiter of a category. Requests
+// of a category without limiter are allowed.
+func (l Limits) Allow(category string, keys ...string) Result {
+	limiter := l[category]
+	if limiter == nil {
+		return Result{Allowed: true}
+	}
+
+	return limiter.Allow(keys...)
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Unix(1540000000, 0)
+	l := NewLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	for i := 2; i >= 0; i-- {
+		res := l.Allow("ip:1.2.3.4")
+		assert.True(t, res.Allowed)
+		assert.Equal(t, i, res.Remaining)
+		assert.Equal(t, 3, res.Limit)
+	}
+
+	res := l.Allow("ip:1.2.3.4")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, res.ResetAfter)
+
+	// other keys have their own bucket
+	assert.True(t, l.Allow("ip:5.6.7.8").Allowed)
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.Allow("ip:1.2.3.4").Allowed)
+	assert.False(t, l.Allow("ip:1.2.3.4").Allowed)
+}
+
+func TestLimiterAllowSeveralKeys(t *testing.T) {
+	now := time.Unix(1540000000, 0)
+	l := NewLimiter(1, 2)
+	l.now = func() time.Time { return now }
+
+	assert.True(t, l.Allow("ip:1", "address:a").Allowed)
+	assert.True(t, l.Allow("ip:2", "address:a").Allowed)
+
+	// the address bucket is empty, the ip bucket is left untouched
+	assert.False(t, l.Allow("ip:3", "address:a").Allowed)
+	res := l.Allow("ip:3")
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+}
+
+func TestLimiterSweep(t *testing.T) {
+	now := time.Unix(1540000000, 0)
+	l := NewLimiter(1, 2)
+	l.now = func() time.Time { return now }
+	l.lastSweep = now
+
+	l.Allow("ip:1")
+	assert.Equal(t, 1, len(l.buckets))
+
+	now = now.Add(2 * sweepInterval)
+	l.Allow("ip:2")
+	assert.Equal(t, 1, len(l.buckets))
+}
+
+func TestLimitsDisabled(t *testing.T) {
+	limits := Limits{Orders: NewLimiter(0, 1)}
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, limits.Allow(Orders, "ip:1").Allowed)
+		assert.True(t, limits.Allow(Cancels, "ip:1").Allowed)
+	}
+}
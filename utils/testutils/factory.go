@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/Proofsuite/amp-matching-engine/app"
 	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/Proofsuite/amp-matching-engine/utils/math"
 	"github.com/ethereum/go-ethereum/common"
@@ -26,10 +27,12 @@ type OrderFactory struct {
 
 // OrderParams groups FeeMake, FeeTake, Nonce, Exipres
 // FeeMake and FeeTake are the default fees imposed on makers and takers
+// ChainID is the chain ID of the network the exchange smart contract is deployed on
 // Nonce is the ethereum account nonce that tracks the numbers of transactions
 // for the order factory account
 type OrderParams struct {
 	ExchangeAddress common.Address
+	ChainID         int64
 	MakeFee         *big.Int
 	TakeFee         *big.Int
 	Nonce           *big.Int
@@ -51,6 +54,7 @@ func NewOrderFactory(p *types.Pair, w *types.Wallet, exchangeAddress common.Addr
 		TakeFee:         big.NewInt(0),
 		Nonce:           big.NewInt(0),
 		ExchangeAddress: exchangeAddress,
+		ChainID:         app.Config.ChainID,
 	}
 
 	source := rand.NewSource(time.Now().UnixNano())
@@ -115,6 +119,7 @@ func (f *OrderFactory) NewOrder(baseToken common.Address, quoteToken common.Addr
 
 	o.UserAddress = f.Wallet.Address
 	o.ExchangeAddress = f.Params.ExchangeAddress
+	o.ChainID = f.Params.ChainID
 	o.BaseToken = baseToken
 	o.QuoteToken = quoteToken
 	o.PricePoint = big.NewInt(pricepoint)
@@ -133,6 +138,7 @@ func (f *OrderFactory) NewLargeOrder(baseToken common.Address, quoteToken common
 
 	o.UserAddress = f.Wallet.Address
 	o.ExchangeAddress = f.Params.ExchangeAddress
+	o.ChainID = f.Params.ChainID
 	o.BaseToken = baseToken
 	o.QuoteToken = quoteToken
 	o.Amount = amount
@@ -172,6 +178,8 @@ func (f *OrderFactory) NewCancelOrder(o *types.Order) (*types.OrderCancel, error
 	oc := &types.OrderCancel{}
 
 	oc.OrderHash = o.Hash
+	oc.ExchangeAddress = o.ExchangeAddress
+	oc.ChainID = f.Params.ChainID
 	oc.Sign(f.Wallet)
 	return oc, nil
 }
@@ -187,6 +195,7 @@ func (f *OrderFactory) NewBuyOrder(pricepoint int64, value float64, filled ...fl
 	o.Amount = math.Div(math.Mul(etherPoints, amountPoints), big.NewInt(100))
 	o.UserAddress = f.Wallet.Address
 	o.ExchangeAddress = f.Params.ExchangeAddress
+	o.ChainID = f.Params.ChainID
 	o.BaseToken = f.Pair.BaseTokenAddress
 	o.QuoteToken = f.Pair.QuoteTokenAddress
 	o.MakeFee = f.Params.MakeFee
@@ -229,6 +238,7 @@ func (f *OrderFactory) NewSellOrder(pricepoint int64, value float64, filled ...f
 	o.Amount = math.Div(math.Mul(etherPoints, amountPoints), big.NewInt(100))
 	o.UserAddress = f.Wallet.Address
 	o.ExchangeAddress = f.Params.ExchangeAddress
+	o.ChainID = f.Params.ChainID
 	o.BaseToken = f.Pair.BaseTokenAddress
 	o.QuoteToken = f.Pair.QuoteTokenAddress
 	o.MakeFee = f.Params.MakeFee
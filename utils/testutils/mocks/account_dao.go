@@ -56,6 +56,29 @@ func (_m *AccountDao) GetAll() ([]types.Account, error) {
 	return r0, r1
 }
 
+// GetBlockedAddresses provides a mock function with given fields:
+func (_m *AccountDao) GetBlockedAddresses() ([]common.Address, error) {
+	ret := _m.Called()
+
+	var r0 []common.Address
+	if rf, ok := ret.Get(0).(func() []common.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByAddress provides a mock function with given fields: owner
 func (_m *AccountDao) GetByAddress(owner common.Address) (*types.Account, error) {
 	ret := _m.Called(owner)
@@ -148,6 +171,34 @@ func (_m *AccountDao) GetTokenBalances(owner common.Address) (map[common.Address
 	return r0, r1
 }
 
+// SyncTokenBalance provides a mock function with given fields: owner, token, tokenBalance
+func (_m *AccountDao) SyncTokenBalance(owner common.Address, token common.Address, tokenBalance *types.TokenBalance) error {
+	ret := _m.Called(owner, token, tokenBalance)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, *types.TokenBalance) error); ok {
+		r0 = rf(owner, token, tokenBalance)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsyncTokenBalances provides a mock function with given fields: token
+func (_m *AccountDao) UnsyncTokenBalances(token common.Address) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateAllowance provides a mock function with given fields: owner, token, allowance
 func (_m *AccountDao) UpdateAllowance(owner common.Address, token common.Address, allowance *big.Int) error {
 	ret := _m.Called(owner, token, allowance)
@@ -176,6 +227,20 @@ func (_m *AccountDao) UpdateBalance(owner common.Address, token common.Address,
 	return r0
 }
 
+// UpdateIsBlocked provides a mock function with given fields: owner, blocked
+func (_m *AccountDao) UpdateIsBlocked(owner common.Address, blocked bool) error {
+	ret := _m.Called(owner, blocked)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, bool) error); ok {
+		r0 = rf(owner, blocked)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTokenBalance provides a mock function with given fields: owner, token, tokenBalance
 func (_m *AccountDao) UpdateTokenBalance(owner common.Address, token common.Address, tokenBalance *types.TokenBalance) error {
 	ret := _m.Called(owner, token, tokenBalance)
@@ -23,3 +23,19 @@ func (_m *Engine) HandleOrders(msg *rabbitmq.Message) error {
 
 	return r0
 }
+
+// OrderBookCodes provides a mock function with given fields:
+func (_m *Engine) OrderBookCodes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
@@ -37,6 +37,50 @@ func (_m *Exchange) CallTrade(o *types.Order, t *types.Trade, call *ethereum.Cal
 	return r0, r1
 }
 
+// CallTradeBatch provides a mock function with given fields: b, call
+func (_m *Exchange) CallTradeBatch(b *types.PendingTradeBatch, call *ethereum.CallMsg) (uint64, error) {
+	ret := _m.Called(b, call)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(*types.PendingTradeBatch, *ethereum.CallMsg) uint64); ok {
+		r0 = rf(b, call)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.PendingTradeBatch, *ethereum.CallMsg) error); ok {
+		r1 = rf(b, call)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecuteTradeBatch provides a mock function with given fields: b, txOpts
+func (_m *Exchange) ExecuteTradeBatch(b *types.PendingTradeBatch, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
+	ret := _m.Called(b, txOpts)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func(*types.PendingTradeBatch, *bind.TransactOpts) *coretypes.Transaction); ok {
+		r0 = rf(b, txOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.PendingTradeBatch, *bind.TransactOpts) error); ok {
+		r1 = rf(b, txOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FeeAccount provides a mock function with given fields:
 func (_m *Exchange) FeeAccount() (common.Address, error) {
 	ret := _m.Called()
@@ -60,6 +104,75 @@ func (_m *Exchange) FeeAccount() (common.Address, error) {
 	return r0, r1
 }
 
+// FilterBatchTrades provides a mock function with given fields: start, end
+func (_m *Exchange) FilterBatchTrades(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogBatchTrades, error) {
+	ret := _m.Called(start, end)
+
+	var r0 []*contractsinterfaces.ExchangeLogBatchTrades
+	if rf, ok := ret.Get(0).(func(uint64, *uint64) []*contractsinterfaces.ExchangeLogBatchTrades); ok {
+		r0 = rf(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contractsinterfaces.ExchangeLogBatchTrades)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, *uint64) error); ok {
+		r1 = rf(start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilterErrors provides a mock function with given fields: start, end
+func (_m *Exchange) FilterErrors(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogError, error) {
+	ret := _m.Called(start, end)
+
+	var r0 []*contractsinterfaces.ExchangeLogError
+	if rf, ok := ret.Get(0).(func(uint64, *uint64) []*contractsinterfaces.ExchangeLogError); ok {
+		r0 = rf(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contractsinterfaces.ExchangeLogError)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, *uint64) error); ok {
+		r1 = rf(start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilterTrades provides a mock function with given fields: start, end
+func (_m *Exchange) FilterTrades(start uint64, end *uint64) ([]*contractsinterfaces.ExchangeLogTrade, error) {
+	ret := _m.Called(start, end)
+
+	var r0 []*contractsinterfaces.ExchangeLogTrade
+	if rf, ok := ret.Get(0).(func(uint64, *uint64) []*contractsinterfaces.ExchangeLogTrade); ok {
+		r0 = rf(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contractsinterfaces.ExchangeLogTrade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, *uint64) error); ok {
+		r1 = rf(start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAddress provides a mock function with given fields:
 func (_m *Exchange) GetAddress() common.Address {
 	ret := _m.Called()
@@ -261,6 +374,27 @@ func (_m *Exchange) SetOperator(a common.Address, isOperator bool, txOpts *bind.
 	return r0, r1
 }
 
+// SimulateTradeBatch provides a mock function with given fields: b, call
+func (_m *Exchange) SimulateTradeBatch(b *types.PendingTradeBatch, call *ethereum.CallMsg) (bool, error) {
+	ret := _m.Called(b, call)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*types.PendingTradeBatch, *ethereum.CallMsg) bool); ok {
+		r0 = rf(b, call)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.PendingTradeBatch, *ethereum.CallMsg) error); ok {
+		r1 = rf(b, call)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Trade provides a mock function with given fields: o, t, txOpts
 func (_m *Exchange) Trade(o *types.Order, t *types.Trade, txOpts *bind.TransactOpts) (*coretypes.Transaction, error) {
 	ret := _m.Called(o, t, txOpts)
@@ -42,6 +42,29 @@ func (_m *OrderDao) Drop() error {
 	return r0
 }
 
+// GetByClientOrderID provides a mock function with given fields: addr, id
+func (_m *OrderDao) GetByClientOrderID(addr common.Address, id string) (*types.Order, error) {
+	ret := _m.Called(addr, id)
+
+	var r0 *types.Order
+	if rf, ok := ret.Get(0).(func(common.Address, string) *types.Order); ok {
+		r0 = rf(addr, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, string) error); ok {
+		r1 = rf(addr, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByHash provides a mock function with given fields: hash
 func (_m *OrderDao) GetByHash(hash common.Hash) (*types.Order, error) {
 	ret := _m.Called(hash)
@@ -157,6 +180,29 @@ func (_m *OrderDao) GetCurrentByUserAddress(addr common.Address) ([]*types.Order
 	return r0, r1
 }
 
+// GetCurrentOrders provides a mock function with given fields:
+func (_m *OrderDao) GetCurrentOrders() ([]*types.Order, error) {
+	ret := _m.Called()
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func() []*types.Order); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHistoryByUserAddress provides a mock function with given fields: addr
 func (_m *OrderDao) GetHistoryByUserAddress(addr common.Address) ([]*types.Order, error) {
 	ret := _m.Called(addr)
@@ -180,13 +226,59 @@ func (_m *OrderDao) GetHistoryByUserAddress(addr common.Address) ([]*types.Order
 	return r0, r1
 }
 
-// GetUserLockedBalance provides a mock function with given fields: account, token
-func (_m *OrderDao) GetUserLockedBalance(account common.Address, token common.Address) (*big.Int, error) {
-	ret := _m.Called(account, token)
+// GetHistoryPage provides a mock function with given fields: q
+func (_m *OrderDao) GetHistoryPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	ret := _m.Called(q)
+
+	var r0 *types.OrderPage
+	if rf, ok := ret.Get(0).(func(*types.HistoryQuery) *types.OrderPage); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OrderPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.HistoryQuery) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPage provides a mock function with given fields: q
+func (_m *OrderDao) GetPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	ret := _m.Called(q)
+
+	var r0 *types.OrderPage
+	if rf, ok := ret.Get(0).(func(*types.HistoryQuery) *types.OrderPage); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OrderPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.HistoryQuery) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserLockedBalance provides a mock function with given fields: account, token, p
+func (_m *OrderDao) GetUserLockedBalance(account common.Address, token common.Address, p *types.Pair) (*big.Int, error) {
+	ret := _m.Called(account, token, p)
 
 	var r0 *big.Int
-	if rf, ok := ret.Get(0).(func(common.Address, common.Address) *big.Int); ok {
-		r0 = rf(account, token)
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, *types.Pair) *big.Int); ok {
+		r0 = rf(account, token, p)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*big.Int)
@@ -194,8 +286,8 @@ func (_m *OrderDao) GetUserLockedBalance(account common.Address, token common.Ad
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
-		r1 = rf(account, token)
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address, *types.Pair) error); ok {
+		r1 = rf(account, token, p)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -203,6 +295,48 @@ func (_m *OrderDao) GetUserLockedBalance(account common.Address, token common.Ad
 	return r0, r1
 }
 
+// MigrateNonces provides a mock function with given fields:
+func (_m *OrderDao) MigrateNonces() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: o
+func (_m *OrderDao) Reserve(o *types.Order) error {
+	ret := _m.Called(o)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*types.Order) error); ok {
+		r0 = rf(o)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamByUserAddress provides a mock function with given fields: addr, fn
+func (_m *OrderDao) StreamByUserAddress(addr common.Address, fn func(*types.Order) error) error {
+	ret := _m.Called(addr, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, func(*types.Order) error) error); ok {
+		r0 = rf(addr, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: id, o
 func (_m *OrderDao) Update(id bson.ObjectId, o *types.Order) error {
 	ret := _m.Called(id, o)
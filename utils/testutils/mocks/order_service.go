@@ -4,6 +4,7 @@ package mocks
 
 import bson "github.com/globalsign/mgo/bson"
 import common "github.com/ethereum/go-ethereum/common"
+import time "time"
 
 import mock "github.com/stretchr/testify/mock"
 import types "github.com/Proofsuite/amp-matching-engine/types"
@@ -13,6 +14,29 @@ type OrderService struct {
 	mock.Mock
 }
 
+// BlockAccount provides a mock function with given fields: a
+func (_m *OrderService) BlockAccount(a common.Address) ([]*types.Order, error) {
+	ret := _m.Called(a)
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func(common.Address) []*types.Order); ok {
+		r0 = rf(a)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(a)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CancelOrder provides a mock function with given fields: oc
 func (_m *OrderService) CancelOrder(oc *types.OrderCancel) error {
 	ret := _m.Called(oc)
@@ -27,6 +51,29 @@ func (_m *OrderService) CancelOrder(oc *types.OrderCancel) error {
 	return r0
 }
 
+// CancelOrderSync provides a mock function with given fields: oc, timeout
+func (_m *OrderService) CancelOrderSync(oc *types.OrderCancel, timeout time.Duration) (*types.EngineResponse, error) {
+	ret := _m.Called(oc, timeout)
+
+	var r0 *types.EngineResponse
+	if rf, ok := ret.Get(0).(func(*types.OrderCancel, time.Duration) *types.EngineResponse); ok {
+		r0 = rf(oc, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.EngineResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.OrderCancel, time.Duration) error); ok {
+		r1 = rf(oc, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CancelTrades provides a mock function with given fields: trades
 func (_m *OrderService) CancelTrades(trades []*types.Trade) error {
 	ret := _m.Called(trades)
@@ -41,6 +88,52 @@ func (_m *OrderService) CancelTrades(trades []*types.Trade) error {
 	return r0
 }
 
+// ForceCancelOrder provides a mock function with given fields: h
+func (_m *OrderService) ForceCancelOrder(h common.Hash) (*types.Order, error) {
+	ret := _m.Called(h)
+
+	var r0 *types.Order
+	if rf, ok := ret.Get(0).(func(common.Hash) *types.Order); ok {
+		r0 = rf(h)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(h)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByClientOrderID provides a mock function with given fields: a, id
+func (_m *OrderService) GetByClientOrderID(a common.Address, id string) (*types.Order, error) {
+	ret := _m.Called(a, id)
+
+	var r0 *types.Order
+	if rf, ok := ret.Get(0).(func(common.Address, string) *types.Order); ok {
+		r0 = rf(a, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, string) error); ok {
+		r1 = rf(a, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByHash provides a mock function with given fields: hash
 func (_m *OrderService) GetByHash(hash common.Hash) (*types.Order, error) {
 	ret := _m.Called(hash)
@@ -156,6 +249,52 @@ func (_m *OrderService) GetHistoryByUserAddress(addr common.Address) ([]*types.O
 	return r0, r1
 }
 
+// GetHistoryPage provides a mock function with given fields: q
+func (_m *OrderService) GetHistoryPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	ret := _m.Called(q)
+
+	var r0 *types.OrderPage
+	if rf, ok := ret.Get(0).(func(*types.HistoryQuery) *types.OrderPage); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OrderPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.HistoryQuery) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPage provides a mock function with given fields: q
+func (_m *OrderService) GetPage(q *types.HistoryQuery) (*types.OrderPage, error) {
+	ret := _m.Called(q)
+
+	var r0 *types.OrderPage
+	if rf, ok := ret.Get(0).(func(*types.HistoryQuery) *types.OrderPage); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OrderPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.HistoryQuery) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HandleEngineResponse provides a mock function with given fields: res
 func (_m *OrderService) HandleEngineResponse(res *types.EngineResponse) error {
 	ret := _m.Called(res)
@@ -184,6 +323,29 @@ func (_m *OrderService) NewOrder(o *types.Order) error {
 	return r0
 }
 
+// NewOrderSync provides a mock function with given fields: o, timeout
+func (_m *OrderService) NewOrderSync(o *types.Order, timeout time.Duration) (*types.EngineResponse, error) {
+	ret := _m.Called(o, timeout)
+
+	var r0 *types.EngineResponse
+	if rf, ok := ret.Get(0).(func(*types.Order, time.Duration) *types.EngineResponse); ok {
+		r0 = rf(o, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.EngineResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Order, time.Duration) error); ok {
+		r1 = rf(o, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RelayOrderUpdate provides a mock function with given fields: res
 func (_m *OrderService) RelayOrderUpdate(res *types.EngineResponse) {
 	_m.Called(res)
@@ -199,6 +361,29 @@ func (_m *OrderService) RelayUpdateOverSocket(res *types.EngineResponse) {
 	_m.Called(res)
 }
 
+// ReloadDenyList provides a mock function with given fields:
+func (_m *OrderService) ReloadDenyList() ([]*types.Order, error) {
+	ret := _m.Called()
+
+	var r0 []*types.Order
+	if rf, ok := ret.Get(0).(func() []*types.Order); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Rollback provides a mock function with given fields: res
 func (_m *OrderService) Rollback(res *types.EngineResponse) *types.EngineResponse {
 	ret := _m.Called(res)
@@ -242,3 +427,8 @@ func (_m *OrderService) RollbackTrade(o *types.Order, t *types.Trade) error {
 
 	return r0
 }
+
+// UnblockAccount provides a mock function with given fields: a
+func (_m *OrderService) UnblockAccount(a common.Address) {
+	_m.Called(a)
+}
@@ -164,3 +164,17 @@ func (_m *PairDao) GetByTokenSymbols(baseTokenSymbol string, quoteTokenSymbol st
 
 	return r0, r1
 }
+
+// UpdateStatus provides a mock function with given fields: baseToken, quoteToken, listed, active
+func (_m *PairDao) UpdateStatus(baseToken common.Address, quoteToken common.Address, listed bool, active bool) error {
+	ret := _m.Called(baseToken, quoteToken, listed, active)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, bool, bool) error); ok {
+		r0 = rf(baseToken, quoteToken, listed, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
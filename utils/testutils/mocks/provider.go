@@ -6,6 +6,7 @@ import big "math/big"
 import common "github.com/ethereum/go-ethereum/common"
 
 import mock "github.com/stretchr/testify/mock"
+import time "time"
 import types "github.com/ethereum/go-ethereum/core/types"
 
 // EthereumProvider is an autogenerated mock type for the EthereumProvider type
@@ -59,6 +60,50 @@ func (_m *EthereumProvider) BalanceOf(owner common.Address, token common.Address
 	return r0, r1
 }
 
+// BlockNumber provides a mock function with given fields:
+func (_m *EthereumProvider) BlockNumber() (uint64, error) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallTransfer provides a mock function with given fields: token, from, to, amount
+func (_m *EthereumProvider) CallTransfer(token common.Address, from common.Address, to common.Address, amount *big.Int) ([]byte, error) {
+	ret := _m.Called(token, from, to, amount)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, common.Address, *big.Int) []byte); ok {
+		r0 = rf(token, from, to, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address, common.Address, *big.Int) error); ok {
+		r1 = rf(token, from, to, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExchangeAllowance provides a mock function with given fields: owner, token
 func (_m *EthereumProvider) ExchangeAllowance(owner common.Address, token common.Address) (*big.Int, error) {
 	ret := _m.Called(owner, token)
@@ -105,6 +150,27 @@ func (_m *EthereumProvider) GetBalanceAt(a common.Address) (*big.Int, error) {
 	return r0, r1
 }
 
+// GetBlockTime provides a mock function with given fields: n
+func (_m *EthereumProvider) GetBlockTime(n uint64) (time.Time, error) {
+	ret := _m.Called(n)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(uint64) time.Time); ok {
+		r0 = rf(n)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(n)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPendingNonceAt provides a mock function with given fields: a
 func (_m *EthereumProvider) GetPendingNonceAt(a common.Address) (uint64, error) {
 	ret := _m.Called(a)
@@ -126,6 +192,96 @@ func (_m *EthereumProvider) GetPendingNonceAt(a common.Address) (uint64, error)
 	return r0, r1
 }
 
+// GetTransactionReceipt provides a mock function with given fields: h
+func (_m *EthereumProvider) GetTransactionReceipt(h common.Hash) (*types.Receipt, error) {
+	ret := _m.Called(h)
+
+	var r0 *types.Receipt
+	if rf, ok := ret.Get(0).(func(common.Hash) *types.Receipt); ok {
+		r0 = rf(h)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(h)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields: token
+func (_m *EthereumProvider) Name(token common.Address) (string, error) {
+	ret := _m.Called(token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(common.Address) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecentTransfers provides a mock function with given fields: token, blocks
+func (_m *EthereumProvider) RecentTransfers(token common.Address, blocks int64) ([]types.Log, error) {
+	ret := _m.Called(token, blocks)
+
+	var r0 []types.Log
+	if rf, ok := ret.Get(0).(func(common.Address, int64) []types.Log); ok {
+		r0 = rf(token, blocks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Log)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, int64) error); ok {
+		r1 = rf(token, blocks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TotalSupply provides a mock function with given fields: token
+func (_m *EthereumProvider) TotalSupply(token common.Address) (*big.Int, error) {
+	ret := _m.Called(token)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Address) *big.Int); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitMined provides a mock function with given fields: hash
 func (_m *EthereumProvider) WaitMined(hash common.Hash) (*types.Receipt, error) {
 	ret := _m.Called(hash)
@@ -155,3 +155,17 @@ func (_m *TokenDao) GetQuoteTokens() ([]types.Token, error) {
 
 	return r0, r1
 }
+
+// UpdateStatus provides a mock function with given fields: addr, listed, active
+func (_m *TokenDao) UpdateStatus(addr common.Address, listed bool, active bool) error {
+	ret := _m.Called(addr, listed, active)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, bool, bool) error); ok {
+		r0 = rf(addr, listed, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
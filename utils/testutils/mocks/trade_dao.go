@@ -2,8 +2,10 @@
 
 package mocks
 
+import big "math/big"
 import bson "github.com/globalsign/mgo/bson"
 import common "github.com/ethereum/go-ethereum/common"
+import time "time"
 
 import mock "github.com/stretchr/testify/mock"
 import types "github.com/Proofsuite/amp-matching-engine/types"
@@ -84,6 +86,29 @@ func (_m *TradeDao) FindAndModify(h common.Hash, t *types.Trade) (*types.Trade,
 	return r0, r1
 }
 
+// GetAccountVolumes provides a mock function with given fields: a, qt, since
+func (_m *TradeDao) GetAccountVolumes(a common.Address, qt common.Address, since time.Time) (map[common.Address]*big.Int, error) {
+	ret := _m.Called(a, qt, since)
+
+	var r0 map[common.Address]*big.Int
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, time.Time) map[common.Address]*big.Int); ok {
+		r0 = rf(a, qt, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[common.Address]*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address, time.Time) error); ok {
+		r1 = rf(a, qt, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields:
 func (_m *TradeDao) GetAll() ([]types.Trade, error) {
 	ret := _m.Called()
@@ -245,6 +270,29 @@ func (_m *TradeDao) GetByTakerOrderHash(h common.Hash) ([]*types.Trade, error) {
 	return r0, r1
 }
 
+// GetByTxHashes provides a mock function with given fields: hashes
+func (_m *TradeDao) GetByTxHashes(hashes []common.Hash) ([]*types.Trade, error) {
+	ret := _m.Called(hashes)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func([]common.Hash) []*types.Trade); ok {
+		r0 = rf(hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]common.Hash) error); ok {
+		r1 = rf(hashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUserAddress provides a mock function with given fields: a
 func (_m *TradeDao) GetByUserAddress(a common.Address) ([]*types.Trade, error) {
 	ret := _m.Called(a)
@@ -291,6 +339,98 @@ func (_m *TradeDao) GetNTradesByPairAddress(bt common.Address, qt common.Address
 	return r0, r1
 }
 
+// GetPage provides a mock function with given fields: q
+func (_m *TradeDao) GetPage(q *types.HistoryQuery) (*types.TradePage, error) {
+	ret := _m.Called(q)
+
+	var r0 *types.TradePage
+	if rf, ok := ret.Get(0).(func(*types.HistoryQuery) *types.TradePage); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TradePage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.HistoryQuery) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingTradesBefore provides a mock function with given fields: t
+func (_m *TradeDao) GetPendingTradesBefore(t time.Time) ([]*types.Trade, error) {
+	ret := _m.Called(t)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(time.Time) []*types.Trade); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnsentTrades provides a mock function with given fields:
+func (_m *TradeDao) GetUnsentTrades() ([]*types.Trade, error) {
+	ret := _m.Called()
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func() []*types.Trade); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettledTradesBetween provides a mock function with given fields: start, end
+func (_m *TradeDao) GetSettledTradesBetween(start time.Time, end time.Time) ([]*types.Trade, error) {
+	ret := _m.Called(start, end)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*types.Trade); ok {
+		r0 = rf(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSortedTrades provides a mock function with given fields: bt, qt, n
 func (_m *TradeDao) GetSortedTrades(bt common.Address, qt common.Address, n int) ([]*types.Trade, error) {
 	ret := _m.Called(bt, qt, n)
@@ -367,6 +507,20 @@ func (_m *TradeDao) GetTradesByPairAddress(bt common.Address, qt common.Address,
 	return r0, r1
 }
 
+// StreamByUserAddress provides a mock function with given fields: a, fn
+func (_m *TradeDao) StreamByUserAddress(a common.Address, fn func(*types.Trade) error) error {
+	ret := _m.Called(a, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address, func(*types.Trade) error) error); ok {
+		r0 = rf(a, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: t
 func (_m *TradeDao) Update(t *types.Trade) error {
 	ret := _m.Called(t)
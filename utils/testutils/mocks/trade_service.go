@@ -13,13 +13,36 @@ type TradeService struct {
 	mock.Mock
 }
 
-// GetByHash provides a mock function with given fields: hash
-func (_m *TradeService) GetByHash(hash common.Hash) (*types.Trade, error) {
-	ret := _m.Called(hash)
+// GetAllTradesByPairAddress provides a mock function with given fields: bt, qt
+func (_m *TradeService) GetAllTradesByPairAddress(bt common.Address, qt common.Address) ([]*types.Trade, error) {
+	ret := _m.Called(bt, qt)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address) []*types.Trade); ok {
+		r0 = rf(bt, qt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
+		r1 = rf(bt, qt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByHash provides a mock function with given fields: h
+func (_m *TradeService) GetByHash(h common.Hash) (*types.Trade, error) {
+	ret := _m.Called(h)
 
 	var r0 *types.Trade
 	if rf, ok := ret.Get(0).(func(common.Hash) *types.Trade); ok {
-		r0 = rf(hash)
+		r0 = rf(h)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.Trade)
@@ -28,7 +51,7 @@ func (_m *TradeService) GetByHash(hash common.Hash) (*types.Trade, error) {
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
-		r1 = rf(hash)
+		r1 = rf(h)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -36,13 +59,13 @@ func (_m *TradeService) GetByHash(hash common.Hash) (*types.Trade, error) {
 	return r0, r1
 }
 
-// GetByOrderHash provides a mock function with given fields: hash
-func (_m *TradeService) GetByOrderHash(hash common.Hash) ([]*types.Trade, error) {
-	ret := _m.Called(hash)
+// GetByMakerOrderHash provides a mock function with given fields: h
+func (_m *TradeService) GetByMakerOrderHash(h common.Hash) ([]*types.Trade, error) {
+	ret := _m.Called(h)
 
 	var r0 []*types.Trade
 	if rf, ok := ret.Get(0).(func(common.Hash) []*types.Trade); ok {
-		r0 = rf(hash)
+		r0 = rf(h)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Trade)
@@ -51,7 +74,7 @@ func (_m *TradeService) GetByOrderHash(hash common.Hash) ([]*types.Trade, error)
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
-		r1 = rf(hash)
+		r1 = rf(h)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,13 +82,13 @@ func (_m *TradeService) GetByOrderHash(hash common.Hash) ([]*types.Trade, error)
 	return r0, r1
 }
 
-// GetByPairAddress provides a mock function with given fields: bt, qt
-func (_m *TradeService) GetByPairAddress(bt common.Address, qt common.Address) ([]*types.Trade, error) {
-	ret := _m.Called(bt, qt)
+// GetByOrderHashes provides a mock function with given fields: h
+func (_m *TradeService) GetByOrderHashes(h []common.Hash) ([]*types.Trade, error) {
+	ret := _m.Called(h)
 
 	var r0 []*types.Trade
-	if rf, ok := ret.Get(0).(func(common.Address, common.Address) []*types.Trade); ok {
-		r0 = rf(bt, qt)
+	if rf, ok := ret.Get(0).(func([]common.Hash) []*types.Trade); ok {
+		r0 = rf(h)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Trade)
@@ -73,8 +96,8 @@ func (_m *TradeService) GetByPairAddress(bt common.Address, qt common.Address) (
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
-		r1 = rf(bt, qt)
+	if rf, ok := ret.Get(1).(func([]common.Hash) error); ok {
+		r1 = rf(h)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,13 +128,36 @@ func (_m *TradeService) GetByPairName(p string) ([]*types.Trade, error) {
 	return r0, r1
 }
 
-// GetByUserAddress provides a mock function with given fields: addr
-func (_m *TradeService) GetByUserAddress(addr common.Address) ([]*types.Trade, error) {
-	ret := _m.Called(addr)
+// GetByTakerOrderHash provides a mock function with given fields: h
+func (_m *TradeService) GetByTakerOrderHash(h common.Hash) ([]*types.Trade, error) {
+	ret := _m.Called(h)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(common.Hash) []*types.Trade); ok {
+		r0 = rf(h)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(h)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserAddress provides a mock function with given fields: a
+func (_m *TradeService) GetByUserAddress(a common.Address) ([]*types.Trade, error) {
+	ret := _m.Called(a)
 
 	var r0 []*types.Trade
 	if rf, ok := ret.Get(0).(func(common.Address) []*types.Trade); ok {
-		r0 = rf(addr)
+		r0 = rf(a)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.Trade)
@@ -120,7 +166,7 @@ func (_m *TradeService) GetByUserAddress(addr common.Address) ([]*types.Trade, e
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(addr)
+		r1 = rf(a)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -128,22 +174,22 @@ func (_m *TradeService) GetByUserAddress(addr common.Address) ([]*types.Trade, e
 	return r0, r1
 }
 
-// GetTrades provides a mock function with given fields: bt, qt
-func (_m *TradeService) GetTrades(bt common.Address, qt common.Address) ([]types.Trade, error) {
-	ret := _m.Called(bt, qt)
+// GetPage provides a mock function with given fields: q
+func (_m *TradeService) GetPage(q *types.HistoryQuery) (*types.TradePage, error) {
+	ret := _m.Called(q)
 
-	var r0 []types.Trade
-	if rf, ok := ret.Get(0).(func(common.Address, common.Address) []types.Trade); ok {
-		r0 = rf(bt, qt)
+	var r0 *types.TradePage
+	if rf, ok := ret.Get(0).(func(*types.HistoryQuery) *types.TradePage); ok {
+		r0 = rf(q)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.Trade)
+			r0 = ret.Get(0).(*types.TradePage)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address, common.Address) error); ok {
-		r1 = rf(bt, qt)
+	if rf, ok := ret.Get(1).(func(*types.HistoryQuery) error); ok {
+		r1 = rf(q)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -151,23 +197,150 @@ func (_m *TradeService) GetTrades(bt common.Address, qt common.Address) ([]types
 	return r0, r1
 }
 
-// Subscribe provides a mock function with given fields: conn, bt, qt
-func (_m *TradeService) Subscribe(conn *ws.Client, bt common.Address, qt common.Address) {
-	_m.Called(conn, bt, qt)
+// GetSortedTrades provides a mock function with given fields: bt, qt, n
+func (_m *TradeService) GetSortedTrades(bt common.Address, qt common.Address, n int) ([]*types.Trade, error) {
+	ret := _m.Called(bt, qt, n)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(common.Address, common.Address, int) []*types.Trade); ok {
+		r0 = rf(bt, qt, n)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, common.Address, int) error); ok {
+		r1 = rf(bt, qt, n)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Unsubscribe provides a mock function with given fields: conn, bt, qt
-func (_m *TradeService) Unsubscribe(conn *ws.Client, bt common.Address, qt common.Address) {
-	_m.Called(conn, bt, qt)
+// GetSortedTradesByUserAddress provides a mock function with given fields: a, limit
+func (_m *TradeService) GetSortedTradesByUserAddress(a common.Address, limit ...int) ([]*types.Trade, error) {
+	_va := make([]interface{}, len(limit))
+	for _i := range limit {
+		_va[_i] = limit[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, a)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func(common.Address, ...int) []*types.Trade); ok {
+		r0 = rf(a, limit...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address, ...int) error); ok {
+		r1 = rf(a, limit...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnsentTrades provides a mock function with given fields:
+func (_m *TradeService) GetUnsentTrades() ([]*types.Trade, error) {
+	ret := _m.Called()
+
+	var r0 []*types.Trade
+	if rf, ok := ret.Get(0).(func() []*types.Trade); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Subscribe provides a mock function with given fields: c, bt, qt
+func (_m *TradeService) Subscribe(c *ws.Client, bt common.Address, qt common.Address) {
+	_m.Called(c, bt, qt)
+}
+
+// Unsubscribe provides a mock function with given fields: c
+func (_m *TradeService) Unsubscribe(c *ws.Client) {
+	_m.Called(c)
+}
+
+// UnsubscribeChannel provides a mock function with given fields: c, bt, qt
+func (_m *TradeService) UnsubscribeChannel(c *ws.Client, bt common.Address, qt common.Address) {
+	_m.Called(c, bt, qt)
+}
+
+// UpdatePendingTrade provides a mock function with given fields: t, txh
+func (_m *TradeService) UpdatePendingTrade(t *types.Trade, txh common.Hash) (*types.Trade, error) {
+	ret := _m.Called(t, txh)
+
+	var r0 *types.Trade
+	if rf, ok := ret.Get(0).(func(*types.Trade, common.Hash) *types.Trade); ok {
+		r0 = rf(t, txh)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Trade, common.Hash) error); ok {
+		r1 = rf(t, txh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSuccessfulTrade provides a mock function with given fields: t
+func (_m *TradeService) UpdateSuccessfulTrade(t *types.Trade) (*types.Trade, error) {
+	ret := _m.Called(t)
+
+	var r0 *types.Trade
+	if rf, ok := ret.Get(0).(func(*types.Trade) *types.Trade); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Trade)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Trade) error); ok {
+		r1 = rf(t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdateTradeTxHash provides a mock function with given fields: tr, txHash
-func (_m *TradeService) UpdateTradeTxHash(tr *types.Trade, txHash common.Hash) error {
-	ret := _m.Called(tr, txHash)
+// UpdateTradeTxHash provides a mock function with given fields: tr, txh
+func (_m *TradeService) UpdateTradeTxHash(tr *types.Trade, txh common.Hash) error {
+	ret := _m.Called(tr, txh)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(*types.Trade, common.Hash) error); ok {
-		r0 = rf(tr, txHash)
+		r0 = rf(tr, txh)
 	} else {
 		r0 = ret.Error(0)
 	}
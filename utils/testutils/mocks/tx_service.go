@@ -13,7 +13,7 @@ type TxService struct {
 }
 
 // GetCustomTxSendOptions provides a mock function with given fields: w
-func (_m *TxService) GetCustomTxSendOptions(w *types.Wallet) *bind.TransactOpts {
+func (_m *TxService) GetCustomTxSendOptions(w *types.Wallet) (*bind.TransactOpts, error) {
 	ret := _m.Called(w)
 
 	var r0 *bind.TransactOpts
@@ -25,7 +25,14 @@ func (_m *TxService) GetCustomTxSendOptions(w *types.Wallet) *bind.TransactOpts
 		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Wallet) error); ok {
+		r1 = rf(w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetTxCallOptions provides a mock function with given fields:
@@ -141,3 +141,40 @@ func (_m *WalletDao) GetOperatorWallets() ([]*types.Wallet, error) {
 
 	return r0, r1
 }
+
+// GetPrivateKeys provides a mock function with given fields:
+func (_m *WalletDao) GetPrivateKeys() (map[common.Address]string, error) {
+	ret := _m.Called()
+
+	var r0 map[common.Address]string
+	if rf, ok := ret.Get(0).(func() map[common.Address]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[common.Address]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemovePrivateKey provides a mock function with given fields: a
+func (_m *WalletDao) RemovePrivateKey(a common.Address) error {
+	ret := _m.Called(a)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Address) error); ok {
+		r0 = rf(a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -126,3 +126,31 @@ func (_m *WalletService) GetOperatorWallets() ([]*types.Wallet, error) {
 
 	return r0, r1
 }
+
+// MigratePrivateKeys provides a mock function with given fields: passphrase
+func (_m *WalletService) MigratePrivateKeys(passphrase string) error {
+	ret := _m.Called(passphrase)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(passphrase)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockWallets provides a mock function with given fields: passphrase
+func (_m *WalletService) UnlockWallets(passphrase string) error {
+	ret := _m.Called(passphrase)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(passphrase)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -50,7 +50,7 @@ func GetTestOrder2() types.Order {
 		Side:            "SELL",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1001),
 		TakeFee:         big.NewInt(50),
 		Signature: &types.Signature{
 			V: 28,
@@ -77,7 +77,7 @@ func GetTestOrder3() types.Order {
 		Side:            "SELL",
 		PairName:        "ZRX/WETH",
 		MakeFee:         big.NewInt(50),
-		Nonce:           big.NewInt(1000),
+		Nonce:           big.NewInt(1002),
 		TakeFee:         big.NewInt(50),
 		Signature: &types.Signature{
 			V: 28,
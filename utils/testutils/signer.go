@@ -0,0 +1,85 @@
+package testutils
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Proofsuite/amp-matching-engine/interfaces"
+	"github.com/Proofsuite/amp-matching-engine/signer"
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+	eth "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SignerService is a stand-in for an external signing service. It answers eth_signTransaction
+// requests by signing with the registered signers.
+type SignerService struct {
+	Signers  map[common.Address]interfaces.Signer
+	Requests []signer.SignTxArgs
+}
+
+// NewSignerServer returns an rpc server exposing a signing service for the given wallets
+func NewSignerServer(wallets ...*types.Wallet) (*rpc.Server, *SignerService) {
+	service := &SignerService{Signers: make(map[common.Address]interfaces.Signer)}
+	for _, w := range wallets {
+		service.Signers[w.Address] = signer.NewLocalSigner(w)
+	}
+
+	server := rpc.NewServer()
+	err := server.RegisterName("eth", service)
+	if err != nil {
+		panic(err)
+	}
+
+	return server, service
+}
+
+// NewTestRemoteSigner returns a remote signer for the given wallet connected in process
+// to a stand-in signing service
+func NewTestRemoteSigner(w *types.Wallet) (*signer.RemoteSigner, *SignerService) {
+	server, service := NewSignerServer(w)
+	client := rpc.DialInProc(server)
+
+	return signer.NewRemoteSignerFromClient(client, w.Address), service
+}
+
+// SignTransaction handles eth_signTransaction requests
+func (s *SignerService) SignTransaction(args signer.SignTxArgs) (*signer.SignTxResult, error) {
+	s.Requests = append(s.Requests, args)
+
+	sig, ok := s.Signers[args.From]
+	if !ok {
+		return nil, errors.New("unknown account")
+	}
+
+	value := big.NewInt(0)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	gasPrice := big.NewInt(0)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+
+	var tx *eth.Transaction
+	if args.To == nil {
+		tx = eth.NewContractCreation(uint64(args.Nonce), value, uint64(args.Gas), gasPrice, args.Data)
+	} else {
+		tx = eth.NewTransaction(uint64(args.Nonce), *args.To, value, uint64(args.Gas), gasPrice, args.Data)
+	}
+
+	signed, err := sig.SignTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signer.SignTxResult{Raw: raw, Tx: signed}, nil
+}
@@ -0,0 +1,113 @@
+package ws
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// loginNonceValidity is the duration during which a login nonce issued to a connection is accepted
+const loginNonceValidity = 5 * time.Minute
+
+// ErrNotAuthenticated is returned when a connection sends a message on behalf of an account
+// whose owner did not log in on the connection
+var ErrNotAuthenticated = errors.New("Connection is not authenticated for this account")
+
+// HandleAuthMessage handles the messages of the auth channel. A client requests a nonce with a
+// CHALLENGE message and logs in as an account owner by sending the nonce signed with the account
+// private key in a LOGIN message. The connection then receives the order events of the account.
+// Several accounts can be logged in on the same connection, LOGOUT removes one of them.
+func HandleAuthMessage(input interface{}, c *Client) {
+	b, _ := json.Marshal(input)
+	var ev *types.WebsocketEvent
+	if err := json.Unmarshal(b, &ev); err != nil || ev == nil {
+		logger.Error(err)
+		c.SendMessage(AuthChannel, "ERROR", "Invalid payload")
+		return
+	}
+
+	switch ev.Type {
+	case "CHALLENGE":
+		handleChallenge(c)
+	case "LOGIN":
+		handleLogin(ev, c)
+	case "LOGOUT":
+		handleLogout(ev, c)
+	default:
+		c.SendMessage(AuthChannel, "ERROR", "Invalid payload")
+	}
+}
+
+func handleChallenge(c *Client) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		logger.Error(err)
+		c.SendMessage(AuthChannel, "ERROR", "Could not create a challenge")
+		return
+	}
+
+	nonce := common.BytesToHash(b)
+	expiresAt := time.Now().Add(loginNonceValidity)
+	c.setNonce(nonce, expiresAt)
+
+	c.SendMessage(AuthChannel, "CHALLENGE", map[string]interface{}{
+		"nonce":     nonce.Hex(),
+		"expiresAt": expiresAt.Format(time.RFC3339Nano),
+	})
+}
+
+func handleLogin(ev *types.WebsocketEvent, c *Client) {
+	b, _ := json.Marshal(ev.Payload)
+	var l *types.WebsocketLogin
+	err := json.Unmarshal(b, &l)
+	if err != nil || l == nil {
+		logger.Error(err)
+		c.SendMessage(AuthChannel, "ERROR", "Invalid payload")
+		return
+	}
+
+	if !c.consumeNonce(l.Nonce) {
+		c.SendMessage(AuthChannel, "ERROR", "Invalid or expired nonce")
+		return
+	}
+
+	err = l.VerifySignature()
+	if err != nil {
+		logger.Error(err)
+		c.SendMessage(AuthChannel, "ERROR", err.Error())
+		return
+	}
+
+	c.authenticate(l.Address)
+	RegisterOrderConnection(l.Address, c)
+	c.SendMessage(AuthChannel, "LOGGED_IN", map[string]string{"address": l.Address.Hex()})
+}
+
+func handleLogout(ev *types.WebsocketEvent, c *Client) {
+	b, _ := json.Marshal(ev.Payload)
+	var p *struct {
+		Address common.Address `json:"address"`
+	}
+
+	err := json.Unmarshal(b, &p)
+	if err != nil || p == nil {
+		logger.Error(err)
+		c.SendMessage(AuthChannel, "ERROR", "Invalid payload")
+		return
+	}
+
+	if !c.IsAuthenticated(p.Address) {
+		c.SendMessage(AuthChannel, "ERROR", ErrNotAuthenticated.Error())
+		return
+	}
+
+	c.logout(p.Address)
+	OrderSocketUnsubscribeHandler(p.Address)(c)
+	UnsubscribeBalanceConnection(p.Address, c)
+	c.SendMessage(AuthChannel, "LOGGED_OUT", map[string]string{"address": p.Address.Hex()})
+}
@@ -0,0 +1,66 @@
+package ws
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// balanceConnections holds the connections subscribed to the balance updates of each account.
+// The balance channel is private: a connection is only registered for an account after the
+// account owner signed the subscription.
+var balanceConnections = make(map[common.Address][]*Client)
+var balanceMutex sync.Mutex
+
+// RegisterBalanceConnection registers a connection to the balance updates of an account
+func RegisterBalanceConnection(a common.Address, c *Client) {
+	balanceMutex.Lock()
+	defer balanceMutex.Unlock()
+
+	for _, conn := range balanceConnections[a] {
+		if conn == c {
+			return
+		}
+	}
+
+	balanceConnections[a] = append(balanceConnections[a], c)
+	RegisterConnectionUnsubscribeHandler(c, BalanceSocketUnsubscribeHandler(a))
+}
+
+// BalanceSocketUnsubscribeHandler returns a handler removing a connection from the balance updates of an account
+func BalanceSocketUnsubscribeHandler(a common.Address) func(c *Client) {
+	return func(c *Client) {
+		UnsubscribeBalanceConnection(a, c)
+	}
+}
+
+// UnsubscribeBalanceConnection removes a connection from the balance updates of an account
+func UnsubscribeBalanceConnection(a common.Address, c *Client) {
+	balanceMutex.Lock()
+	defer balanceMutex.Unlock()
+
+	conns := []*Client{}
+	for _, conn := range balanceConnections[a] {
+		if conn != c {
+			conns = append(conns, conn)
+		}
+	}
+
+	if len(conns) == 0 {
+		delete(balanceConnections, a)
+		return
+	}
+
+	balanceConnections[a] = conns
+}
+
+// SendBalanceMessage sends a message to every connection subscribed to the balance updates of an account
+func SendBalanceMessage(msgType string, a common.Address, payload interface{}) {
+	balanceMutex.Lock()
+	conns := append([]*Client{}, balanceConnections[a]...)
+	balanceMutex.Unlock()
+
+	for _, c := range conns {
+		c.SendMessage(BalanceChannel, msgType, payload)
+	}
+}
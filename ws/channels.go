@@ -11,6 +11,8 @@ const (
 	OrderChannel        = "orders"
 	OrderBookChannel    = "orderbook"
 	OHLCVChannel        = "ohlcv"
+	BalanceChannel      = "balances"
+	AuthChannel         = "auth"
 )
 
 var socketChannels map[string]func(interface{}, *Client)
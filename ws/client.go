@@ -2,26 +2,41 @@ package ws
 
 import (
 	"sync"
+	"time"
 
 	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/websocket"
 )
 
+// Client is a websocket connection. The addresses of the accounts whose owner logged in on the
+// connection are bound to the client, they are the only ones it receives private events for.
 type Client struct {
 	*websocket.Conn
 	mu   sync.Mutex
 	send chan types.WebsocketMessage
+	ip   string
+
+	authMu         sync.Mutex
+	addresses      map[common.Address]bool
+	nonce          common.Hash
+	nonceExpiresAt time.Time
 }
 
 // TODO: refactor into non-global variables
 var unsubscribeHandlers map[*Client][]func(*Client)
 var subscriptionMutex sync.Mutex
 
-func NewClient(c *websocket.Conn) *Client {
+func NewClient(c *websocket.Conn, ip string) *Client {
 	subscriptionMutex.Lock()
 	defer subscriptionMutex.Unlock()
-	conn := &Client{Conn: c, mu: sync.Mutex{}, send: make(chan types.WebsocketMessage)}
+	conn := &Client{
+		Conn:      c,
+		mu:        sync.Mutex{},
+		send:      make(chan types.WebsocketMessage),
+		ip:        ip,
+		addresses: make(map[common.Address]bool),
+	}
 
 	if unsubscribeHandlers == nil {
 		unsubscribeHandlers = make(map[*Client][]func(*Client))
@@ -55,6 +70,61 @@ func (c *Client) SendMessage(channel string, msgType string, payload interface{}
 	c.send <- m
 }
 
+// IsAuthenticated returns true if the owner of the account a logged in on the connection
+func (c *Client) IsAuthenticated(a common.Address) bool {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	return c.addresses[a]
+}
+
+// Addresses returns the addresses of the accounts logged in on the connection
+func (c *Client) Addresses() []common.Address {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	addresses := []common.Address{}
+	for a := range c.addresses {
+		addresses = append(addresses, a)
+	}
+
+	return addresses
+}
+
+// setNonce sets the login nonce of the connection, replacing the previous one
+func (c *Client) setNonce(n common.Hash, expiresAt time.Time) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	c.nonce = n
+	c.nonceExpiresAt = expiresAt
+}
+
+// consumeNonce returns true if n is the current login nonce of the connection and has not
+// expired. The nonce can not be used again.
+func (c *Client) consumeNonce(n common.Hash) bool {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	valid := (c.nonce != common.Hash{}) && c.nonce == n && time.Now().Before(c.nonceExpiresAt)
+	c.nonce = common.Hash{}
+	return valid
+}
+
+func (c *Client) authenticate(a common.Address) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	c.addresses[a] = true
+}
+
+func (c *Client) logout(a common.Address) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	delete(c.addresses, a)
+}
+
 func (c *Client) closeConnection() {
 	subscriptionMutex.Lock()
 	defer subscriptionMutex.Unlock()
@@ -66,15 +136,12 @@ func (c *Client) closeConnection() {
 	c.Close()
 }
 
-func (c *Client) SendOrderErrorMessage(err error, h common.Hash) {
-	p := map[string]interface{}{
-		"message": err.Error(),
-		"hash":    h.Hex(),
-	}
-
+// SendOrderErrorMessage sends an error about the order of the given hash. The client order ID
+// of the order is echoed if it is set.
+func (c *Client) SendOrderErrorMessage(err error, h common.Hash, clientOrderID string) {
 	e := types.WebsocketEvent{
 		Type:    "ERROR",
-		Payload: p,
+		Payload: orderErrorPayload(err, h, clientOrderID),
 	}
 
 	m := types.WebsocketMessage{
@@ -86,3 +153,16 @@ func (c *Client) SendOrderErrorMessage(err error, h common.Hash) {
 	defer c.mu.Unlock()
 	c.send <- m
 }
+
+func orderErrorPayload(err error, h common.Hash, clientOrderID string) map[string]interface{} {
+	p := map[string]interface{}{
+		"message": err.Error(),
+		"hash":    h.Hex(),
+	}
+
+	if clientOrderID != "" {
+		p["clientOrderId"] = clientOrderID
+	}
+
+	return p
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/httputils"
 	"github.com/gorilla/websocket"
 )
 
@@ -35,7 +36,7 @@ func ConnectionEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c := NewClient(conn)
+	c := NewClient(conn, httputils.ClientIP(r, trustProxyHeaders))
 	c.SetCloseHandler(closeHandler(c))
 
 	go readHandler(c)
@@ -83,6 +84,10 @@ func readHandler(c *Client) {
 			return
 		}
 
+		if !allowMessage(c, &msg) {
+			continue
+		}
+
 		go socketChannels[msg.Channel](msg.Event, c)
 	}
 }
@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"github.com/Proofsuite/amp-matching-engine/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -93,3 +94,8 @@ func SendOrderMessage(msgType string, a common.Address, payload interface{}) {
 		c.SendMessage(OrderChannel, msgType, payload)
 	}
 }
+
+// SendOrderErrorMessage sends an error about an order to all the order connections of an account
+func SendOrderErrorMessage(a common.Address, err error, o *types.Order) {
+	SendOrderMessage("ERROR", a, orderErrorPayload(err, o.Hash, o.ClientOrderID))
+}
@@ -0,0 +1,56 @@
+package ws
+
+import (
+	"math"
+
+	"github.com/Proofsuite/amp-matching-engine/types"
+	"github.com/Proofsuite/amp-matching-engine/utils/ratelimit"
+)
+
+var rateLimits ratelimit.Limits
+var trustProxyHeaders bool
+
+// SetRateLimits sets the rate limits applied to the incoming messages. trustProxy is set when
+// the client IP should be read from the X-Forwarded-For header of the connection request.
+func SetRateLimits(limits ratelimit.Limits, trustProxy bool) {
+	rateLimits = limits
+	trustProxyHeaders = trustProxy
+}
+
+// allowMessage takes a token from the rate limit buckets of the connection IP and, for the orders
+// and cancels, of the accounts logged in on the connection. A RATE_LIMITED event is sent back on
+// the channel of the message if it is refused.
+func allowMessage(c *Client, msg *types.WebsocketMessage) bool {
+	if rateLimits == nil {
+		return true
+	}
+
+	category := ratelimit.MarketData
+	if msg.Channel == OrderChannel {
+		switch msg.Event.Type {
+		case "NEW_ORDER":
+			category = ratelimit.Orders
+		case "CANCEL_ORDER":
+			category = ratelimit.Cancels
+		}
+	}
+
+	keys := []string{"ip:" + c.ip}
+	if category != ratelimit.MarketData {
+		for _, a := range c.Addresses() {
+			keys = append(keys, "address:"+a.Hex())
+		}
+	}
+
+	res := rateLimits.Allow(category, keys...)
+	if res.Allowed {
+		return true
+	}
+
+	c.SendMessage(msg.Channel, "RATE_LIMITED", map[string]interface{}{
+		"category":   category,
+		"retryAfter": int(math.Ceil(res.RetryAfter.Seconds() * 1000)),
+	})
+
+	return false
+}